@@ -0,0 +1,87 @@
+package mark
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	markstat "github.com/RealTimeMap/RealTimeMap-backend/pkg/pb/mark"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+type Config struct {
+	Address string        `yaml:"address" env:"MARK_QUERY_ADDRESS"`
+	Timeout time.Duration `yaml:"timeout" env:"MARK_QUERY_TIMEOUT"`
+}
+
+type Client struct {
+	conn    *grpc.ClientConn
+	api     markstat.MarkQueryServiceClient
+	timeout time.Duration
+}
+
+func NewClient(cfg Config) (*Client, error) {
+	conn, err := grpc.NewClient(cfg.Address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to MarkQueryService: %w", err)
+	}
+	return &Client{
+		conn:    conn,
+		api:     markstat.NewMarkQueryServiceClient(conn),
+		timeout: cfg.Timeout,
+	}, nil
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// GetMarksState состояние меток по списку id, в том же порядке что и запрос
+func (c *Client) GetMarksState(ctx context.Context, ids []uint) ([]MarkState, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	req := &markstat.MarksStateRequest{Ids: make([]uint64, 0, len(ids))}
+	for _, id := range ids {
+		req.Ids = append(req.Ids, uint64(id))
+	}
+	res, err := c.api.GetMarksState(ctx, req)
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+
+	states := make([]MarkState, 0, len(res.GetMarks()))
+	for _, m := range res.GetMarks() {
+		states = append(states, MarkState{
+			ID:      uint(m.GetId()),
+			Exists:  m.GetExists(),
+			Active:  m.GetActive(),
+			Ended:   m.GetEnded(),
+			OwnerID: uint(m.GetOwnerId()),
+		})
+	}
+	return states, nil
+}
+
+func wrapErr(err error) error {
+	if isUnavailable(err) {
+		return fmt.Errorf("%w: %v", ErrServiceUnavailable, err)
+	}
+	return err
+}
+
+func isUnavailable(err error) bool {
+	st, ok := status.FromError(err)
+	if !ok {
+		return false
+	}
+	switch st.Code() {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,5 @@
+package mark
+
+import "errors"
+
+var ErrServiceUnavailable = errors.New("mark-service unavailable")
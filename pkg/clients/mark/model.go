@@ -0,0 +1,10 @@
+package mark
+
+// MarkState состояние метки. Exists = false для отсутствующих, удаленных и скрытых меток
+type MarkState struct {
+	ID      uint
+	Exists  bool
+	Active  bool // Событие идет в данный момент
+	Ended   bool // Событие прошло или завершено досрочно
+	OwnerID uint
+}
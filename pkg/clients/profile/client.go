@@ -71,6 +71,35 @@ func (c *Client) GetUserProfileByIDs(ctx context.Context, ids []uint) ([]*UserPr
 	return out, nil
 }
 
+// GetUserProfilesByTags профили по тегам. Пользователи в блокировке с viewerID не возвращаются
+func (c *Client) GetUserProfilesByTags(ctx context.Context, tags []string, viewerID uint) ([]*UserProfile, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	resp, err := c.api.GetUserProfilesByTags(ctx, &pb.ProfilesByTagsRequest{Tags: tags, ViewerId: uint64(viewerID)})
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+
+	out := make([]*UserProfile, 0, len(resp.GetProfiles()))
+	for _, p := range resp.GetProfiles() {
+		out = append(out, toProfile(p))
+	}
+	return out, nil
+}
+
+// GetRelation дружба и блокировка между userID и otherID
+func (c *Client) GetRelation(ctx context.Context, userID, otherID uint) (*Relation, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	resp, err := c.api.GetRelation(ctx, &pb.RelationRequest{UserId: uint64(userID), OtherId: uint64(otherID)})
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+	return &Relation{Friends: resp.GetFriends(), Blocked: resp.GetBlocked()}, nil
+}
+
 func wrapErr(err error) error {
 	if isUnavailable(err) {
 		return fmt.Errorf("%w: %v", ErrUnavailable, err)
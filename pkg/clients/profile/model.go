@@ -6,3 +6,9 @@ type UserProfile struct {
 	Tag      string
 	Avatar   string
 }
+
+// Relation отношения между двумя пользователями
+type Relation struct {
+	Friends bool
+	Blocked bool // Блокировка в любую сторону
+}
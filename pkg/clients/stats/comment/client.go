@@ -0,0 +1,86 @@
+package comment
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/date"
+	commentstat "github.com/RealTimeMap/RealTimeMap-backend/pkg/pb/comment"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type Config struct {
+	Address string        `yaml:"address" env:"COMMENT_STATS_ADDRESS"`
+	Timeout time.Duration `yaml:"timeout" env:"COMMENT_STATS_TIMEOUT"`
+}
+
+type Client struct {
+	conn    *grpc.ClientConn
+	api     commentstat.CommentStatsServiceClient
+	timeout time.Duration
+}
+
+func NewClient(cfg Config) (*Client, error) {
+	conn, err := grpc.NewClient(cfg.Address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to CommentStatsService: %w", err)
+	}
+	return &Client{
+		conn:    conn,
+		api:     commentstat.NewCommentStatsServiceClient(conn),
+		timeout: cfg.Timeout,
+	}, nil
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// GetUserCommentStat комментарии пользователя за период. Для AllTime границы не передаются
+func (c *Client) GetUserCommentStat(ctx context.Context, userID uint, period date.Resolved) (*CommentStat, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	req := &commentstat.CommentCountRequest{UserId: uint64(userID)}
+	if start, end := period.Current(); start != nil && end != nil {
+		req.StartDate, req.EndDate = timestamppb.New(*start), timestamppb.New(*end)
+	}
+	if start, end := period.Previous(); start != nil && end != nil {
+		req.PrevStartDate, req.PrevEndDate = timestamppb.New(*start), timestamppb.New(*end)
+	}
+
+	res, err := c.api.GetUserCommentStat(ctx, req)
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+	return &CommentStat{
+		Count:     res.GetCount(),
+		PrevCount: res.GetPrevCount(),
+		Direction: res.GetDirection(),
+	}, nil
+}
+
+func wrapErr(err error) error {
+	if isUnavailable(err) {
+		return fmt.Errorf("%w: %v", ErrServiceUnavailable, err)
+	}
+	return err
+}
+
+func isUnavailable(err error) bool {
+	st, ok := status.FromError(err)
+	if !ok {
+		return false
+	}
+	switch st.Code() {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
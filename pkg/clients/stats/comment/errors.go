@@ -0,0 +1,5 @@
+package comment
+
+import "errors"
+
+var ErrServiceUnavailable = errors.New("comment-service unavailable")
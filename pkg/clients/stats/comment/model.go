@@ -0,0 +1,8 @@
+package comment
+
+// CommentStat количество комментариев за период в сравнении с предыдущим
+type CommentStat struct {
+	Count     uint64
+	PrevCount uint64
+	Direction string
+}
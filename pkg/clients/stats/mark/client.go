@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/date"
 	markstat "github.com/RealTimeMap/RealTimeMap-backend/pkg/pb/mark"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -84,6 +85,40 @@ func (c *Client) GetPopularUserCategories(ctx context.Context, userID uint, topN
 	return toPopularCategoriesResponse(res), nil
 }
 
+// GetUserEngagementStat лайки и переходы за период. Для AllTime границы не передаются
+func (c *Client) GetUserEngagementStat(ctx context.Context, userID uint, period date.Resolved) (*EngagementStat, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	req := &markstat.EngagementStatRequest{UserId: uint64(userID)}
+	if start, end := period.Current(); start != nil && end != nil {
+		req.StartDate, req.EndDate = timestamppb.New(*start), timestamppb.New(*end)
+	}
+	if start, end := period.Previous(); start != nil && end != nil {
+		req.PrevStartDate, req.PrevEndDate = timestamppb.New(*start), timestamppb.New(*end)
+	}
+
+	res, err := c.api.GetUserEngagementStat(ctx, req)
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+	return &EngagementStat{
+		Likes:  toPeriodStat(res.GetLikes()),
+		Shares: toPeriodStat(res.GetShares()),
+	}, nil
+}
+
+func (c *Client) GetUserCategoryMonthlySeries(ctx context.Context, userID uint, year, topN int) ([]*CategorySeries, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	res, err := c.api.GetUserCategoryMonthlySeries(ctx, &markstat.CategorySeriesRequest{UserId: uint64(userID), Year: int64(year), TopN: int64(topN)})
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+	return toCategorySeriesResponse(res), nil
+}
+
 func wrapErr(err error) error {
 	if isUnavailable(err) {
 		return fmt.Errorf("%w: %v", ErrServiceUnavailable, err)
@@ -141,3 +176,23 @@ func toPopularCategoriesResponse(data *markstat.PopularCategoriesResponse) []*Po
 	}
 	return res
 }
+
+func toPeriodStat(data *markstat.PeriodStat) PeriodStat {
+	return PeriodStat{
+		Count:     data.GetCount(),
+		PrevCount: data.GetPrevCount(),
+		Direction: data.GetDirection(),
+	}
+}
+
+func toCategorySeriesResponse(data *markstat.CategorySeriesResponse) []*CategorySeries {
+	res := make([]*CategorySeries, 0, len(data.GetSeries()))
+	for _, series := range data.GetSeries() {
+		res = append(res, &CategorySeries{
+			CategoryName: series.GetCategoryName(),
+			Total:        series.GetTotal(),
+			Months:       toMonthlyActivityResponse(&markstat.UserMarksActivityResponse{Activities: series.GetMonths()}),
+		})
+	}
+	return res
+}
@@ -21,3 +21,23 @@ type PopularCategory struct {
 	Count        int64
 	Percent      float64
 }
+
+// PeriodStat значение за период в сравнении с предыдущим
+type PeriodStat struct {
+	Count     uint64
+	PrevCount uint64
+	Direction string
+}
+
+// EngagementStat лайки и переходы по ссылкам, полученные метками пользователя
+type EngagementStat struct {
+	Likes  PeriodStat
+	Shares PeriodStat
+}
+
+// CategorySeries количество меток категории по месяцам года
+type CategorySeries struct {
+	CategoryName string
+	Total        int64
+	Months       []*MonthlyActivity
+}
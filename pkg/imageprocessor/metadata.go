@@ -0,0 +1,523 @@
+package imageprocessor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"time"
+
+	"github.com/disintegration/imaging"
+	"go.uber.org/zap"
+)
+
+var errInvalidExif = errors.New("invalid exif data")
+
+// ErrMalformedImage структуру файла не удалось разобрать, а перекодировать его нечем.
+// Сохранять такой файл нельзя: в нем могли остаться метаданные
+var ErrMalformedImage = errors.New("malformed image")
+
+// Metadata метаданные изображения, извлеченные из EXIF до их удаления
+type Metadata struct {
+	Orientation int        // EXIF ориентация (1-8), 0 если не указана
+	TakenAt     *time.Time // Время съемки (DateTimeOriginal)
+	Latitude    *float64   // Широта места съемки
+	Longitude   *float64   // Долгота места съемки
+}
+
+// HasLocation проверяет, есть ли в метаданных координаты
+func (m *Metadata) HasLocation() bool {
+	return m != nil && m.Latitude != nil && m.Longitude != nil
+}
+
+// StripMetadata удаляет EXIF/XMP и прочие метаданные из изображения.
+// Перед удалением применяется EXIF ориентация, чтобы изображение не "перевернулось" у клиентов
+// (WebP не перекодируется - в нем ориентация сохраняется отдельным минимальным EXIF).
+// WebP, структуру которого не удалось разобрать, отклоняется с ErrMalformedImage.
+// Возвращает очищенное изображение и метаданные, извлеченные до очистки
+func (p *Processor) StripMetadata(data []byte, mimeType string) ([]byte, *Metadata, error) {
+	var (
+		stripped []byte
+		exif     []byte
+		err      error
+	)
+	switch mimeType {
+	case "image/jpeg", "image/jpg":
+		stripped, exif, err = stripJPEG(data)
+	case "image/png":
+		stripped, exif, err = stripPNG(data)
+	case "image/webp":
+		stripped, exif, err = stripWebP(data)
+	default:
+		// GIF и прочие форматы не содержат EXIF
+		return data, &Metadata{}, nil
+	}
+	if err != nil && mimeType == "image/webp" {
+		// Кодировщика WebP нет, перекодировать нечем - файл отклоняется
+		return nil, nil, fmt.Errorf("%w: %v", ErrMalformedImage, err)
+	}
+	if err != nil {
+		// Не удалось разобрать структуру файла - перекодируем изображение целиком,
+		// это гарантированно отбрасывает все метаданные
+		p.logger.Warn("failed to strip metadata, re-encoding image", zap.Error(err))
+		reencoded, encErr := p.reencode(data, mimeType)
+		if encErr != nil {
+			return nil, nil, fmt.Errorf("%w: %v", ErrMalformedImage, err)
+		}
+		return reencoded, &Metadata{}, nil
+	}
+
+	meta := &Metadata{}
+	if len(exif) > 0 {
+		if parsed, err := parseExif(exif); err != nil {
+			p.logger.Warn("failed to parse exif", zap.Error(err))
+		} else {
+			meta = parsed
+		}
+	}
+
+	if meta.Orientation > 1 && mimeType == "image/webp" {
+		// WebP не перекодируется: вместо поворота пикселей возвращаем в файл
+		// минимальный EXIF, содержащий только тег ориентации
+		stripped = appendWebPOrientation(stripped, meta.Orientation)
+	} else if meta.Orientation > 1 {
+		oriented, err := p.applyOrientation(stripped, mimeType, meta.Orientation)
+		if err != nil {
+			p.logger.Warn("failed to apply exif orientation", zap.Error(err))
+		} else {
+			stripped = oriented
+		}
+	}
+
+	return stripped, meta, nil
+}
+
+// applyOrientation поворачивает/отражает изображение согласно EXIF ориентации
+func (p *Processor) applyOrientation(data []byte, mimeType string, orientation int) ([]byte, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	var oriented image.Image
+	switch orientation {
+	case 2:
+		oriented = imaging.FlipH(img)
+	case 3:
+		oriented = imaging.Rotate180(img)
+	case 4:
+		oriented = imaging.FlipV(img)
+	case 5:
+		oriented = imaging.Transpose(img)
+	case 6:
+		oriented = imaging.Rotate270(img)
+	case 7:
+		oriented = imaging.Transverse(img)
+	case 8:
+		oriented = imaging.Rotate90(img)
+	default:
+		return data, nil
+	}
+
+	return encode(oriented, mimeType)
+}
+
+// reencode декодирует и заново кодирует изображение без метаданных
+func (p *Processor) reencode(data []byte, mimeType string) ([]byte, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+	return encode(img, mimeType)
+}
+
+// encode кодирует изображение в исходный формат (кодировщика WebP нет - для него возвращается ошибка)
+func encode(img image.Image, mimeType string) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+	switch mimeType {
+	case "image/jpeg", "image/jpg":
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: 90})
+	case "image/png":
+		err = png.Encode(&buf, img)
+	default:
+		return nil, fmt.Errorf("unsupported mime type for encoding: %s", mimeType)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode image: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// stripJPEG удаляет из JPEG сегменты APP1 (EXIF/XMP), APP13 (IPTC) и комментарии.
+// Возвращает очищенные данные и содержимое EXIF (TIFF структура), если оно было
+func stripJPEG(data []byte) ([]byte, []byte, error) {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return nil, nil, errors.New("invalid jpeg header")
+	}
+
+	out := make([]byte, 0, len(data))
+	out = append(out, 0xFF, 0xD8)
+	var exif []byte
+
+	pos := 2
+	for pos < len(data) {
+		if data[pos] != 0xFF {
+			return nil, nil, fmt.Errorf("invalid jpeg marker at %d", pos)
+		}
+		// Пропуск заполняющих 0xFF
+		for pos < len(data) && data[pos] == 0xFF {
+			pos++
+		}
+		if pos >= len(data) {
+			return nil, nil, errors.New("unexpected end of jpeg")
+		}
+		marker := data[pos]
+		pos++
+
+		// Маркеры без длины
+		if marker == 0xD8 || marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7) {
+			out = append(out, 0xFF, marker)
+			continue
+		}
+		if marker == 0xD9 {
+			out = append(out, 0xFF, marker)
+			return out, exif, nil
+		}
+
+		if pos+2 > len(data) {
+			return nil, nil, errors.New("unexpected end of jpeg")
+		}
+		length := int(binary.BigEndian.Uint16(data[pos : pos+2]))
+		if length < 2 || pos+length > len(data) {
+			return nil, nil, fmt.Errorf("invalid jpeg segment length at %d", pos)
+		}
+		segment := data[pos+2 : pos+length]
+
+		// Начало сжатых данных - дальше метаданных нет, копируем остаток как есть
+		if marker == 0xDA {
+			out = append(out, 0xFF, marker)
+			out = append(out, data[pos:]...)
+			return out, exif, nil
+		}
+
+		switch marker {
+		case 0xE1: // APP1: EXIF или XMP
+			if exif == nil && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+				exif = segment[6:]
+			}
+		case 0xED, 0xFE: // APP13 (IPTC) и комментарии
+		default:
+			out = append(out, 0xFF, marker)
+			out = append(out, data[pos:pos+length]...)
+		}
+		pos += length
+	}
+	return out, exif, nil
+}
+
+// stripPNG удаляет из PNG чанки с метаданными (eXIf, текстовые чанки, tIME)
+func stripPNG(data []byte) ([]byte, []byte, error) {
+	const signatureLen = 8
+	if len(data) < signatureLen || !bytes.Equal(data[:signatureLen], []byte("\x89PNG\r\n\x1a\n")) {
+		return nil, nil, errors.New("invalid png header")
+	}
+
+	out := make([]byte, 0, len(data))
+	out = append(out, data[:signatureLen]...)
+	var exif []byte
+
+	pos := signatureLen
+	for pos < len(data) {
+		if pos+8 > len(data) {
+			return nil, nil, errors.New("unexpected end of png")
+		}
+		length := int(binary.BigEndian.Uint32(data[pos : pos+4]))
+		chunkType := string(data[pos+4 : pos+8])
+		end := pos + 12 + length // длина + тип + данные + CRC
+		if end > len(data) {
+			return nil, nil, fmt.Errorf("invalid png chunk length at %d", pos)
+		}
+
+		switch chunkType {
+		case "eXIf":
+			exif = data[pos+8 : pos+8+length]
+		case "tEXt", "zTXt", "iTXt", "tIME":
+		default:
+			out = append(out, data[pos:end]...)
+		}
+		pos = end
+		if chunkType == "IEND" {
+			break
+		}
+	}
+	return out, exif, nil
+}
+
+// stripWebP удаляет из WebP чанки EXIF и XMP и снимает соответствующие флаги в VP8X
+func stripWebP(data []byte) ([]byte, []byte, error) {
+	if len(data) < 12 || string(data[0:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return nil, nil, errors.New("invalid webp header")
+	}
+
+	out := make([]byte, 12, len(data))
+	copy(out, data[:12])
+	var exif []byte
+
+	pos := 12
+	for pos+8 <= len(data) {
+		fourCC := string(data[pos : pos+4])
+		size := int(binary.LittleEndian.Uint32(data[pos+4 : pos+8]))
+		end := pos + 8 + size + size%2 // чанки выровнены по четной границе
+		if end > len(data) {
+			// Последний чанк может быть без выравнивающего байта
+			if pos+8+size != len(data) {
+				return nil, nil, fmt.Errorf("invalid webp chunk size at %d", pos)
+			}
+			end = len(data)
+		}
+
+		switch fourCC {
+		case "EXIF":
+			exif = bytes.TrimPrefix(data[pos+8:pos+8+size], []byte("Exif\x00\x00"))
+		case "XMP ":
+		case "VP8X":
+			start := len(out)
+			out = append(out, data[pos:end]...)
+			if size > 0 {
+				out[start+8] &^= 0x08 | 0x04 // флаги EXIF и XMP
+			}
+		default:
+			out = append(out, data[pos:end]...)
+		}
+		pos = end
+	}
+
+	binary.LittleEndian.PutUint32(out[4:8], uint32(len(out)-8))
+	return out, exif, nil
+}
+
+// appendWebPOrientation добавляет в очищенный WebP чанк EXIF только с тегом ориентации.
+// EXIF допустим лишь в расширенном формате (VP8X), без него файл возвращается без изменений
+func appendWebPOrientation(data []byte, orientation int) []byte {
+	pos := 12
+	vp8x := -1
+	for pos+8 <= len(data) {
+		size := int(binary.LittleEndian.Uint32(data[pos+4 : pos+8]))
+		if string(data[pos:pos+4]) == "VP8X" && size > 0 {
+			vp8x = pos
+			break
+		}
+		pos += 8 + size + size%2
+	}
+	if vp8x < 0 {
+		return data
+	}
+
+	// TIFF (little endian): заголовок, IFD0 с одной записью Orientation (SHORT) и нулевой ссылкой на следующий IFD
+	exif := make([]byte, 26)
+	copy(exif, "II*\x00")
+	binary.LittleEndian.PutUint32(exif[4:8], 8)
+	binary.LittleEndian.PutUint16(exif[8:10], 1)
+	binary.LittleEndian.PutUint16(exif[10:12], tagOrientation)
+	binary.LittleEndian.PutUint16(exif[12:14], 3)
+	binary.LittleEndian.PutUint32(exif[14:18], 1)
+	binary.LittleEndian.PutUint16(exif[18:20], uint16(orientation))
+
+	out := make([]byte, 0, len(data)+8+len(exif))
+	out = append(out, data...)
+	if len(out)%2 != 0 {
+		out = append(out, 0) // выравнивание последнего чанка
+	}
+	out = append(out, "EXIF"...)
+	out = binary.LittleEndian.AppendUint32(out, uint32(len(exif)))
+	out = append(out, exif...)
+
+	out[vp8x+8] |= 0x08
+	binary.LittleEndian.PutUint32(out[4:8], uint32(len(out)-8))
+	return out
+}
+
+// Теги EXIF, которые нас интересуют
+const (
+	tagOrientation      = 0x0112
+	tagDateTime         = 0x0132
+	tagExifIFD          = 0x8769
+	tagGPSIFD           = 0x8825
+	tagDateTimeOriginal = 0x9003
+	tagGPSLatitudeRef   = 0x0001
+	tagGPSLatitude      = 0x0002
+	tagGPSLongitudeRef  = 0x0003
+	tagGPSLongitude     = 0x0004
+)
+
+// exifDateLayout формат даты в EXIF
+const exifDateLayout = "2006:01:02 15:04:05"
+
+type ifdEntry struct {
+	typ   uint16
+	count uint32
+	value []byte // сырые байты значения
+}
+
+type tiffReader struct {
+	data  []byte
+	order binary.ByteOrder
+}
+
+// parseExif разбирает TIFF структуру EXIF и извлекает ориентацию, время съемки и координаты
+func parseExif(data []byte) (*Metadata, error) {
+	if len(data) < 8 {
+		return nil, errInvalidExif
+	}
+	r := &tiffReader{data: data}
+	switch string(data[:2]) {
+	case "II":
+		r.order = binary.LittleEndian
+	case "MM":
+		r.order = binary.BigEndian
+	default:
+		return nil, errInvalidExif
+	}
+	if r.order.Uint16(data[2:4]) != 42 {
+		return nil, errInvalidExif
+	}
+
+	ifd0, err := r.readIFD(r.order.Uint32(data[4:8]))
+	if err != nil {
+		return nil, err
+	}
+
+	meta := &Metadata{}
+	if e, ok := ifd0[tagOrientation]; ok {
+		if v, ok := r.uint(e); ok && v >= 1 && v <= 8 {
+			meta.Orientation = int(v)
+		}
+	}
+
+	takenAt := r.ascii(ifd0[tagDateTime])
+	if e, ok := ifd0[tagExifIFD]; ok {
+		if offset, ok := r.uint(e); ok {
+			if exifIFD, err := r.readIFD(offset); err == nil {
+				if original := r.ascii(exifIFD[tagDateTimeOriginal]); original != "" {
+					takenAt = original
+				}
+			}
+		}
+	}
+	if t, err := time.Parse(exifDateLayout, takenAt); err == nil {
+		meta.TakenAt = &t
+	}
+
+	if e, ok := ifd0[tagGPSIFD]; ok {
+		if offset, ok := r.uint(e); ok {
+			if gps, err := r.readIFD(offset); err == nil {
+				lat, latOk := r.coordinate(gps[tagGPSLatitude], r.ascii(gps[tagGPSLatitudeRef]), "S")
+				lon, lonOk := r.coordinate(gps[tagGPSLongitude], r.ascii(gps[tagGPSLongitudeRef]), "W")
+				if latOk && lonOk && lat >= -90 && lat <= 90 && lon >= -180 && lon <= 180 {
+					meta.Latitude = &lat
+					meta.Longitude = &lon
+				}
+			}
+		}
+	}
+
+	return meta, nil
+}
+
+// typeSize размер одного значения для типов TIFF
+func typeSize(typ uint16) int {
+	switch typ {
+	case 1, 2, 6, 7: // BYTE, ASCII, SBYTE, UNDEFINED
+		return 1
+	case 3, 8: // SHORT, SSHORT
+		return 2
+	case 4, 9, 11: // LONG, SLONG, FLOAT
+		return 4
+	case 5, 10, 12: // RATIONAL, SRATIONAL, DOUBLE
+		return 8
+	default:
+		return 0
+	}
+}
+
+func (r *tiffReader) readIFD(offset uint32) (map[uint16]ifdEntry, error) {
+	start := int(offset)
+	if start < 0 || start+2 > len(r.data) {
+		return nil, errInvalidExif
+	}
+	count := int(r.order.Uint16(r.data[start : start+2]))
+	if start+2+count*12 > len(r.data) {
+		return nil, errInvalidExif
+	}
+
+	entries := make(map[uint16]ifdEntry, count)
+	for i := 0; i < count; i++ {
+		raw := r.data[start+2+i*12 : start+2+(i+1)*12]
+		tag := r.order.Uint16(raw[0:2])
+		typ := r.order.Uint16(raw[2:4])
+		n := r.order.Uint32(raw[4:8])
+
+		size := typeSize(typ) * int(n)
+		if size <= 0 {
+			continue
+		}
+		var value []byte
+		if size <= 4 {
+			value = raw[8 : 8+size]
+		} else {
+			valueOffset := int(r.order.Uint32(raw[8:12]))
+			if valueOffset < 0 || valueOffset+size > len(r.data) {
+				continue
+			}
+			value = r.data[valueOffset : valueOffset+size]
+		}
+		entries[tag] = ifdEntry{typ: typ, count: n, value: value}
+	}
+	return entries, nil
+}
+
+// uint читает первое целочисленное значение (SHORT или LONG)
+func (r *tiffReader) uint(e ifdEntry) (uint32, bool) {
+	switch e.typ {
+	case 3:
+		return uint32(r.order.Uint16(e.value)), true
+	case 4:
+		return r.order.Uint32(e.value), true
+	default:
+		return 0, false
+	}
+}
+
+// ascii читает строковое значение без завершающего нуля
+func (r *tiffReader) ascii(e ifdEntry) string {
+	if e.typ != 2 {
+		return ""
+	}
+	return strings.TrimRight(string(e.value), "\x00 ")
+}
+
+// coordinate переводит градусы/минуты/секунды (3 RATIONAL) в десятичные градусы
+func (r *tiffReader) coordinate(e ifdEntry, ref, negativeRef string) (float64, bool) {
+	if e.typ != 5 || e.count < 3 {
+		return 0, false
+	}
+	var parts [3]float64
+	for i := range parts {
+		num := r.order.Uint32(e.value[i*8 : i*8+4])
+		den := r.order.Uint32(e.value[i*8+4 : i*8+8])
+		if den == 0 {
+			return 0, false
+		}
+		parts[i] = float64(num) / float64(den)
+	}
+	value := parts[0] + parts[1]/60 + parts[2]/3600
+	if strings.EqualFold(ref, negativeRef) {
+		value = -value
+	}
+	return value, true
+}
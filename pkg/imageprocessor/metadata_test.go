@@ -0,0 +1,342 @@
+package imageprocessor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// exifFixture параметры тестового EXIF
+type exifFixture struct {
+	order       binary.ByteOrder
+	orientation uint16
+	takenAt     string
+	latRef      string
+	lat         [3]uint32 // градусы, минуты, секунды
+	lonRef      string
+	lon         [3]uint32
+}
+
+// buildExif собирает TIFF структуру: IFD0 (Orientation, ExifIFD, GPSIFD), Exif IFD (DateTimeOriginal) и GPS IFD
+func buildExif(f exifFixture) []byte {
+	const (
+		ifd0Offset = 8
+		exifOffset = ifd0Offset + 2 + 3*12 + 4
+		dateOffset = exifOffset + 2 + 12 + 4
+		gpsOffset  = dateOffset + 20
+		latOffset  = gpsOffset + 2 + 4*12 + 4
+		lonOffset  = latOffset + 24
+		total      = lonOffset + 24
+	)
+	o := f.order
+	buf := make([]byte, total)
+	if o == binary.LittleEndian {
+		copy(buf, "II")
+	} else {
+		copy(buf, "MM")
+	}
+	o.PutUint16(buf[2:4], 42)
+	o.PutUint32(buf[4:8], ifd0Offset)
+
+	entry := func(pos int, tag, typ uint16, count uint32, value func([]byte)) {
+		o.PutUint16(buf[pos:pos+2], tag)
+		o.PutUint16(buf[pos+2:pos+4], typ)
+		o.PutUint32(buf[pos+4:pos+8], count)
+		value(buf[pos+8 : pos+12])
+	}
+	long := func(v uint32) func([]byte) { return func(b []byte) { o.PutUint32(b, v) } }
+
+	o.PutUint16(buf[ifd0Offset:], 3)
+	entry(ifd0Offset+2, tagOrientation, 3, 1, func(b []byte) { o.PutUint16(b, f.orientation) })
+	entry(ifd0Offset+14, tagExifIFD, 4, 1, long(exifOffset))
+	entry(ifd0Offset+26, tagGPSIFD, 4, 1, long(gpsOffset))
+
+	o.PutUint16(buf[exifOffset:], 1)
+	entry(exifOffset+2, tagDateTimeOriginal, 2, 20, long(dateOffset))
+	copy(buf[dateOffset:], f.takenAt)
+
+	o.PutUint16(buf[gpsOffset:], 4)
+	entry(gpsOffset+2, tagGPSLatitudeRef, 2, 2, func(b []byte) { copy(b, f.latRef) })
+	entry(gpsOffset+14, tagGPSLatitude, 5, 3, long(latOffset))
+	entry(gpsOffset+26, tagGPSLongitudeRef, 2, 2, func(b []byte) { copy(b, f.lonRef) })
+	entry(gpsOffset+38, tagGPSLongitude, 5, 3, long(lonOffset))
+	for i := 0; i < 3; i++ {
+		o.PutUint32(buf[latOffset+i*8:], f.lat[i])
+		o.PutUint32(buf[latOffset+i*8+4:], 1)
+		o.PutUint32(buf[lonOffset+i*8:], f.lon[i])
+		o.PutUint32(buf[lonOffset+i*8+4:], 1)
+	}
+	return buf
+}
+
+var (
+	moscowExif = exifFixture{
+		order:       binary.LittleEndian,
+		orientation: 6,
+		takenAt:     "2024:05:09 10:30:00",
+		latRef:      "N",
+		lat:         [3]uint32{55, 45, 0},
+		lonRef:      "E",
+		lon:         [3]uint32{37, 37, 12},
+	}
+	santiagoExif = exifFixture{
+		order:       binary.BigEndian,
+		orientation: 1,
+		takenAt:     "2023:12:31 23:59:59",
+		latRef:      "S",
+		lat:         [3]uint32{33, 27, 0},
+		lonRef:      "W",
+		lon:         [3]uint32{70, 39, 36},
+	}
+)
+
+const xmpPacket = `<x:xmpmeta xmlns:x="adobe:ns:meta/"><rdf:RDF/></x:xmpmeta>`
+
+// testImage изображение 4x2, чтобы поворот менял размеры
+func testImage() image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	for x := 0; x < 4; x++ {
+		for y := 0; y < 2; y++ {
+			img.Set(x, y, color.RGBA{R: uint8(x * 60), G: uint8(y * 120), A: 255})
+		}
+	}
+	return img
+}
+
+func jpegSegment(marker byte, payload []byte) []byte {
+	seg := []byte{0xFF, marker, 0, 0}
+	binary.BigEndian.PutUint16(seg[2:], uint16(len(payload)+2))
+	return append(seg, payload...)
+}
+
+// buildJPEG кодирует тестовое изображение и вставляет после SOI сегменты EXIF, XMP и комментарий
+func buildJPEG(t *testing.T, exif []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, testImage(), nil); err != nil {
+		t.Fatal(err)
+	}
+	encoded := buf.Bytes()
+
+	out := []byte{0xFF, 0xD8}
+	out = append(out, jpegSegment(0xE1, append([]byte("Exif\x00\x00"), exif...))...)
+	out = append(out, jpegSegment(0xE1, append([]byte("http://ns.adobe.com/xap/1.0/\x00"), xmpPacket...))...)
+	out = append(out, jpegSegment(0xFE, []byte("secret comment"))...)
+	return append(out, encoded[2:]...)
+}
+
+func pngChunk(typ string, data []byte) []byte {
+	chunk := binary.BigEndian.AppendUint32(nil, uint32(len(data)))
+	chunk = append(chunk, typ...)
+	chunk = append(chunk, data...)
+	return binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(chunk[4:]))
+}
+
+// buildPNG кодирует тестовое изображение и вставляет после IHDR чанки eXIf, iTXt (XMP) и tEXt
+func buildPNG(t *testing.T, exif []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, testImage()); err != nil {
+		t.Fatal(err)
+	}
+	encoded := buf.Bytes()
+	const ihdrEnd = 8 + 12 + 13
+
+	out := append([]byte{}, encoded[:ihdrEnd]...)
+	out = append(out, pngChunk("eXIf", exif)...)
+	out = append(out, pngChunk("iTXt", append([]byte("XML:com.adobe.xmp\x00\x00\x00\x00\x00"), xmpPacket...))...)
+	out = append(out, pngChunk("tEXt", []byte("Comment\x00secret comment"))...)
+	return append(out, encoded[ihdrEnd:]...)
+}
+
+func webpChunk(fourCC string, data []byte) []byte {
+	chunk := append([]byte(fourCC), binary.LittleEndian.AppendUint32(nil, uint32(len(data)))...)
+	chunk = append(chunk, data...)
+	if len(data)%2 != 0 {
+		chunk = append(chunk, 0)
+	}
+	return chunk
+}
+
+// buildWebP собирает расширенный WebP (VP8X) с чанками EXIF и XMP.
+// Данные кадра не декодируются при очистке, поэтому вместо них заглушка нечетной длины
+func buildWebP(exif []byte) []byte {
+	vp8x := make([]byte, 10)
+	vp8x[0] = 0x08 | 0x04
+	vp8x[4] = 3 // ширина - 1
+	vp8x[7] = 1 // высота - 1
+
+	body := []byte("WEBP")
+	body = append(body, webpChunk("VP8X", vp8x)...)
+	body = append(body, webpChunk("VP8L", []byte{0x2F, 1, 2, 3, 4})...)
+	body = append(body, webpChunk("EXIF", exif)...)
+	body = append(body, webpChunk("XMP ", []byte(xmpPacket))...)
+
+	out := append([]byte("RIFF"), binary.LittleEndian.AppendUint32(nil, uint32(len(body)))...)
+	return append(out, body...)
+}
+
+func TestParseExif(t *testing.T) {
+	tests := []struct {
+		name            string
+		data            []byte
+		wantErr         bool
+		wantOrientation int
+		wantTakenAt     string
+		wantLat         float64
+		wantLon         float64
+	}{
+		{"little endian, северное и восточное полушарие", buildExif(moscowExif), false, 6, "2024:05:09 10:30:00", 55.75, 37.62},
+		{"big endian, южное и западное полушарие", buildExif(santiagoExif), false, 1, "2023:12:31 23:59:59", -33.45, -70.66},
+		{"неизвестный порядок байт", append([]byte("XX"), buildExif(moscowExif)[2:]...), true, 0, "", 0, 0},
+		{"слишком короткие данные", []byte("II*"), true, 0, "", 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meta, err := parseExif(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseExif() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if meta.Orientation != tt.wantOrientation {
+				t.Errorf("Orientation = %d, want %d", meta.Orientation, tt.wantOrientation)
+			}
+			if meta.TakenAt == nil || meta.TakenAt.Format(exifDateLayout) != tt.wantTakenAt {
+				t.Errorf("TakenAt = %v, want %s", meta.TakenAt, tt.wantTakenAt)
+			}
+			if !meta.HasLocation() || !almostEqual(*meta.Latitude, tt.wantLat) || !almostEqual(*meta.Longitude, tt.wantLon) {
+				t.Fatalf("location = %v/%v, want %v/%v", meta.Latitude, meta.Longitude, tt.wantLat, tt.wantLon)
+			}
+		})
+	}
+}
+
+func TestStripMetadata(t *testing.T) {
+	p := NewProcessor(zap.NewNop())
+	takenAt := time.Date(2024, 5, 9, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		data       []byte
+		mimeType   string
+		wantWidth  int // 0 - размеры не проверяются
+		wantHeight int
+	}{
+		{"jpeg с EXIF, XMP и комментарием", buildJPEG(t, buildExif(moscowExif)), "image/jpeg", 2, 4},
+		{"png с eXIf, XMP и текстом", buildPNG(t, buildExif(moscowExif)), "image/png", 2, 4},
+		{"webp с EXIF и XMP", buildWebP(buildExif(moscowExif)), "image/webp", 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stripped, meta, err := p.StripMetadata(tt.data, tt.mimeType)
+			if err != nil {
+				t.Fatalf("StripMetadata() error = %v", err)
+			}
+
+			if meta.Orientation != 6 {
+				t.Errorf("Orientation = %d, want 6", meta.Orientation)
+			}
+			if meta.TakenAt == nil || !meta.TakenAt.Equal(takenAt) {
+				t.Errorf("TakenAt = %v, want %v", meta.TakenAt, takenAt)
+			}
+			if !meta.HasLocation() || !almostEqual(*meta.Latitude, 55.75) || !almostEqual(*meta.Longitude, 37.62) {
+				t.Errorf("location = %v/%v, want 55.75/37.62", meta.Latitude, meta.Longitude)
+			}
+
+			for _, leaked := range []string{"xmpmeta", "secret comment", "2024:05:09"} {
+				if bytes.Contains(stripped, []byte(leaked)) {
+					t.Errorf("stripped image still contains %q", leaked)
+				}
+			}
+
+			if tt.wantWidth == 0 {
+				return
+			}
+			if bytes.Contains(stripped, []byte("Exif")) || bytes.Contains(stripped, []byte("eXIf")) {
+				t.Error("stripped image still contains exif")
+			}
+			cfg, _, err := image.DecodeConfig(bytes.NewReader(stripped))
+			if err != nil {
+				t.Fatalf("stripped image is not decodable: %v", err)
+			}
+			if cfg.Width != tt.wantWidth || cfg.Height != tt.wantHeight {
+				t.Errorf("size = %dx%d, want %dx%d (orientation not applied)", cfg.Width, cfg.Height, tt.wantWidth, tt.wantHeight)
+			}
+		})
+	}
+}
+
+func TestStripMetadataWebPOrientation(t *testing.T) {
+	p := NewProcessor(zap.NewNop())
+
+	stripped, _, err := p.StripMetadata(buildWebP(buildExif(moscowExif)), "image/webp")
+	if err != nil {
+		t.Fatalf("StripMetadata() error = %v", err)
+	}
+	if got := binary.LittleEndian.Uint32(stripped[4:8]); int(got) != len(stripped)-8 {
+		t.Errorf("RIFF size = %d, want %d", got, len(stripped)-8)
+	}
+	if stripped[20]&0x08 == 0 || stripped[20]&0x04 != 0 {
+		t.Errorf("VP8X flags = %#x, want EXIF set and XMP cleared", stripped[20])
+	}
+
+	// В файле остается только ориентация
+	_, exif, err := stripWebP(stripped)
+	if err != nil {
+		t.Fatalf("stripWebP() error = %v", err)
+	}
+	meta, err := parseExif(exif)
+	if err != nil {
+		t.Fatalf("parseExif() error = %v", err)
+	}
+	if meta.Orientation != 6 || meta.TakenAt != nil || meta.HasLocation() {
+		t.Errorf("retagged exif = %+v, want orientation only", meta)
+	}
+}
+
+func TestStripMetadataMalformed(t *testing.T) {
+	p := NewProcessor(zap.NewNop())
+
+	webp := buildWebP(buildExif(moscowExif))
+	binary.LittleEndian.PutUint32(webp[16:20], 1<<20) // размер VP8X больше файла
+	jpg := buildJPEG(t, buildExif(moscowExif))
+
+	tests := []struct {
+		name     string
+		data     []byte
+		mimeType string
+	}{
+		{"webp с неверным размером чанка", webp, "image/webp"},
+		{"обрезанный jpeg", jpg[:40], "image/jpeg"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stripped, _, err := p.StripMetadata(tt.data, tt.mimeType)
+			if !errors.Is(err, ErrMalformedImage) {
+				t.Fatalf("StripMetadata() error = %v, want ErrMalformedImage", err)
+			}
+			if stripped != nil {
+				t.Error("malformed image must not be returned")
+			}
+		})
+	}
+}
+
+func almostEqual(a, b float64) bool {
+	const eps = 1e-6
+	return a-b < eps && b-a < eps
+}
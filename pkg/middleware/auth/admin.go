@@ -33,6 +33,10 @@ func AdminOnly() gin.HandlerFunc {
 		// Сохраняем типизированные значения
 		c.Set(UserIDKey, userID)
 		c.Set(UserIsAdminKey, isAdmin)
+		// Имя не обязательно для админских запросов, но нужно для журналов действий
+		if userName := c.GetHeader("X-User-Name"); userName != "" {
+			c.Set(UsernameKey, userName)
+		}
 		c.Next()
 	}
 }
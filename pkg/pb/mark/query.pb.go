@@ -0,0 +1,259 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v7.34.1
+// source: mark/query.proto
+
+package markstat
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type MarksStateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []uint64               `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarksStateRequest) Reset() {
+	*x = MarksStateRequest{}
+	mi := &file_mark_query_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarksStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarksStateRequest) ProtoMessage() {}
+
+func (x *MarksStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mark_query_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarksStateRequest.ProtoReflect.Descriptor instead.
+func (*MarksStateRequest) Descriptor() ([]byte, []int) {
+	return file_mark_query_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *MarksStateRequest) GetIds() []uint64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+// exists = false для отсутствующих, удаленных и скрытых модератором меток
+type MarkState struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Exists        bool                   `protobuf:"varint,2,opt,name=exists,proto3" json:"exists,omitempty"`
+	Active        bool                   `protobuf:"varint,3,opt,name=active,proto3" json:"active,omitempty"`
+	Ended         bool                   `protobuf:"varint,4,opt,name=ended,proto3" json:"ended,omitempty"`
+	OwnerId       uint64                 `protobuf:"varint,5,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkState) Reset() {
+	*x = MarkState{}
+	mi := &file_mark_query_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkState) ProtoMessage() {}
+
+func (x *MarkState) ProtoReflect() protoreflect.Message {
+	mi := &file_mark_query_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkState.ProtoReflect.Descriptor instead.
+func (*MarkState) Descriptor() ([]byte, []int) {
+	return file_mark_query_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MarkState) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *MarkState) GetExists() bool {
+	if x != nil {
+		return x.Exists
+	}
+	return false
+}
+
+func (x *MarkState) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *MarkState) GetEnded() bool {
+	if x != nil {
+		return x.Ended
+	}
+	return false
+}
+
+func (x *MarkState) GetOwnerId() uint64 {
+	if x != nil {
+		return x.OwnerId
+	}
+	return 0
+}
+
+// Состояние возвращается для каждого запрошенного id в том же порядке
+type MarksStateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Marks         []*MarkState           `protobuf:"bytes,1,rep,name=marks,proto3" json:"marks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarksStateResponse) Reset() {
+	*x = MarksStateResponse{}
+	mi := &file_mark_query_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarksStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarksStateResponse) ProtoMessage() {}
+
+func (x *MarksStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mark_query_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarksStateResponse.ProtoReflect.Descriptor instead.
+func (*MarksStateResponse) Descriptor() ([]byte, []int) {
+	return file_mark_query_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MarksStateResponse) GetMarks() []*MarkState {
+	if x != nil {
+		return x.Marks
+	}
+	return nil
+}
+
+var File_mark_query_proto protoreflect.FileDescriptor
+
+const file_mark_query_proto_rawDesc = "" +
+	"\n" +
+	"\x10mark/query.proto\x12\bmarkstat\"%\n" +
+	"\x11MarksStateRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\x04R\x03ids\"|\n" +
+	"\tMarkState\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x16\n" +
+	"\x06exists\x18\x02 \x01(\bR\x06exists\x12\x16\n" +
+	"\x06active\x18\x03 \x01(\bR\x06active\x12\x14\n" +
+	"\x05ended\x18\x04 \x01(\bR\x05ended\x12\x19\n" +
+	"\bowner_id\x18\x05 \x01(\x04R\aownerId\"?\n" +
+	"\x12MarksStateResponse\x12)\n" +
+	"\x05marks\x18\x01 \x03(\v2\x13.markstat.MarkStateR\x05marks2^\n" +
+	"\x10MarkQueryService\x12J\n" +
+	"\rGetMarksState\x12\x1b.markstat.MarksStateRequest\x1a\x1c.markstat.MarksStateResponseB<Z:github.com/RealTimeMap/RealTimeMap-backend/pkg/pb/markstatb\x06proto3"
+
+var (
+	file_mark_query_proto_rawDescOnce sync.Once
+	file_mark_query_proto_rawDescData []byte
+)
+
+func file_mark_query_proto_rawDescGZIP() []byte {
+	file_mark_query_proto_rawDescOnce.Do(func() {
+		file_mark_query_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_mark_query_proto_rawDesc), len(file_mark_query_proto_rawDesc)))
+	})
+	return file_mark_query_proto_rawDescData
+}
+
+var file_mark_query_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_mark_query_proto_goTypes = []any{
+	(*MarksStateRequest)(nil),  // 0: markstat.MarksStateRequest
+	(*MarkState)(nil),          // 1: markstat.MarkState
+	(*MarksStateResponse)(nil), // 2: markstat.MarksStateResponse
+}
+var file_mark_query_proto_depIdxs = []int32{
+	1, // 0: markstat.MarksStateResponse.marks:type_name -> markstat.MarkState
+	0, // 1: markstat.MarkQueryService.GetMarksState:input_type -> markstat.MarksStateRequest
+	2, // 2: markstat.MarkQueryService.GetMarksState:output_type -> markstat.MarksStateResponse
+	2, // [2:3] is the sub-list for method output_type
+	1, // [1:2] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_mark_query_proto_init() }
+func file_mark_query_proto_init() {
+	if File_mark_query_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_mark_query_proto_rawDesc), len(file_mark_query_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_mark_query_proto_goTypes,
+		DependencyIndexes: file_mark_query_proto_depIdxs,
+		MessageInfos:      file_mark_query_proto_msgTypes,
+	}.Build()
+	File_mark_query_proto = out.File
+	file_mark_query_proto_goTypes = nil
+	file_mark_query_proto_depIdxs = nil
+}
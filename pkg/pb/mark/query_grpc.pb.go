@@ -0,0 +1,125 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.1
+// - protoc             v7.34.1
+// source: mark/query.proto
+
+package markstat
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	MarkQueryService_GetMarksState_FullMethodName = "/markstat.MarkQueryService/GetMarksState"
+)
+
+// MarkQueryServiceClient is the client API for MarkQueryService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Проверка меток другими сервисами (например, перед созданием комментария)
+type MarkQueryServiceClient interface {
+	GetMarksState(ctx context.Context, in *MarksStateRequest, opts ...grpc.CallOption) (*MarksStateResponse, error)
+}
+
+type markQueryServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMarkQueryServiceClient(cc grpc.ClientConnInterface) MarkQueryServiceClient {
+	return &markQueryServiceClient{cc}
+}
+
+func (c *markQueryServiceClient) GetMarksState(ctx context.Context, in *MarksStateRequest, opts ...grpc.CallOption) (*MarksStateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MarksStateResponse)
+	err := c.cc.Invoke(ctx, MarkQueryService_GetMarksState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MarkQueryServiceServer is the server API for MarkQueryService service.
+// All implementations must embed UnimplementedMarkQueryServiceServer
+// for forward compatibility.
+//
+// Проверка меток другими сервисами (например, перед созданием комментария)
+type MarkQueryServiceServer interface {
+	GetMarksState(context.Context, *MarksStateRequest) (*MarksStateResponse, error)
+	mustEmbedUnimplementedMarkQueryServiceServer()
+}
+
+// UnimplementedMarkQueryServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMarkQueryServiceServer struct{}
+
+func (UnimplementedMarkQueryServiceServer) GetMarksState(context.Context, *MarksStateRequest) (*MarksStateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetMarksState not implemented")
+}
+func (UnimplementedMarkQueryServiceServer) mustEmbedUnimplementedMarkQueryServiceServer() {}
+func (UnimplementedMarkQueryServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeMarkQueryServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MarkQueryServiceServer will
+// result in compilation errors.
+type UnsafeMarkQueryServiceServer interface {
+	mustEmbedUnimplementedMarkQueryServiceServer()
+}
+
+func RegisterMarkQueryServiceServer(s grpc.ServiceRegistrar, srv MarkQueryServiceServer) {
+	// If the following call panics, it indicates UnimplementedMarkQueryServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&MarkQueryService_ServiceDesc, srv)
+}
+
+func _MarkQueryService_GetMarksState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarksStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarkQueryServiceServer).GetMarksState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MarkQueryService_GetMarksState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarkQueryServiceServer).GetMarksState(ctx, req.(*MarksStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MarkQueryService_ServiceDesc is the grpc.ServiceDesc for MarkQueryService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MarkQueryService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "markstat.MarkQueryService",
+	HandlerType: (*MarkQueryServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetMarksState",
+			Handler:    _MarkQueryService_GetMarksState_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "mark/query.proto",
+}
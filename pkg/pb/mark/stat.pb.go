@@ -570,6 +570,361 @@ func (x *PopularCategoriesResponse) GetCategories() []*PopularCategoriesItem {
 	return nil
 }
 
+// Лайки и переходы по ссылкам, полученные метками пользователя
+type EngagementStatRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId uint64                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Опциональные, без границ считается за все время
+	StartDate     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	PrevStartDate *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=prev_start_date,json=prevStartDate,proto3" json:"prev_start_date,omitempty"`
+	PrevEndDate   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=prev_end_date,json=prevEndDate,proto3" json:"prev_end_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EngagementStatRequest) Reset() {
+	*x = EngagementStatRequest{}
+	mi := &file_mark_stat_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EngagementStatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EngagementStatRequest) ProtoMessage() {}
+
+func (x *EngagementStatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mark_stat_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EngagementStatRequest.ProtoReflect.Descriptor instead.
+func (*EngagementStatRequest) Descriptor() ([]byte, []int) {
+	return file_mark_stat_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *EngagementStatRequest) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *EngagementStatRequest) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *EngagementStatRequest) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+func (x *EngagementStatRequest) GetPrevStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PrevStartDate
+	}
+	return nil
+}
+
+func (x *EngagementStatRequest) GetPrevEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PrevEndDate
+	}
+	return nil
+}
+
+// Совпадает по форме с CommentStatsResponse
+type PeriodStat struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Count         uint64                 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	PrevCount     uint64                 `protobuf:"varint,2,opt,name=prev_count,json=prevCount,proto3" json:"prev_count,omitempty"`
+	Direction     string                 `protobuf:"bytes,3,opt,name=direction,proto3" json:"direction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PeriodStat) Reset() {
+	*x = PeriodStat{}
+	mi := &file_mark_stat_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PeriodStat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeriodStat) ProtoMessage() {}
+
+func (x *PeriodStat) ProtoReflect() protoreflect.Message {
+	mi := &file_mark_stat_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeriodStat.ProtoReflect.Descriptor instead.
+func (*PeriodStat) Descriptor() ([]byte, []int) {
+	return file_mark_stat_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *PeriodStat) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *PeriodStat) GetPrevCount() uint64 {
+	if x != nil {
+		return x.PrevCount
+	}
+	return 0
+}
+
+func (x *PeriodStat) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+type EngagementStatResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Likes         *PeriodStat            `protobuf:"bytes,1,opt,name=likes,proto3" json:"likes,omitempty"`
+	Shares        *PeriodStat            `protobuf:"bytes,2,opt,name=shares,proto3" json:"shares,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EngagementStatResponse) Reset() {
+	*x = EngagementStatResponse{}
+	mi := &file_mark_stat_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EngagementStatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EngagementStatResponse) ProtoMessage() {}
+
+func (x *EngagementStatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mark_stat_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EngagementStatResponse.ProtoReflect.Descriptor instead.
+func (*EngagementStatResponse) Descriptor() ([]byte, []int) {
+	return file_mark_stat_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *EngagementStatResponse) GetLikes() *PeriodStat {
+	if x != nil {
+		return x.Likes
+	}
+	return nil
+}
+
+func (x *EngagementStatResponse) GetShares() *PeriodStat {
+	if x != nil {
+		return x.Shares
+	}
+	return nil
+}
+
+type CategorySeriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        uint64                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Year          int64                  `protobuf:"varint,2,opt,name=year,proto3" json:"year,omitempty"`
+	TopN          int64                  `protobuf:"varint,3,opt,name=top_n,json=topN,proto3" json:"top_n,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategorySeriesRequest) Reset() {
+	*x = CategorySeriesRequest{}
+	mi := &file_mark_stat_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategorySeriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategorySeriesRequest) ProtoMessage() {}
+
+func (x *CategorySeriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mark_stat_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategorySeriesRequest.ProtoReflect.Descriptor instead.
+func (*CategorySeriesRequest) Descriptor() ([]byte, []int) {
+	return file_mark_stat_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CategorySeriesRequest) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CategorySeriesRequest) GetYear() int64 {
+	if x != nil {
+		return x.Year
+	}
+	return 0
+}
+
+func (x *CategorySeriesRequest) GetTopN() int64 {
+	if x != nil {
+		return x.TopN
+	}
+	return 0
+}
+
+type CategorySeries struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CategoryName  string                 `protobuf:"bytes,1,opt,name=category_name,json=categoryName,proto3" json:"category_name,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Months        []*MarkMonthResponse   `protobuf:"bytes,3,rep,name=months,proto3" json:"months,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategorySeries) Reset() {
+	*x = CategorySeries{}
+	mi := &file_mark_stat_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategorySeries) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategorySeries) ProtoMessage() {}
+
+func (x *CategorySeries) ProtoReflect() protoreflect.Message {
+	mi := &file_mark_stat_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategorySeries.ProtoReflect.Descriptor instead.
+func (*CategorySeries) Descriptor() ([]byte, []int) {
+	return file_mark_stat_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CategorySeries) GetCategoryName() string {
+	if x != nil {
+		return x.CategoryName
+	}
+	return ""
+}
+
+func (x *CategorySeries) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *CategorySeries) GetMonths() []*MarkMonthResponse {
+	if x != nil {
+		return x.Months
+	}
+	return nil
+}
+
+type CategorySeriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Series        []*CategorySeries      `protobuf:"bytes,1,rep,name=series,proto3" json:"series,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategorySeriesResponse) Reset() {
+	*x = CategorySeriesResponse{}
+	mi := &file_mark_stat_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategorySeriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategorySeriesResponse) ProtoMessage() {}
+
+func (x *CategorySeriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mark_stat_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategorySeriesResponse.ProtoReflect.Descriptor instead.
+func (*CategorySeriesResponse) Descriptor() ([]byte, []int) {
+	return file_mark_stat_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CategorySeriesResponse) GetSeries() []*CategorySeries {
+	if x != nil {
+		return x.Series
+	}
+	return nil
+}
+
 var File_mark_stat_proto protoreflect.FileDescriptor
 
 const file_mark_stat_proto_rawDesc = "" +
@@ -609,12 +964,40 @@ const file_mark_stat_proto_rawDesc = "" +
 	"\x19PopularCategoriesResponse\x12?\n" +
 	"\n" +
 	"categories\x18\x01 \x03(\v2\x1f.markstat.PopularCategoriesItemR\n" +
-	"categories2\x88\x03\n" +
+	"categories\"\xa6\x02\n" +
+	"\x15EngagementStatRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x04R\x06userId\x129\n" +
+	"\n" +
+	"start_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bend_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\x12B\n" +
+	"\x0fprev_start_date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\rprevStartDate\x12>\n" +
+	"\rprev_end_date\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vprevEndDate\"_\n" +
+	"\n" +
+	"PeriodStat\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x04R\x05count\x12\x1d\n" +
+	"\n" +
+	"prev_count\x18\x02 \x01(\x04R\tprevCount\x12\x1c\n" +
+	"\tdirection\x18\x03 \x01(\tR\tdirection\"r\n" +
+	"\x16EngagementStatResponse\x12*\n" +
+	"\x05likes\x18\x01 \x01(\v2\x14.markstat.PeriodStatR\x05likes\x12,\n" +
+	"\x06shares\x18\x02 \x01(\v2\x14.markstat.PeriodStatR\x06shares\"Y\n" +
+	"\x15CategorySeriesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x04R\x06userId\x12\x12\n" +
+	"\x04year\x18\x02 \x01(\x03R\x04year\x12\x13\n" +
+	"\x05top_n\x18\x03 \x01(\x03R\x04topN\"\x80\x01\n" +
+	"\x0eCategorySeries\x12#\n" +
+	"\rcategory_name\x18\x01 \x01(\tR\fcategoryName\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x123\n" +
+	"\x06months\x18\x03 \x03(\v2\x1b.markstat.MarkMonthResponseR\x06months\"J\n" +
+	"\x16CategorySeriesResponse\x120\n" +
+	"\x06series\x18\x01 \x03(\v2\x18.markstat.CategorySeriesR\x06series2\xc7\x04\n" +
 	"\x10MarkStatsService\x12N\n" +
 	"\x11GetUserMarksCount\x12\x1b.markstat.MarksCountRequest\x1a\x1c.markstat.MarksCountResponse\x12i\n" +
 	"\x1bGetUserMarksMonthlyActivity\x12%.markstat.MarksMonthlyActivityRequest\x1a#.markstat.UserMarksActivityResponse\x12T\n" +
 	"\x13GetUserMarksHeatMap\x12\x1d.markstat.MarksHeatMapRequest\x1a\x1e.markstat.MarksHeatMapResponse\x12c\n" +
-	"\x18GetPopularUserCategories\x12\".markstat.PopularCategoriesRequest\x1a#.markstat.PopularCategoriesResponseB<Z:github.com/RealTimeMap/RealTimeMap-backend/pkg/pb/markstatb\x06proto3"
+	"\x18GetPopularUserCategories\x12\".markstat.PopularCategoriesRequest\x1a#.markstat.PopularCategoriesResponse\x12Z\n" +
+	"\x15GetUserEngagementStat\x12\x1f.markstat.EngagementStatRequest\x1a .markstat.EngagementStatResponse\x12a\n" +
+	"\x1cGetUserCategoryMonthlySeries\x12\x1f.markstat.CategorySeriesRequest\x1a .markstat.CategorySeriesResponseB<Z:github.com/RealTimeMap/RealTimeMap-backend/pkg/pb/markstatb\x06proto3"
 
 var (
 	file_mark_stat_proto_rawDescOnce sync.Once
@@ -628,7 +1011,7 @@ func file_mark_stat_proto_rawDescGZIP() []byte {
 	return file_mark_stat_proto_rawDescData
 }
 
-var file_mark_stat_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_mark_stat_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
 var file_mark_stat_proto_goTypes = []any{
 	(*MarksMonthlyActivityRequest)(nil), // 0: markstat.MarksMonthlyActivityRequest
 	(*MarkMonthResponse)(nil),           // 1: markstat.MarkMonthResponse
@@ -641,28 +1024,46 @@ var file_mark_stat_proto_goTypes = []any{
 	(*PopularCategoriesRequest)(nil),    // 8: markstat.PopularCategoriesRequest
 	(*PopularCategoriesItem)(nil),       // 9: markstat.PopularCategoriesItem
 	(*PopularCategoriesResponse)(nil),   // 10: markstat.PopularCategoriesResponse
-	(*timestamppb.Timestamp)(nil),       // 11: google.protobuf.Timestamp
+	(*EngagementStatRequest)(nil),       // 11: markstat.EngagementStatRequest
+	(*PeriodStat)(nil),                  // 12: markstat.PeriodStat
+	(*EngagementStatResponse)(nil),      // 13: markstat.EngagementStatResponse
+	(*CategorySeriesRequest)(nil),       // 14: markstat.CategorySeriesRequest
+	(*CategorySeries)(nil),              // 15: markstat.CategorySeries
+	(*CategorySeriesResponse)(nil),      // 16: markstat.CategorySeriesResponse
+	(*timestamppb.Timestamp)(nil),       // 17: google.protobuf.Timestamp
 }
 var file_mark_stat_proto_depIdxs = []int32{
 	1,  // 0: markstat.UserMarksActivityResponse.activities:type_name -> markstat.MarkMonthResponse
-	11, // 1: markstat.MarksHeatMapRequest.start_date:type_name -> google.protobuf.Timestamp
-	11, // 2: markstat.MarksHeatMapRequest.end_date:type_name -> google.protobuf.Timestamp
-	11, // 3: markstat.MarkHeatMapResponse.day:type_name -> google.protobuf.Timestamp
+	17, // 1: markstat.MarksHeatMapRequest.start_date:type_name -> google.protobuf.Timestamp
+	17, // 2: markstat.MarksHeatMapRequest.end_date:type_name -> google.protobuf.Timestamp
+	17, // 3: markstat.MarkHeatMapResponse.day:type_name -> google.protobuf.Timestamp
 	6,  // 4: markstat.MarksHeatMapResponse.activity:type_name -> markstat.MarkHeatMapResponse
 	9,  // 5: markstat.PopularCategoriesResponse.categories:type_name -> markstat.PopularCategoriesItem
-	3,  // 6: markstat.MarkStatsService.GetUserMarksCount:input_type -> markstat.MarksCountRequest
-	0,  // 7: markstat.MarkStatsService.GetUserMarksMonthlyActivity:input_type -> markstat.MarksMonthlyActivityRequest
-	5,  // 8: markstat.MarkStatsService.GetUserMarksHeatMap:input_type -> markstat.MarksHeatMapRequest
-	8,  // 9: markstat.MarkStatsService.GetPopularUserCategories:input_type -> markstat.PopularCategoriesRequest
-	4,  // 10: markstat.MarkStatsService.GetUserMarksCount:output_type -> markstat.MarksCountResponse
-	2,  // 11: markstat.MarkStatsService.GetUserMarksMonthlyActivity:output_type -> markstat.UserMarksActivityResponse
-	7,  // 12: markstat.MarkStatsService.GetUserMarksHeatMap:output_type -> markstat.MarksHeatMapResponse
-	10, // 13: markstat.MarkStatsService.GetPopularUserCategories:output_type -> markstat.PopularCategoriesResponse
-	10, // [10:14] is the sub-list for method output_type
-	6,  // [6:10] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	17, // 6: markstat.EngagementStatRequest.start_date:type_name -> google.protobuf.Timestamp
+	17, // 7: markstat.EngagementStatRequest.end_date:type_name -> google.protobuf.Timestamp
+	17, // 8: markstat.EngagementStatRequest.prev_start_date:type_name -> google.protobuf.Timestamp
+	17, // 9: markstat.EngagementStatRequest.prev_end_date:type_name -> google.protobuf.Timestamp
+	12, // 10: markstat.EngagementStatResponse.likes:type_name -> markstat.PeriodStat
+	12, // 11: markstat.EngagementStatResponse.shares:type_name -> markstat.PeriodStat
+	1,  // 12: markstat.CategorySeries.months:type_name -> markstat.MarkMonthResponse
+	15, // 13: markstat.CategorySeriesResponse.series:type_name -> markstat.CategorySeries
+	3,  // 14: markstat.MarkStatsService.GetUserMarksCount:input_type -> markstat.MarksCountRequest
+	0,  // 15: markstat.MarkStatsService.GetUserMarksMonthlyActivity:input_type -> markstat.MarksMonthlyActivityRequest
+	5,  // 16: markstat.MarkStatsService.GetUserMarksHeatMap:input_type -> markstat.MarksHeatMapRequest
+	8,  // 17: markstat.MarkStatsService.GetPopularUserCategories:input_type -> markstat.PopularCategoriesRequest
+	11, // 18: markstat.MarkStatsService.GetUserEngagementStat:input_type -> markstat.EngagementStatRequest
+	14, // 19: markstat.MarkStatsService.GetUserCategoryMonthlySeries:input_type -> markstat.CategorySeriesRequest
+	4,  // 20: markstat.MarkStatsService.GetUserMarksCount:output_type -> markstat.MarksCountResponse
+	2,  // 21: markstat.MarkStatsService.GetUserMarksMonthlyActivity:output_type -> markstat.UserMarksActivityResponse
+	7,  // 22: markstat.MarkStatsService.GetUserMarksHeatMap:output_type -> markstat.MarksHeatMapResponse
+	10, // 23: markstat.MarkStatsService.GetPopularUserCategories:output_type -> markstat.PopularCategoriesResponse
+	13, // 24: markstat.MarkStatsService.GetUserEngagementStat:output_type -> markstat.EngagementStatResponse
+	16, // 25: markstat.MarkStatsService.GetUserCategoryMonthlySeries:output_type -> markstat.CategorySeriesResponse
+	20, // [20:26] is the sub-list for method output_type
+	14, // [14:20] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_mark_stat_proto_init() }
@@ -676,7 +1077,7 @@ func file_mark_stat_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_mark_stat_proto_rawDesc), len(file_mark_stat_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   17,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,10 +19,12 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	MarkStatsService_GetUserMarksCount_FullMethodName           = "/markstat.MarkStatsService/GetUserMarksCount"
-	MarkStatsService_GetUserMarksMonthlyActivity_FullMethodName = "/markstat.MarkStatsService/GetUserMarksMonthlyActivity"
-	MarkStatsService_GetUserMarksHeatMap_FullMethodName         = "/markstat.MarkStatsService/GetUserMarksHeatMap"
-	MarkStatsService_GetPopularUserCategories_FullMethodName    = "/markstat.MarkStatsService/GetPopularUserCategories"
+	MarkStatsService_GetUserMarksCount_FullMethodName            = "/markstat.MarkStatsService/GetUserMarksCount"
+	MarkStatsService_GetUserMarksMonthlyActivity_FullMethodName  = "/markstat.MarkStatsService/GetUserMarksMonthlyActivity"
+	MarkStatsService_GetUserMarksHeatMap_FullMethodName          = "/markstat.MarkStatsService/GetUserMarksHeatMap"
+	MarkStatsService_GetPopularUserCategories_FullMethodName     = "/markstat.MarkStatsService/GetPopularUserCategories"
+	MarkStatsService_GetUserEngagementStat_FullMethodName        = "/markstat.MarkStatsService/GetUserEngagementStat"
+	MarkStatsService_GetUserCategoryMonthlySeries_FullMethodName = "/markstat.MarkStatsService/GetUserCategoryMonthlySeries"
 )
 
 // MarkStatsServiceClient is the client API for MarkStatsService service.
@@ -33,6 +35,8 @@ type MarkStatsServiceClient interface {
 	GetUserMarksMonthlyActivity(ctx context.Context, in *MarksMonthlyActivityRequest, opts ...grpc.CallOption) (*UserMarksActivityResponse, error)
 	GetUserMarksHeatMap(ctx context.Context, in *MarksHeatMapRequest, opts ...grpc.CallOption) (*MarksHeatMapResponse, error)
 	GetPopularUserCategories(ctx context.Context, in *PopularCategoriesRequest, opts ...grpc.CallOption) (*PopularCategoriesResponse, error)
+	GetUserEngagementStat(ctx context.Context, in *EngagementStatRequest, opts ...grpc.CallOption) (*EngagementStatResponse, error)
+	GetUserCategoryMonthlySeries(ctx context.Context, in *CategorySeriesRequest, opts ...grpc.CallOption) (*CategorySeriesResponse, error)
 }
 
 type markStatsServiceClient struct {
@@ -83,6 +87,26 @@ func (c *markStatsServiceClient) GetPopularUserCategories(ctx context.Context, i
 	return out, nil
 }
 
+func (c *markStatsServiceClient) GetUserEngagementStat(ctx context.Context, in *EngagementStatRequest, opts ...grpc.CallOption) (*EngagementStatResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EngagementStatResponse)
+	err := c.cc.Invoke(ctx, MarkStatsService_GetUserEngagementStat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *markStatsServiceClient) GetUserCategoryMonthlySeries(ctx context.Context, in *CategorySeriesRequest, opts ...grpc.CallOption) (*CategorySeriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CategorySeriesResponse)
+	err := c.cc.Invoke(ctx, MarkStatsService_GetUserCategoryMonthlySeries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MarkStatsServiceServer is the server API for MarkStatsService service.
 // All implementations must embed UnimplementedMarkStatsServiceServer
 // for forward compatibility.
@@ -91,6 +115,8 @@ type MarkStatsServiceServer interface {
 	GetUserMarksMonthlyActivity(context.Context, *MarksMonthlyActivityRequest) (*UserMarksActivityResponse, error)
 	GetUserMarksHeatMap(context.Context, *MarksHeatMapRequest) (*MarksHeatMapResponse, error)
 	GetPopularUserCategories(context.Context, *PopularCategoriesRequest) (*PopularCategoriesResponse, error)
+	GetUserEngagementStat(context.Context, *EngagementStatRequest) (*EngagementStatResponse, error)
+	GetUserCategoryMonthlySeries(context.Context, *CategorySeriesRequest) (*CategorySeriesResponse, error)
 	mustEmbedUnimplementedMarkStatsServiceServer()
 }
 
@@ -113,6 +139,12 @@ func (UnimplementedMarkStatsServiceServer) GetUserMarksHeatMap(context.Context,
 func (UnimplementedMarkStatsServiceServer) GetPopularUserCategories(context.Context, *PopularCategoriesRequest) (*PopularCategoriesResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetPopularUserCategories not implemented")
 }
+func (UnimplementedMarkStatsServiceServer) GetUserEngagementStat(context.Context, *EngagementStatRequest) (*EngagementStatResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetUserEngagementStat not implemented")
+}
+func (UnimplementedMarkStatsServiceServer) GetUserCategoryMonthlySeries(context.Context, *CategorySeriesRequest) (*CategorySeriesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetUserCategoryMonthlySeries not implemented")
+}
 func (UnimplementedMarkStatsServiceServer) mustEmbedUnimplementedMarkStatsServiceServer() {}
 func (UnimplementedMarkStatsServiceServer) testEmbeddedByValue()                          {}
 
@@ -206,6 +238,42 @@ func _MarkStatsService_GetPopularUserCategories_Handler(srv interface{}, ctx con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MarkStatsService_GetUserEngagementStat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EngagementStatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarkStatsServiceServer).GetUserEngagementStat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MarkStatsService_GetUserEngagementStat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarkStatsServiceServer).GetUserEngagementStat(ctx, req.(*EngagementStatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MarkStatsService_GetUserCategoryMonthlySeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CategorySeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarkStatsServiceServer).GetUserCategoryMonthlySeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MarkStatsService_GetUserCategoryMonthlySeries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarkStatsServiceServer).GetUserCategoryMonthlySeries(ctx, req.(*CategorySeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MarkStatsService_ServiceDesc is the grpc.ServiceDesc for MarkStatsService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -229,6 +297,14 @@ var MarkStatsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPopularUserCategories",
 			Handler:    _MarkStatsService_GetPopularUserCategories_Handler,
 		},
+		{
+			MethodName: "GetUserEngagementStat",
+			Handler:    _MarkStatsService_GetUserEngagementStat_Handler,
+		},
+		{
+			MethodName: "GetUserCategoryMonthlySeries",
+			Handler:    _MarkStatsService_GetUserCategoryMonthlySeries_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "mark/stat.proto",
@@ -109,6 +109,59 @@ func (x *MultipleProfileRequest) GetIds() []*ProfileRequest {
 	return nil
 }
 
+type ProfilesByTagsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Tags  []string               `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Профили, состоящие с viewer в блокировке (в любую сторону), не возвращаются
+	ViewerId      uint64 `protobuf:"varint,2,opt,name=viewer_id,json=viewerId,proto3" json:"viewer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProfilesByTagsRequest) Reset() {
+	*x = ProfilesByTagsRequest{}
+	mi := &file_profile_profile_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProfilesByTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProfilesByTagsRequest) ProtoMessage() {}
+
+func (x *ProfilesByTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_profile_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProfilesByTagsRequest.ProtoReflect.Descriptor instead.
+func (*ProfilesByTagsRequest) Descriptor() ([]byte, []int) {
+	return file_profile_profile_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ProfilesByTagsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ProfilesByTagsRequest) GetViewerId() uint64 {
+	if x != nil {
+		return x.ViewerId
+	}
+	return 0
+}
+
 type ProfileResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -121,7 +174,7 @@ type ProfileResponse struct {
 
 func (x *ProfileResponse) Reset() {
 	*x = ProfileResponse{}
-	mi := &file_profile_profile_proto_msgTypes[2]
+	mi := &file_profile_profile_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -133,7 +186,7 @@ func (x *ProfileResponse) String() string {
 func (*ProfileResponse) ProtoMessage() {}
 
 func (x *ProfileResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_profile_profile_proto_msgTypes[2]
+	mi := &file_profile_profile_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -146,7 +199,7 @@ func (x *ProfileResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProfileResponse.ProtoReflect.Descriptor instead.
 func (*ProfileResponse) Descriptor() ([]byte, []int) {
-	return file_profile_profile_proto_rawDescGZIP(), []int{2}
+	return file_profile_profile_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *ProfileResponse) GetId() uint64 {
@@ -186,7 +239,7 @@ type MultipleProfileResponse struct {
 
 func (x *MultipleProfileResponse) Reset() {
 	*x = MultipleProfileResponse{}
-	mi := &file_profile_profile_proto_msgTypes[3]
+	mi := &file_profile_profile_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -198,7 +251,7 @@ func (x *MultipleProfileResponse) String() string {
 func (*MultipleProfileResponse) ProtoMessage() {}
 
 func (x *MultipleProfileResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_profile_profile_proto_msgTypes[3]
+	mi := &file_profile_profile_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -211,7 +264,7 @@ func (x *MultipleProfileResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MultipleProfileResponse.ProtoReflect.Descriptor instead.
 func (*MultipleProfileResponse) Descriptor() ([]byte, []int) {
-	return file_profile_profile_proto_rawDescGZIP(), []int{3}
+	return file_profile_profile_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *MultipleProfileResponse) GetProfiles() []*ProfileResponse {
@@ -221,6 +274,111 @@ func (x *MultipleProfileResponse) GetProfiles() []*ProfileResponse {
 	return nil
 }
 
+type RelationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        uint64                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OtherId       uint64                 `protobuf:"varint,2,opt,name=other_id,json=otherId,proto3" json:"other_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RelationRequest) Reset() {
+	*x = RelationRequest{}
+	mi := &file_profile_profile_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelationRequest) ProtoMessage() {}
+
+func (x *RelationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_profile_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelationRequest.ProtoReflect.Descriptor instead.
+func (*RelationRequest) Descriptor() ([]byte, []int) {
+	return file_profile_profile_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RelationRequest) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *RelationRequest) GetOtherId() uint64 {
+	if x != nil {
+		return x.OtherId
+	}
+	return 0
+}
+
+type RelationResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Friends bool                   `protobuf:"varint,1,opt,name=friends,proto3" json:"friends,omitempty"`
+	// Блокировка в любую сторону
+	Blocked       bool `protobuf:"varint,2,opt,name=blocked,proto3" json:"blocked,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RelationResponse) Reset() {
+	*x = RelationResponse{}
+	mi := &file_profile_profile_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelationResponse) ProtoMessage() {}
+
+func (x *RelationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_profile_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelationResponse.ProtoReflect.Descriptor instead.
+func (*RelationResponse) Descriptor() ([]byte, []int) {
+	return file_profile_profile_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RelationResponse) GetFriends() bool {
+	if x != nil {
+		return x.Friends
+	}
+	return false
+}
+
+func (x *RelationResponse) GetBlocked() bool {
+	if x != nil {
+		return x.Blocked
+	}
+	return false
+}
+
 var File_profile_profile_proto protoreflect.FileDescriptor
 
 const file_profile_profile_proto_rawDesc = "" +
@@ -229,17 +387,28 @@ const file_profile_profile_proto_rawDesc = "" +
 	"\x0eProfileRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x04R\x02id\"J\n" +
 	"\x16MultipleProfileRequest\x120\n" +
-	"\x03ids\x18\x01 \x03(\v2\x1e.profileservice.ProfileRequestR\x03ids\"g\n" +
+	"\x03ids\x18\x01 \x03(\v2\x1e.profileservice.ProfileRequestR\x03ids\"H\n" +
+	"\x15ProfilesByTagsRequest\x12\x12\n" +
+	"\x04tags\x18\x01 \x03(\tR\x04tags\x12\x1b\n" +
+	"\tviewer_id\x18\x02 \x01(\x04R\bviewerId\"g\n" +
 	"\x0fProfileResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\x12\x10\n" +
 	"\x03tag\x18\x03 \x01(\tR\x03tag\x12\x16\n" +
 	"\x06avatar\x18\x04 \x01(\tR\x06avatar\"V\n" +
 	"\x17MultipleProfileResponse\x12;\n" +
-	"\bprofiles\x18\x01 \x03(\v2\x1f.profileservice.ProfileResponseR\bprofiles2\xd3\x01\n" +
+	"\bprofiles\x18\x01 \x03(\v2\x1f.profileservice.ProfileResponseR\bprofiles\"E\n" +
+	"\x0fRelationRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x04R\x06userId\x12\x19\n" +
+	"\bother_id\x18\x02 \x01(\x04R\aotherId\"F\n" +
+	"\x10RelationResponse\x12\x18\n" +
+	"\afriends\x18\x01 \x01(\bR\afriends\x12\x18\n" +
+	"\ablocked\x18\x02 \x01(\bR\ablocked2\x92\x03\n" +
 	"\x0eProfileService\x12W\n" +
 	"\x12GetUserProfileByID\x12\x1e.profileservice.ProfileRequest\x1a\x1f.profileservice.ProfileResponse\"\x00\x12h\n" +
-	"\x13GetUserProfileByIDs\x12&.profileservice.MultipleProfileRequest\x1a'.profileservice.MultipleProfileResponse\"\x00B;Z9github.com/RealTimeMap/RealTimeMap-backend/pkg/pb/profileb\x06proto3"
+	"\x13GetUserProfileByIDs\x12&.profileservice.MultipleProfileRequest\x1a'.profileservice.MultipleProfileResponse\"\x00\x12i\n" +
+	"\x15GetUserProfilesByTags\x12%.profileservice.ProfilesByTagsRequest\x1a'.profileservice.MultipleProfileResponse\"\x00\x12R\n" +
+	"\vGetRelation\x12\x1f.profileservice.RelationRequest\x1a .profileservice.RelationResponse\"\x00B;Z9github.com/RealTimeMap/RealTimeMap-backend/pkg/pb/profileb\x06proto3"
 
 var (
 	file_profile_profile_proto_rawDescOnce sync.Once
@@ -253,22 +422,29 @@ func file_profile_profile_proto_rawDescGZIP() []byte {
 	return file_profile_profile_proto_rawDescData
 }
 
-var file_profile_profile_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_profile_profile_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_profile_profile_proto_goTypes = []any{
 	(*ProfileRequest)(nil),          // 0: profileservice.ProfileRequest
 	(*MultipleProfileRequest)(nil),  // 1: profileservice.MultipleProfileRequest
-	(*ProfileResponse)(nil),         // 2: profileservice.ProfileResponse
-	(*MultipleProfileResponse)(nil), // 3: profileservice.MultipleProfileResponse
+	(*ProfilesByTagsRequest)(nil),   // 2: profileservice.ProfilesByTagsRequest
+	(*ProfileResponse)(nil),         // 3: profileservice.ProfileResponse
+	(*MultipleProfileResponse)(nil), // 4: profileservice.MultipleProfileResponse
+	(*RelationRequest)(nil),         // 5: profileservice.RelationRequest
+	(*RelationResponse)(nil),        // 6: profileservice.RelationResponse
 }
 var file_profile_profile_proto_depIdxs = []int32{
 	0, // 0: profileservice.MultipleProfileRequest.ids:type_name -> profileservice.ProfileRequest
-	2, // 1: profileservice.MultipleProfileResponse.profiles:type_name -> profileservice.ProfileResponse
+	3, // 1: profileservice.MultipleProfileResponse.profiles:type_name -> profileservice.ProfileResponse
 	0, // 2: profileservice.ProfileService.GetUserProfileByID:input_type -> profileservice.ProfileRequest
 	1, // 3: profileservice.ProfileService.GetUserProfileByIDs:input_type -> profileservice.MultipleProfileRequest
-	2, // 4: profileservice.ProfileService.GetUserProfileByID:output_type -> profileservice.ProfileResponse
-	3, // 5: profileservice.ProfileService.GetUserProfileByIDs:output_type -> profileservice.MultipleProfileResponse
-	4, // [4:6] is the sub-list for method output_type
-	2, // [2:4] is the sub-list for method input_type
+	2, // 4: profileservice.ProfileService.GetUserProfilesByTags:input_type -> profileservice.ProfilesByTagsRequest
+	5, // 5: profileservice.ProfileService.GetRelation:input_type -> profileservice.RelationRequest
+	3, // 6: profileservice.ProfileService.GetUserProfileByID:output_type -> profileservice.ProfileResponse
+	4, // 7: profileservice.ProfileService.GetUserProfileByIDs:output_type -> profileservice.MultipleProfileResponse
+	4, // 8: profileservice.ProfileService.GetUserProfilesByTags:output_type -> profileservice.MultipleProfileResponse
+	6, // 9: profileservice.ProfileService.GetRelation:output_type -> profileservice.RelationResponse
+	6, // [6:10] is the sub-list for method output_type
+	2, // [2:6] is the sub-list for method input_type
 	2, // [2:2] is the sub-list for extension type_name
 	2, // [2:2] is the sub-list for extension extendee
 	0, // [0:2] is the sub-list for field type_name
@@ -285,7 +461,7 @@ func file_profile_profile_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_profile_profile_proto_rawDesc), len(file_profile_profile_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
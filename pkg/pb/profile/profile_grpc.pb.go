@@ -19,8 +19,10 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ProfileService_GetUserProfileByID_FullMethodName  = "/profileservice.ProfileService/GetUserProfileByID"
-	ProfileService_GetUserProfileByIDs_FullMethodName = "/profileservice.ProfileService/GetUserProfileByIDs"
+	ProfileService_GetUserProfileByID_FullMethodName    = "/profileservice.ProfileService/GetUserProfileByID"
+	ProfileService_GetUserProfileByIDs_FullMethodName   = "/profileservice.ProfileService/GetUserProfileByIDs"
+	ProfileService_GetUserProfilesByTags_FullMethodName = "/profileservice.ProfileService/GetUserProfilesByTags"
+	ProfileService_GetRelation_FullMethodName           = "/profileservice.ProfileService/GetRelation"
 )
 
 // ProfileServiceClient is the client API for ProfileService service.
@@ -29,6 +31,10 @@ const (
 type ProfileServiceClient interface {
 	GetUserProfileByID(ctx context.Context, in *ProfileRequest, opts ...grpc.CallOption) (*ProfileResponse, error)
 	GetUserProfileByIDs(ctx context.Context, in *MultipleProfileRequest, opts ...grpc.CallOption) (*MultipleProfileResponse, error)
+	// Поиск профилей по тегам для упоминаний
+	GetUserProfilesByTags(ctx context.Context, in *ProfilesByTagsRequest, opts ...grpc.CallOption) (*MultipleProfileResponse, error)
+	// Отношения между двумя пользователями, например для прав комментирования стены
+	GetRelation(ctx context.Context, in *RelationRequest, opts ...grpc.CallOption) (*RelationResponse, error)
 }
 
 type profileServiceClient struct {
@@ -59,12 +65,36 @@ func (c *profileServiceClient) GetUserProfileByIDs(ctx context.Context, in *Mult
 	return out, nil
 }
 
+func (c *profileServiceClient) GetUserProfilesByTags(ctx context.Context, in *ProfilesByTagsRequest, opts ...grpc.CallOption) (*MultipleProfileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MultipleProfileResponse)
+	err := c.cc.Invoke(ctx, ProfileService_GetUserProfilesByTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileServiceClient) GetRelation(ctx context.Context, in *RelationRequest, opts ...grpc.CallOption) (*RelationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RelationResponse)
+	err := c.cc.Invoke(ctx, ProfileService_GetRelation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ProfileServiceServer is the server API for ProfileService service.
 // All implementations must embed UnimplementedProfileServiceServer
 // for forward compatibility.
 type ProfileServiceServer interface {
 	GetUserProfileByID(context.Context, *ProfileRequest) (*ProfileResponse, error)
 	GetUserProfileByIDs(context.Context, *MultipleProfileRequest) (*MultipleProfileResponse, error)
+	// Поиск профилей по тегам для упоминаний
+	GetUserProfilesByTags(context.Context, *ProfilesByTagsRequest) (*MultipleProfileResponse, error)
+	// Отношения между двумя пользователями, например для прав комментирования стены
+	GetRelation(context.Context, *RelationRequest) (*RelationResponse, error)
 	mustEmbedUnimplementedProfileServiceServer()
 }
 
@@ -81,6 +111,12 @@ func (UnimplementedProfileServiceServer) GetUserProfileByID(context.Context, *Pr
 func (UnimplementedProfileServiceServer) GetUserProfileByIDs(context.Context, *MultipleProfileRequest) (*MultipleProfileResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetUserProfileByIDs not implemented")
 }
+func (UnimplementedProfileServiceServer) GetUserProfilesByTags(context.Context, *ProfilesByTagsRequest) (*MultipleProfileResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetUserProfilesByTags not implemented")
+}
+func (UnimplementedProfileServiceServer) GetRelation(context.Context, *RelationRequest) (*RelationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetRelation not implemented")
+}
 func (UnimplementedProfileServiceServer) mustEmbedUnimplementedProfileServiceServer() {}
 func (UnimplementedProfileServiceServer) testEmbeddedByValue()                        {}
 
@@ -138,6 +174,42 @@ func _ProfileService_GetUserProfileByIDs_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProfileService_GetUserProfilesByTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProfilesByTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).GetUserProfilesByTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileService_GetUserProfilesByTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).GetUserProfilesByTags(ctx, req.(*ProfilesByTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProfileService_GetRelation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RelationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).GetRelation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileService_GetRelation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).GetRelation(ctx, req.(*RelationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ProfileService_ServiceDesc is the grpc.ServiceDesc for ProfileService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -153,6 +225,14 @@ var ProfileService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetUserProfileByIDs",
 			Handler:    _ProfileService_GetUserProfileByIDs_Handler,
 		},
+		{
+			MethodName: "GetUserProfilesByTags",
+			Handler:    _ProfileService_GetUserProfilesByTags_Handler,
+		},
+		{
+			MethodName: "GetRelation",
+			Handler:    _ProfileService_GetRelation_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "profile/profile.proto",
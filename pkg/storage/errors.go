@@ -8,4 +8,5 @@ var (
 	ErrUploadFailed    = errors.New("upload failed")
 	ErrInvalidMimeType = errors.New("invalid mime type")
 	ErrFileTooLarge    = errors.New("file too large")
+	ErrInvalidImage    = errors.New("invalid image") // Изображение не удалось очистить от метаданных
 )
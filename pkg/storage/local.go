@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
@@ -14,7 +15,6 @@ import (
 
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/imageprocessor"
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/types"
-	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
@@ -77,10 +77,6 @@ func (s *LocalStorage) Upload(ctx context.Context, file io.Reader, opts UploadOp
 		return nil, fmt.Errorf("%w: %d bytes, max: %d", ErrFileTooLarge, len(data), opts.MaxSize)
 	}
 
-	// Вычислить SHA256 хеш
-	hash := sha256.Sum256(data)
-	hashStr := hex.EncodeToString(hash[:])
-
 	// Определить MIME type если не указан
 	if opts.MimeType == "" {
 		opts.MimeType = imageprocessor.DetectMimeType(data)
@@ -91,6 +87,12 @@ func (s *LocalStorage) Upload(ctx context.Context, file io.Reader, opts UploadOp
 		return nil, fmt.Errorf("%w: %s", ErrInvalidMimeType, opts.MimeType)
 	}
 
+	// Удалить EXIF/XMP (координаты и данные устройства) с учетом ориентации
+	data, meta, err := s.stripMetadata(data, opts.MimeType, opts)
+	if err != nil {
+		return nil, err
+	}
+
 	// Оптимизация изображения
 	if opts.Optimize && s.isImage(opts.MimeType) {
 		optimized, err := s.processor.Optimize(data, opts.MimeType)
@@ -107,38 +109,31 @@ func (s *LocalStorage) Upload(ctx context.Context, file io.Reader, opts UploadOp
 		s.logger.Warn("failed to get image dimensions", zap.Error(err))
 	}
 
-	// Сгенерировать путь: photos/marks/2024/01/abc123def.jpg
-	now := time.Now()
-	yearMonth := now.Format("2006/01")
-	ext := filepath.Ext(opts.FileName)
-	if ext == "" {
-		ext = imageprocessor.GetExtensionByMimeType(opts.MimeType)
-	}
-
-	filename := fmt.Sprintf("%s%s", uuid.New(), ext)
-
-	storageKey := filepath.Join("photos", opts.Category.String(), yearMonth, filename)
-	fullPath := filepath.Join(s.basePath, storageKey)
+	// Хеш считается по сохраняемым байтам: после удаления метаданных и оптимизации
+	hashStr := contentHash(data)
 
-	// Создать директорию
-	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
-		return nil, fmt.Errorf("failed to create directory: %w", err)
-	}
+	// Content-addressed путь: photos/marks/ab/abcdef....jpg
+	// Одинаковые файлы хранятся один раз, повторная загрузка увеличивает счетчик ссылок
+	now := time.Now()
+	storageKey := contentKey(opts.Category, hashStr, opts.MimeType, opts.FileName)
 
-	// Проверка: файл уже существует? (дедупликация по хешу)
-	if _, err := os.Stat(fullPath); err == nil {
-		s.logger.Info("file already exists, reusing", zap.String("path", storageKey))
-	} else {
-		// Сохранить файл
+	reused, err := s.acquire(storageKey, func(fullPath string) error {
 		if err := os.WriteFile(fullPath, data, 0644); err != nil {
-			return nil, fmt.Errorf("failed to write file: %w", err)
+			return fmt.Errorf("failed to write file: %w", err)
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if reused {
+		s.logger.Info("file already exists, reusing", zap.String("path", storageKey))
 	}
 
-	// Создать миниатюру
+	// Создать миниатюру (если ее еще нет у существующего файла)
 	var thumbnailKey string
 	if opts.GenerateThumb && s.isImage(opts.MimeType) {
-		thumbnailKey, err = s.generateThumbnail(data, storageKey, opts)
+		thumbnailKey, err = s.ensureThumbnail(data, storageKey, opts)
 		if err != nil {
 			s.logger.Warn("failed to generate thumbnail", zap.Error(err))
 		}
@@ -156,6 +151,7 @@ func (s *LocalStorage) Upload(ctx context.Context, file io.Reader, opts UploadOp
 		Hash:       hashStr,
 		StorageKey: storageKey,
 		UploadedAt: now,
+		Metadata:   meta,
 	}
 
 	if thumbnailKey != "" {
@@ -369,10 +365,6 @@ func (s *LocalStorage) uploadSingleMultipartOptimized(ctx context.Context, fileH
 		return nil, fmt.Errorf("failed to read uploaded file: %w", err)
 	}
 
-	// Вычисляем hash из памяти
-	hash := sha256.Sum256(data)
-	hashStr := hex.EncodeToString(hash[:])
-
 	// Определить MIME type из содержимого
 	mimeType := opts.MimeType
 	if mimeType == "" {
@@ -384,37 +376,45 @@ func (s *LocalStorage) uploadSingleMultipartOptimized(ctx context.Context, fileH
 		return nil, fmt.Errorf("%w: %s", ErrInvalidMimeType, mimeType)
 	}
 
-	// Сгенерировать путь для сохранения
-	now := time.Now()
-	yearMonth := now.Format("2006/01")
-	ext := filepath.Ext(fileHeader.Filename)
-	if ext == "" {
-		ext = imageprocessor.GetExtensionByMimeType(mimeType)
+	// Удалить EXIF/XMP (координаты и данные устройства) с учетом ориентации
+	data, meta, err := s.stripMetadata(data, mimeType, opts)
+	if err != nil {
+		return nil, err
 	}
+	// Хеш из памяти по очищенным байтам, которые и попадут на диск
+	hashStr := contentHash(data)
 
-	filename := fmt.Sprintf("%s%s", uuid.New(), ext)
-	storageKey := filepath.Join("photos", opts.Category.String(), yearMonth, filename)
-	fullPath := filepath.Join(s.basePath, storageKey)
-
-	// Создать директорию
-	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
-		return nil, fmt.Errorf("failed to create directory: %w", err)
-	}
+	// Content-addressed путь для сохранения
+	now := time.Now()
+	storageKey := contentKey(opts.Category, hashStr, mimeType, fileHeader.Filename)
+	thumbKey := s.thumbnailKey(storageKey)
 
 	var width, height int
 	var thumbnailKey string
 
+	// Миниатюру генерируем только если ее еще нет (файл мог быть загружен ранее)
+	needThumb := opts.GenerateThumb && s.isImage(mimeType)
+	if needThumb {
+		if _, err := os.Stat(filepath.Join(s.basePath, thumbKey)); err == nil {
+			needThumb = false
+			thumbnailKey = thumbKey
+		}
+	}
+
 	// Параллельная обработка: запись на диск + обработка изображения
 	var wg sync.WaitGroup
 	var writeErr error
+	var reused bool
 	var thumbData []byte
 	var thumbErr error
 
-	// 1. Запись файла на диск (в отдельной горутине)
+	// 1. Запись файла на диск или увеличение счетчика ссылок (в отдельной горутине)
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		writeErr = os.WriteFile(fullPath, data, 0644)
+		reused, writeErr = s.acquire(storageKey, func(fullPath string) error {
+			return os.WriteFile(fullPath, data, 0644)
+		})
 	}()
 
 	// 2. Обработка изображения (если это изображение)
@@ -423,7 +423,7 @@ func (s *LocalStorage) uploadSingleMultipartOptimized(ctx context.Context, fileH
 		width, height = s.processor.GetDimensionsFast(data)
 
 		// Генерация thumbnail только если нужно
-		if opts.GenerateThumb {
+		if needThumb {
 			wg.Add(1)
 			go func() {
 				defer wg.Done()
@@ -446,11 +446,12 @@ func (s *LocalStorage) uploadSingleMultipartOptimized(ctx context.Context, fileH
 	if writeErr != nil {
 		return nil, fmt.Errorf("failed to save file: %w", writeErr)
 	}
+	if reused {
+		s.logger.Info("file already exists, reusing", zap.String("path", storageKey))
+	}
 
 	// Сохранить миниатюру (если была сгенерирована)
-	if opts.GenerateThumb && thumbErr == nil && thumbData != nil {
-		ext := filepath.Ext(storageKey)
-		thumbKey := storageKey[:len(storageKey)-len(ext)] + "_thumb" + ext
+	if needThumb && thumbErr == nil && thumbData != nil {
 		thumbPath := filepath.Join(s.basePath, thumbKey)
 
 		if err := os.WriteFile(thumbPath, thumbData, 0644); err != nil {
@@ -472,6 +473,7 @@ func (s *LocalStorage) uploadSingleMultipartOptimized(ctx context.Context, fileH
 		Hash:       hashStr,
 		StorageKey: storageKey,
 		UploadedAt: now,
+		Metadata:   meta,
 	}
 
 	if thumbnailKey != "" {
@@ -507,37 +509,27 @@ func (s *LocalStorage) uploadSingleMultipart(ctx context.Context, fileHeader *mu
 	}
 	defer src.Close()
 
-	// Сгенерировать путь для сохранения
+	// Сначала пишем во временный файл: итоговый ключ зависит от хеша содержимого
 	now := time.Now()
-	yearMonth := now.Format("2006/01")
-	ext := filepath.Ext(fileHeader.Filename)
-	if ext == "" && opts.MimeType != "" {
-		ext = imageprocessor.GetExtensionByMimeType(opts.MimeType)
-	}
-
-	filename := fmt.Sprintf("%s%s", uuid.New(), ext)
-	storageKey := filepath.Join("photos", opts.Category.String(), yearMonth, filename)
-	fullPath := filepath.Join(s.basePath, storageKey)
-
-	// Создать директорию
-	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+	tmpDir := filepath.Join(s.basePath, "photos", opts.Category.String())
+	if err := os.MkdirAll(tmpDir, 0755); err != nil {
 		return nil, fmt.Errorf("failed to create directory: %w", err)
 	}
 
-	// Создать файл на диске
-	dst, err := os.Create(fullPath)
+	dst, err := os.CreateTemp(tmpDir, ".upload-*")
 	if err != nil {
 		return nil, fmt.Errorf("failed to create file: %w", err)
 	}
-	defer dst.Close()
+	tmpPath := dst.Name()
+	defer os.Remove(tmpPath) // no-op, если файл уже перемещен
 
 	// Копировать с вычислением hash на лету
 	hasher := sha256.New()
 	multiWriter := io.MultiWriter(dst, hasher)
 
 	written, err := io.Copy(multiWriter, src)
+	dst.Close()
 	if err != nil {
-		os.Remove(fullPath) // Удалить частично записанный файл
 		return nil, fmt.Errorf("failed to save file: %w", err)
 	}
 
@@ -547,15 +539,49 @@ func (s *LocalStorage) uploadSingleMultipart(ctx context.Context, fileHeader *mu
 	mimeType := opts.MimeType
 	if mimeType == "" {
 		// Открыть файл для определения MIME
-		file, err := os.Open(fullPath)
+		file, err := os.Open(tmpPath)
 		if err == nil {
-			defer file.Close()
 			buf := make([]byte, 512)
 			n, _ := file.Read(buf)
+			file.Close()
 			mimeType = imageprocessor.DetectMimeType(buf[:n])
 		}
 	}
 
+	// Удалить EXIF/XMP (координаты и данные устройства) с учетом ориентации
+	var meta *types.PhotoMetadata
+	if s.isImage(mimeType) {
+		original, err := os.ReadFile(tmpPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read uploaded file: %w", err)
+		}
+		var stripped []byte
+		stripped, meta, err = s.stripMetadata(original, mimeType, opts)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(tmpPath, stripped, 0644); err != nil {
+			return nil, fmt.Errorf("failed to save file: %w", err)
+		}
+		written = int64(len(stripped))
+		// Хеш, посчитанный при копировании, относится к исходным байтам
+		hashStr = contentHash(stripped)
+	}
+
+	// Переместить в content-addressed путь или переиспользовать существующий файл
+	storageKey := contentKey(opts.Category, hashStr, mimeType, fileHeader.Filename)
+	fullPath := filepath.Join(s.basePath, storageKey)
+
+	reused, err := s.acquire(storageKey, func(fullPath string) error {
+		return os.Rename(tmpPath, fullPath)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save file: %w", err)
+	}
+	if reused {
+		s.logger.Info("file already exists, reusing", zap.String("path", storageKey))
+	}
+
 	// Получить размеры изображения (открываем файл один раз)
 	var width, height int
 	if s.isImage(mimeType) {
@@ -575,7 +601,7 @@ func (s *LocalStorage) uploadSingleMultipart(ctx context.Context, fileHeader *mu
 			if err == nil {
 				defer file.Close()
 				data, _ := io.ReadAll(file)
-				_, _ = s.generateThumbnail(data, storageKey, opts)
+				_, _ = s.ensureThumbnail(data, storageKey, opts)
 			}
 		}()
 	}
@@ -592,6 +618,7 @@ func (s *LocalStorage) uploadSingleMultipart(ctx context.Context, fileHeader *mu
 		Hash:       hashStr,
 		StorageKey: storageKey,
 		UploadedAt: now,
+		Metadata:   meta,
 	}
 
 	if thumbnailKey != "" {
@@ -607,24 +634,18 @@ func (s *LocalStorage) uploadSingleMultipart(ctx context.Context, fileHeader *mu
 	return photo, nil
 }
 
-// Delete удаляет файл
+// Delete снимает одну ссылку с файла. Содержимое удаляется, когда ссылок не осталось
 func (s *LocalStorage) Delete(ctx context.Context, storageKey string) error {
-	fullPath := filepath.Join(s.basePath, storageKey)
-
-	if err := os.Remove(fullPath); err != nil {
-		if os.IsNotExist(err) {
-			return ErrFileNotFound
-		}
-		return fmt.Errorf("failed to delete file: %w", err)
+	removed, err := s.release(storageKey)
+	if err != nil {
+		return err
 	}
 
-	// Удалить миниатюру если есть
-	thumbPath := s.getThumbnailPath(storageKey)
-	if _, err := os.Stat(thumbPath); err == nil {
-		os.Remove(thumbPath)
+	if removed {
+		s.logger.Info("file deleted", zap.String("storage_key", storageKey))
+	} else {
+		s.logger.Info("file reference released", zap.String("storage_key", storageKey))
 	}
-
-	s.logger.Info("file deleted", zap.String("storage_key", storageKey))
 	return nil
 }
 
@@ -667,6 +688,15 @@ func (s *LocalStorage) Exists(ctx context.Context, storageKey string) (bool, err
 	return false, err
 }
 
+// ensureThumbnail создает миниатюру, если у файла ее еще нет
+func (s *LocalStorage) ensureThumbnail(data []byte, originalKey string, opts UploadOptions) (string, error) {
+	thumbKey := s.thumbnailKey(originalKey)
+	if _, err := os.Stat(filepath.Join(s.basePath, thumbKey)); err == nil {
+		return thumbKey, nil
+	}
+	return s.generateThumbnail(data, originalKey, opts)
+}
+
 // generateThumbnail создает миниатюру
 func (s *LocalStorage) generateThumbnail(data []byte, originalKey string, opts UploadOptions) (string, error) {
 	width := opts.ThumbWidth
@@ -683,9 +713,7 @@ func (s *LocalStorage) generateThumbnail(data []byte, originalKey string, opts U
 		return "", err
 	}
 
-	// Путь миниатюры: добавить _thumb перед расширением
-	ext := filepath.Ext(originalKey)
-	thumbKey := originalKey[:len(originalKey)-len(ext)] + "_thumb" + ext
+	thumbKey := s.thumbnailKey(originalKey)
 	thumbPath := filepath.Join(s.basePath, thumbKey)
 
 	if err := os.WriteFile(thumbPath, thumbData, 0644); err != nil {
@@ -695,11 +723,40 @@ func (s *LocalStorage) generateThumbnail(data []byte, originalKey string, opts U
 	return thumbKey, nil
 }
 
+// thumbnailKey возвращает ключ миниатюры: добавить _thumb перед расширением
+func (s *LocalStorage) thumbnailKey(originalKey string) string {
+	ext := filepath.Ext(originalKey)
+	return originalKey[:len(originalKey)-len(ext)] + "_thumb" + ext
+}
+
 // getThumbnailPath возвращает путь к миниатюре
 func (s *LocalStorage) getThumbnailPath(originalKey string) string {
-	ext := filepath.Ext(originalKey)
-	thumbKey := originalKey[:len(originalKey)-len(ext)] + "_thumb" + ext
-	return filepath.Join(s.basePath, thumbKey)
+	return filepath.Join(s.basePath, s.thumbnailKey(originalKey))
+}
+
+// stripMetadata удаляет метаданные из изображения.
+// Время съемки и координаты возвращаются только если это запрошено в opts.KeepMetadata
+func (s *LocalStorage) stripMetadata(data []byte, mimeType string, opts UploadOptions) ([]byte, *types.PhotoMetadata, error) {
+	if !s.isImage(mimeType) {
+		return data, nil, nil
+	}
+
+	stripped, meta, err := s.processor.StripMetadata(data, mimeType)
+	if errors.Is(err, imageprocessor.ErrMalformedImage) {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrUploadFailed, err)
+	}
+	if !opts.KeepMetadata || (meta.TakenAt == nil && !meta.HasLocation()) {
+		return stripped, nil, nil
+	}
+
+	return stripped, &types.PhotoMetadata{
+		TakenAt:   meta.TakenAt,
+		Latitude:  meta.Latitude,
+		Longitude: meta.Longitude,
+	}, nil
 }
 
 // isValidMimeType проверяет валидность MIME типа
@@ -0,0 +1,16 @@
+//go:build !unix
+
+package storage
+
+import "sync"
+
+var fileLocks sync.Map
+
+// lockFile без flock блокирует только в пределах процесса: каталог хранилища
+// не должен использоваться несколькими процессами
+func lockFile(path string) (func(), error) {
+	m, _ := fileLocks.LoadOrStore(path, &sync.Mutex{})
+	mu := m.(*sync.Mutex)
+	mu.Lock()
+	return mu.Unlock, nil
+}
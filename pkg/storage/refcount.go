@@ -0,0 +1,156 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/imageprocessor"
+)
+
+// refSuffix - суффикс файла-счетчика ссылок рядом с содержимым
+const refSuffix = ".ref"
+
+// lockFileName - файл блокировки в каталоге-шарде (photos/marks/ab/.lock).
+// Каталоги шардов не удаляются, поэтому блокировка не теряется вместе с удаленным содержимым
+const lockFileName = ".lock"
+
+// lockKey сериализует операции над ключом для всех процессов и реплик, работающих с каталогом
+// хранилища: счетчик ссылок читается и записывается только под блокировкой файла шарда
+func (s *LocalStorage) lockKey(storageKey string) (func(), error) {
+	dir := filepath.Join(s.basePath, filepath.Dir(storageKey))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+	unlock, err := lockFile(filepath.Join(dir, lockFileName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock %s: %w", storageKey, err)
+	}
+	return unlock, nil
+}
+
+// contentHash SHA-256 содержимого в hex, используется для ключа и Photo.Hash
+func contentHash(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+// contentKey формирует content-addressed ключ: photos/marks/ab/abcdef....jpg
+// Одинаковое содержимое в рамках одной категории всегда получает один и тот же ключ
+func contentKey(category CategoryStorage, hash, mimeType, fileName string) string {
+	ext := imageprocessor.GetExtensionByMimeType(mimeType)
+	if ext == ".bin" {
+		if fileExt := strings.ToLower(filepath.Ext(fileName)); fileExt != "" {
+			ext = fileExt
+		}
+	}
+	return filepath.Join("photos", category.String(), hash[:2], hash+ext)
+}
+
+// readRefs возвращает количество ссылок на файл.
+// Для файлов без счетчика (загруженных до дедупликации) считается одна ссылка
+func readRefs(fullPath string) (int, error) {
+	data, err := os.ReadFile(fullPath + refSuffix)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 1, nil
+		}
+		return 0, err
+	}
+	refs, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0, fmt.Errorf("corrupted ref counter %s: %w", fullPath+refSuffix, err)
+	}
+	return refs, nil
+}
+
+// writeRefs атомарно записывает счетчик ссылок (через временный файл + rename)
+func writeRefs(fullPath string, refs int) error {
+	tmp := fullPath + refSuffix + ".tmp"
+	if err := os.WriteFile(tmp, []byte(strconv.Itoa(refs)), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, fullPath+refSuffix)
+}
+
+// acquire сохраняет содержимое по ключу или, если оно уже есть, увеличивает счетчик ссылок.
+// write вызывается только когда файла еще нет и должен записать его по переданному пути.
+// Возвращает true, если использован уже существующий файл
+func (s *LocalStorage) acquire(storageKey string, write func(fullPath string) error) (bool, error) {
+	unlock, err := s.lockKey(storageKey)
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	fullPath := filepath.Join(s.basePath, storageKey)
+
+	if _, err := os.Stat(fullPath); err == nil {
+		refs, err := readRefs(fullPath)
+		if err != nil {
+			return false, err
+		}
+		if err := writeRefs(fullPath, refs+1); err != nil {
+			return false, fmt.Errorf("failed to update ref counter: %w", err)
+		}
+		return true, nil
+	} else if !os.IsNotExist(err) {
+		return false, err
+	}
+
+	if err := write(fullPath); err != nil {
+		os.Remove(fullPath)
+		return false, err
+	}
+	if err := writeRefs(fullPath, 1); err != nil {
+		os.Remove(fullPath)
+		return false, fmt.Errorf("failed to write ref counter: %w", err)
+	}
+	return false, nil
+}
+
+// release уменьшает счетчик ссылок. Возвращает true, если ссылок не осталось
+// и файл (вместе с миниатюрой и счетчиком) был удален
+func (s *LocalStorage) release(storageKey string) (bool, error) {
+	unlock, err := s.lockKey(storageKey)
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+
+	fullPath := filepath.Join(s.basePath, storageKey)
+
+	if _, err := os.Stat(fullPath); err != nil {
+		if os.IsNotExist(err) {
+			return false, ErrFileNotFound
+		}
+		return false, err
+	}
+
+	refs, err := readRefs(fullPath)
+	if err != nil {
+		return false, err
+	}
+	if refs > 1 {
+		if err := writeRefs(fullPath, refs-1); err != nil {
+			return false, fmt.Errorf("failed to update ref counter: %w", err)
+		}
+		return false, nil
+	}
+
+	if err := os.Remove(fullPath); err != nil {
+		return false, fmt.Errorf("failed to delete file: %w", err)
+	}
+	os.Remove(fullPath + refSuffix)
+
+	// Удалить миниатюру если есть
+	thumbPath := s.getThumbnailPath(storageKey)
+	if _, err := os.Stat(thumbPath); err == nil {
+		os.Remove(thumbPath)
+	}
+	return true, nil
+}
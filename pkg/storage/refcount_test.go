@@ -0,0 +1,138 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func writeContent(data string) func(fullPath string) error {
+	return func(fullPath string) error {
+		return os.WriteFile(fullPath, []byte(data), 0644)
+	}
+}
+
+func TestAcquireRelease(t *testing.T) {
+	s := &LocalStorage{basePath: t.TempDir()}
+	key := filepath.Join("photos", "marks", "ab", "abcdef.jpg")
+	fullPath := filepath.Join(s.basePath, key)
+
+	reused, err := s.acquire(key, writeContent("photo"))
+	if err != nil || reused {
+		t.Fatalf("первая загрузка: reused = %v, err = %v", reused, err)
+	}
+	reused, err = s.acquire(key, func(string) error {
+		t.Fatal("существующий файл не должен перезаписываться")
+		return nil
+	})
+	if err != nil || !reused {
+		t.Fatalf("повторная загрузка: reused = %v, err = %v", reused, err)
+	}
+	if refs, _ := readRefs(fullPath); refs != 2 {
+		t.Fatalf("refs = %d, want 2", refs)
+	}
+
+	removed, err := s.release(key)
+	if err != nil || removed {
+		t.Fatalf("первое удаление: removed = %v, err = %v", removed, err)
+	}
+	if _, err := os.Stat(fullPath); err != nil {
+		t.Fatalf("файл со ссылками удален: %v", err)
+	}
+
+	removed, err = s.release(key)
+	if err != nil || !removed {
+		t.Fatalf("последнее удаление: removed = %v, err = %v", removed, err)
+	}
+	for _, path := range []string{fullPath, fullPath + refSuffix} {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("%s должен быть удален", path)
+		}
+	}
+
+	if _, err := s.release(key); err != ErrFileNotFound {
+		t.Errorf("удаление отсутствующего файла: err = %v, want ErrFileNotFound", err)
+	}
+}
+
+func TestReadRefs(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		content *string
+		want    int
+		wantErr bool
+	}{
+		{"без счетчика - одна ссылка", nil, 1, false},
+		{"число", strPtr("3"), 3, false},
+		{"число с переводом строки", strPtr("5\n"), 5, false},
+		{"поврежденный счетчик", strPtr("abc"), 0, true},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fullPath := filepath.Join(dir, string(rune('a'+i)))
+			if tt.content != nil {
+				if err := os.WriteFile(fullPath+refSuffix, []byte(*tt.content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			got, err := readRefs(fullPath)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("readRefs() err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("readRefs() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// Экземпляры хранилища на одном каталоге имитируют реплики: счетчик не должен терять обновления
+func TestAcquireConcurrentInstances(t *testing.T) {
+	base := t.TempDir()
+	instances := []*LocalStorage{{basePath: base}, {basePath: base}, {basePath: base}}
+	key := filepath.Join("photos", "marks", "cd", "cdef.jpg")
+	const perInstance = 30
+
+	var wg sync.WaitGroup
+	for _, s := range instances {
+		for i := 0; i < perInstance; i++ {
+			wg.Add(1)
+			go func(s *LocalStorage) {
+				defer wg.Done()
+				if _, err := s.acquire(key, writeContent("photo")); err != nil {
+					t.Error(err)
+				}
+			}(s)
+		}
+	}
+	wg.Wait()
+
+	want := len(instances) * perInstance
+	if refs, _ := readRefs(filepath.Join(base, key)); refs != want {
+		t.Fatalf("refs = %d, want %d", refs, want)
+	}
+
+	for _, s := range instances {
+		for i := 0; i < perInstance; i++ {
+			wg.Add(1)
+			go func(s *LocalStorage) {
+				defer wg.Done()
+				if _, err := s.release(key); err != nil {
+					t.Error(err)
+				}
+			}(s)
+		}
+	}
+	wg.Wait()
+
+	if _, err := os.Stat(filepath.Join(base, key)); !os.IsNotExist(err) {
+		t.Errorf("файл должен быть удален после последней ссылки")
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
@@ -65,6 +65,7 @@ type UploadOptions struct {
 	ThumbWidth    int               // Ширина миниатюры
 	ThumbHeight   int               // Высота миниатюры
 	Optimize      bool              // Оптимизировать изображение
+	KeepMetadata  bool              // Сохранить время съемки и координаты из EXIF в Photo.Metadata
 	Metadata      map[string]string // Дополнительные метаданные
 }
 
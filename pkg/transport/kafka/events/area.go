@@ -0,0 +1,35 @@
+package events
+
+const (
+	AreaMarkCreated = "area.mark_created"
+)
+
+type AreaMarkCreatedEvent struct {
+	Envelop
+	Payload AreaMarkCreatedPayload `json:"payload"`
+}
+
+// AreaRef сохраненная область, в которую попала метка
+type AreaRef struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+// AreaMarkCreatedPayload уведомление пользователя о новой метке в его сохраненных областях
+type AreaMarkCreatedPayload struct {
+	UserID     int       `json:"userId"`
+	Areas      []AreaRef `json:"areas"`
+	MarkID     int       `json:"markId"`
+	MarkName   string    `json:"markName"`
+	CategoryID int       `json:"categoryId"`
+	OwnerID    int       `json:"ownerId"`
+	Longitude  float64   `json:"longitude"`
+	Latitude   float64   `json:"latitude"`
+}
+
+func NewAreaMarkCreated(payload AreaMarkCreatedPayload) AreaMarkCreatedEvent {
+	return AreaMarkCreatedEvent{
+		Envelop: NewEnvelop(AreaMarkCreated),
+		Payload: payload,
+	}
+}
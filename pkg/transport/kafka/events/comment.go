@@ -4,6 +4,12 @@ const (
 	CommentCreated = "comment.created"
 	CommentUpdated = "comment.updated"
 	CommentDeleted = "comment.deleted"
+
+	// Восстановление удаленного модератором комментария
+	CommentRestored = "comment.restored"
+
+	CommentReactionToggled = "comment.reaction_toggled"
+	CommentMentioned       = "comment.mentioned"
 )
 
 type CommentEvent struct {
@@ -37,3 +43,105 @@ func NewCommentCreated(payload CommentPayload) CommentEvent {
 		Payload: payload,
 	}
 }
+
+// CommentUpdatedEvent изменение текста комментария
+type CommentUpdatedEvent struct {
+	Envelop
+	Payload CommentUpdatedPayload `json:"payload"`
+}
+
+type CommentUpdatedPayload struct {
+	CommentPayload
+	PrevContent string `json:"prevContent"`
+}
+
+func NewCommentUpdated(payload CommentPayload, prevContent string) CommentUpdatedEvent {
+	return CommentUpdatedEvent{
+		Envelop: NewEnvelop(CommentUpdated),
+		Payload: CommentUpdatedPayload{CommentPayload: payload, PrevContent: prevContent},
+	}
+}
+
+// CommentDeletedEvent удаление комментария. Content содержит текст до удаления
+type CommentDeletedEvent struct {
+	Envelop
+	Payload CommentDeletedPayload `json:"payload"`
+}
+
+type CommentDeletedPayload struct {
+	CommentPayload
+	DeletedBy uint   `json:"deletedBy"`        // Совпадает с UserID, если удалил автор
+	Reason    string `json:"reason,omitempty"` // Причина удаления модератором
+}
+
+func NewCommentDeleted(payload CommentPayload, deletedBy uint, reason string) CommentDeletedEvent {
+	return CommentDeletedEvent{
+		Envelop: NewEnvelop(CommentDeleted),
+		Payload: CommentDeletedPayload{CommentPayload: payload, DeletedBy: deletedBy, Reason: reason},
+	}
+}
+
+// CommentRestoredEvent восстановление комментария модератором
+type CommentRestoredEvent struct {
+	Envelop
+	Payload CommentRestoredPayload `json:"payload"`
+}
+
+type CommentRestoredPayload struct {
+	CommentPayload
+	RestoredBy uint   `json:"restoredBy"`
+	Reason     string `json:"reason,omitempty"`
+}
+
+func NewCommentRestored(payload CommentPayload, restoredBy uint, reason string) CommentRestoredEvent {
+	return CommentRestoredEvent{
+		Envelop: NewEnvelop(CommentRestored),
+		Payload: CommentRestoredPayload{CommentPayload: payload, RestoredBy: restoredBy, Reason: reason},
+	}
+}
+
+// CommentReactionEvent постановка, снятие или смена реакции на комментарий
+type CommentReactionEvent struct {
+	Envelop
+	Payload CommentReactionPayload `json:"payload"`
+}
+
+type CommentReactionPayload struct {
+	CommentID  uint            `json:"commentId"`
+	OwnerID    uint            `json:"ownerId"` // Автор комментария
+	UserID     uint            `json:"userId"`  // Поставивший реакцию
+	EntityType string          `json:"entityType"`
+	EntityID   uint            `json:"entityId"`
+	Type       string          `json:"type,omitempty"`     // Пусто, если реакция снята
+	PrevType   string          `json:"prevType,omitempty"` // Пусто, если реакции не было
+	Counts     map[string]uint `json:"counts"`             // Количество реакций каждого типа после изменения
+
+	// Deprecated: counts["like"] и counts["dislike"], оставлены для потребителей прежнего формата на один релиз
+	LikesCount    uint `json:"likesCount"`
+	DislikesCount uint `json:"dislikesCount"`
+}
+
+func NewCommentReactionToggled(payload CommentReactionPayload) CommentReactionEvent {
+	return CommentReactionEvent{
+		Envelop: NewEnvelop(CommentReactionToggled),
+		Payload: payload,
+	}
+}
+
+// CommentMentionedEvent упоминание пользователя в комментарии, отправляется на каждого упомянутого
+type CommentMentionedEvent struct {
+	Envelop
+	Payload CommentMentionedPayload `json:"payload"`
+}
+
+type CommentMentionedPayload struct {
+	CommentPayload
+	MentionedUserID uint `json:"mentionedUserId"`
+}
+
+func NewCommentMentioned(payload CommentPayload, mentionedUserID uint) CommentMentionedEvent {
+	return CommentMentionedEvent{
+		Envelop: NewEnvelop(CommentMentioned),
+		Payload: CommentMentionedPayload{CommentPayload: payload, MentionedUserID: mentionedUserID},
+	}
+}
@@ -4,6 +4,10 @@ const (
 	MarkCreated = "markCreated"
 	MarkUpdated = "markUpdated"
 	MarkDeleted = "markDeleted"
+
+	// Действия модераторов
+	MarkHidden   = "markHidden"
+	MarkUnhidden = "markUnhidden"
 )
 
 type MarkEvent struct {
@@ -37,3 +41,97 @@ func NewMarkCreate(payload MarkPayload) MarkEvent {
 		Payload: payload,
 	}
 }
+
+// MarkModerationEvent событие о действии модератора над меткой
+type MarkModerationEvent struct {
+	Envelop
+	Payload MarkModerationPayload `json:"payload"`
+}
+
+type MarkModerationPayload struct {
+	MarkPayload
+	ModeratorID int    `json:"moderatorId"`
+	Reason      string `json:"reason,omitempty"`
+}
+
+func NewMarkModeration(eventType string, payload MarkModerationPayload) MarkModerationEvent {
+	return MarkModerationEvent{
+		Envelop: NewEnvelop(eventType),
+		Payload: payload,
+	}
+}
+
+const (
+	MarkCheckIn = "mark.checkin"
+)
+
+type MarkCheckInEvent struct {
+	Envelop
+	Payload MarkCheckInPayload `json:"payload"`
+}
+
+// MarkCheckInPayload данные об отметке пользователя на метке
+type MarkCheckInPayload struct {
+	MarkID     int     `json:"markId"`
+	CategoryID int     `json:"categoryId"`
+	OwnerID    int     `json:"ownerId"`
+	UserID     uint    `json:"userId"`
+	Distance   float64 `json:"distance"`
+}
+
+func NewMarkCheckIn(payload MarkCheckInPayload) MarkCheckInEvent {
+	return MarkCheckInEvent{
+		Envelop: NewEnvelop(MarkCheckIn),
+		Payload: payload,
+	}
+}
+
+const (
+	MarkRSVPPromoted = "mark.rsvp_promoted"
+)
+
+type MarkRSVPEvent struct {
+	Envelop
+	Payload MarkRSVPPayload `json:"payload"`
+}
+
+// MarkRSVPPayload данные об изменении статуса участия пользователя
+type MarkRSVPPayload struct {
+	MarkID   int    `json:"markId"`
+	OwnerID  int    `json:"ownerId"`
+	UserID   uint   `json:"userId"`
+	MarkName string `json:"markName"`
+	Status   string `json:"status"`
+}
+
+func NewMarkRSVPPromoted(payload MarkRSVPPayload) MarkRSVPEvent {
+	return MarkRSVPEvent{
+		Envelop: NewEnvelop(MarkRSVPPromoted),
+		Payload: payload,
+	}
+}
+
+const (
+	MarkShareOpened = "mark.share_opened"
+)
+
+type MarkShareOpenedEvent struct {
+	Envelop
+	Payload MarkShareOpenedPayload `json:"payload"`
+}
+
+// MarkShareOpenedPayload данные о переходе по ссылке, которой поделился пользователь
+type MarkShareOpenedPayload struct {
+	MarkID   int    `json:"markId"`
+	OwnerID  int    `json:"ownerId"`
+	SharerID uint   `json:"sharerId"`
+	MarkName string `json:"markName"`
+	Opens    int64  `json:"opens"` // Уникальных переходов по ссылкам пользователя на метку
+}
+
+func NewMarkShareOpened(payload MarkShareOpenedPayload) MarkShareOpenedEvent {
+	return MarkShareOpenedEvent{
+		Envelop: NewEnvelop(MarkShareOpened),
+		Payload: payload,
+	}
+}
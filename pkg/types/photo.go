@@ -18,6 +18,24 @@ type Photo struct {
 	Hash       string    `json:"hash,omitempty"`        // Хэш файла (SHA256) для проверки целостности
 	StorageKey string    `json:"storage_key,omitempty"` // Ключ/путь в хранилище (S3, MinIO и т.д.)
 	UploadedAt time.Time `json:"uploaded_at,omitempty"` // Время загрузки
+
+	Metadata *PhotoMetadata `json:"metadata,omitempty"` // Данные из EXIF (только если запрошено при загрузке)
+}
+
+// PhotoMetadata данные, извлеченные из EXIF до его удаления из файла.
+// Координаты съемки не должны отдаваться клиентам - только для внутренних проверок
+type PhotoMetadata struct {
+	TakenAt   *time.Time `json:"taken_at,omitempty"`  // Время съемки
+	Latitude  *float64   `json:"latitude,omitempty"`  // Широта места съемки
+	Longitude *float64   `json:"longitude,omitempty"` // Долгота места съемки
+
+	Distance         *float64 `json:"distance,omitempty"`          // Расстояние (м) от места съемки до связанного объекта
+	LocationMismatch bool     `json:"location_mismatch,omitempty"` // Фото сделано слишком далеко от связанного объекта
+}
+
+// HasLocation проверяет, известны ли координаты места съемки
+func (m *PhotoMetadata) HasLocation() bool {
+	return m != nil && m.Latitude != nil && m.Longitude != nil
 }
 
 // Scan реализует интерфейс sql.Scanner для чтения Photo из БД
@@ -23,6 +23,22 @@ func (p *Point) Scan(val interface{}) error {
 		return nil
 	}
 
+	point, err := scanGeometry(val)
+	if err != nil {
+		return err
+	}
+
+	if pt, ok := point.(orb.Point); ok {
+		p.Point = pt
+	} else {
+		return fmt.Errorf("geometry is not a point: %T", point)
+	}
+
+	return nil
+}
+
+// scanGeometry декодирует геометрию PostGIS из EWKB/WKB (в том числе hex-encoded)
+func scanGeometry(val interface{}) (orb.Geometry, error) {
 	var b []byte
 
 	switch v := val.(type) {
@@ -31,7 +47,7 @@ func (p *Point) Scan(val interface{}) error {
 		if len(v) > 0 && isHexString(v) {
 			decoded, err := hex.DecodeString(string(v))
 			if err != nil {
-				return fmt.Errorf("failed to decode hex: %w", err)
+				return nil, fmt.Errorf("failed to decode hex: %w", err)
 			}
 			b = decoded
 		} else {
@@ -41,30 +57,23 @@ func (p *Point) Scan(val interface{}) error {
 		// Строка из PostGIS почти всегда hex-encoded
 		decoded, err := hex.DecodeString(v)
 		if err != nil {
-			return fmt.Errorf("failed to decode hex string: %w", err)
+			return nil, fmt.Errorf("failed to decode hex string: %w", err)
 		}
 		b = decoded
 	default:
-		return fmt.Errorf("cannot scan %T into Point", val)
+		return nil, fmt.Errorf("cannot scan %T into geometry", val)
 	}
 
 	// Пробуем EWKB (с SRID)
-	point, _, err := ewkb.Unmarshal(b)
+	geom, _, err := ewkb.Unmarshal(b)
 	if err != nil {
 		// Fallback на обычный WKB
-		point, err = wkb.Unmarshal(b)
+		geom, err = wkb.Unmarshal(b)
 		if err != nil {
-			return fmt.Errorf("failed to unmarshal point: %w", err)
+			return nil, fmt.Errorf("failed to unmarshal geometry: %w", err)
 		}
 	}
-
-	if pt, ok := point.(orb.Point); ok {
-		p.Point = pt
-	} else {
-		return fmt.Errorf("geometry is not a point: %T", point)
-	}
-
-	return nil
+	return geom, nil
 }
 
 // Проверяет, является ли []byte hex-строкой
@@ -0,0 +1,43 @@
+package types
+
+import (
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/encoding/ewkb"
+)
+
+// Polygon структура для полигона PostGis
+type Polygon struct {
+	orb.Polygon
+}
+
+// Scan реализует интерфейс sql.Scanner для чтения из БД
+func (p *Polygon) Scan(val interface{}) error {
+	if val == nil {
+		*p = Polygon{}
+		return nil
+	}
+
+	geom, err := scanGeometry(val)
+	if err != nil {
+		return err
+	}
+
+	polygon, ok := geom.(orb.Polygon)
+	if !ok {
+		return fmt.Errorf("geometry is not a polygon: %T", geom)
+	}
+	p.Polygon = polygon
+	return nil
+}
+
+// Value реализует интерфейс driver.Valuer для записи в БД (EWKB с SRID 4326)
+func (p Polygon) Value() (driver.Value, error) {
+	hexString, err := ewkb.MarshalToHex(p.Polygon, 4326)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal polygon to EWKB: %w", err)
+	}
+	return hexString, nil
+}
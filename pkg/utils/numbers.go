@@ -32,3 +32,22 @@ func FormatNumber(val int64) string {
 		return fmt.Sprintf("%d", val)
 	}
 }
+
+// Направление изменения значения относительно предыдущего периода
+const (
+	DirectionUp   = "up"
+	DirectionDown = "down"
+	DirectionSame = "same"
+)
+
+// Direction сравнивает текущее значение с предыдущим
+func Direction(current, prev int64) string {
+	switch {
+	case current > prev:
+		return DirectionUp
+	case current < prev:
+		return DirectionDown
+	default:
+		return DirectionSame
+	}
+}
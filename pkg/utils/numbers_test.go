@@ -50,3 +50,27 @@ func TestPercent(t *testing.T) {
 		})
 	}
 }
+
+func TestDirection(t *testing.T) {
+	tests := []struct {
+		name          string
+		current, prev int64
+		want          string
+	}{
+		{"рост", 5, 3, DirectionUp},
+		{"рост с нуля", 1, 0, DirectionUp},
+		{"падение", 2, 7, DirectionDown},
+		{"падение до нуля", 0, 4, DirectionDown},
+		{"без изменений", 3, 3, DirectionSame},
+		{"оба нуля", 0, 0, DirectionSame},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Direction(tt.current, tt.prev)
+			if got != tt.want {
+				t.Errorf("Direction(%d, %d) = %q; want %q", tt.current, tt.prev, got, tt.want)
+			}
+		})
+	}
+}
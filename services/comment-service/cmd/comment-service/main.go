@@ -3,13 +3,17 @@ package main
 import (
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/database"
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/logger"
+	commentstat "github.com/RealTimeMap/RealTimeMap-backend/pkg/pb/comment"
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/runner"
+	grpcserver "github.com/RealTimeMap/RealTimeMap-backend/pkg/transport/grpc"
 	httpserver "github.com/RealTimeMap/RealTimeMap-backend/pkg/transport/http"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/app"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/config"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/model"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/infrastructure/persistence/postgres"
 	httptransport "github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/transport/http"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 )
 
 func main() {
@@ -28,15 +32,26 @@ func main() {
 		DBName:   cfg.Database.DBName,
 	}, log)
 	defer database.Close(db)
-	db.AutoMigrate(&model.Comment{}, &model.Reaction{})
+	db.AutoMigrate(&model.Comment{}, &model.Reaction{}, &model.CommentModeration{}, &model.Mention{}, &model.CommentRevision{})
+	if err := postgres.MigrateReactionCounts(db); err != nil {
+		log.Fatal("Failed to migrate reaction counts", zap.Error(err))
+	}
 
 	container := app.NewContainer(cfg, db, log)
 	defer container.Close()
 
 	httpServer := httpserver.NewServer(cfg.HTTP, log)
 	httptransport.RegisterRoutes(httpServer.Router(), container)
+	httpServer.Router().Static("/store", cfg.Storage.BasePath)
+
+	grpcServer, err := grpcserver.NewServer(cfg.GrpcServer, log, func(s *grpc.Server) {
+		commentstat.RegisterCommentStatsServiceServer(s, container.CommentStatServer)
+	})
+	if err != nil {
+		log.Fatal("Failed to start Comment Service", zap.Error(err))
+	}
 
-	if err := runner.Run(log, httpServer); err != nil {
+	if err := runner.Run(log, httpServer, grpcServer); err != nil {
 		log.Fatal("Comment Service error", zap.Error(err))
 	}
 
@@ -1,29 +1,44 @@
 package app
 
 import (
+	pkgmark "github.com/RealTimeMap/RealTimeMap-backend/pkg/clients/mark"
 	pkgprofile "github.com/RealTimeMap/RealTimeMap-backend/pkg/clients/profile"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/mediavalidator"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/storage"
 	producer2 "github.com/RealTimeMap/RealTimeMap-backend/pkg/transport/kafka/producer"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/config"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/model"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/service"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/service/comment"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/service/entity"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/service/live"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/service/moderation"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/service/stats"
+	markgrpc "github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/infrastructure/grpc/mark"
 	profilegrpc "github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/infrastructure/grpc/profile"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/infrastructure/kafka"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/infrastructure/persistence/postgres"
+	grpcstat "github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/transport/grpc/stats"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
 type Container struct {
-	CommentService *comment.Service
-	EventPublisher service.EventPublisher
-	ProfileAdapter *profilegrpc.Adapter
+	CommentService    *comment.Service
+	ModerationService *moderation.Service
+	EventPublisher    service.EventPublisher
+	ProfileAdapter    *profilegrpc.Adapter
+	LiveHub           *live.Hub
 
 	profileClient *pkgprofile.Client
+	markClient    *pkgmark.Client
 
 	StatService *stats.CommentStatsService
 	Logger      *zap.Logger
 	DB          *gorm.DB
+
+	// grpc
+	CommentStatServer *grpcstat.Handler
 }
 
 func NewContainer(cfg *config.Config, db *gorm.DB, logger *zap.Logger) *Container {
@@ -34,6 +49,18 @@ func NewContainer(cfg *config.Config, db *gorm.DB, logger *zap.Logger) *Containe
 	// Репозитории
 	commentRepo := postgres.NewPgCommentRepository(db, logger)
 	reactionRepo := postgres.NewPgReactionRepository(db, logger)
+	moderationRepo := postgres.NewPgModerationRepository(db, logger)
+	mentionRepo := postgres.NewPgMentionRepository(db, logger)
+	revisionRepo := postgres.NewPgRevisionRepository(db, logger)
+
+	// Хранилище фотографий
+	store, err := storage.NewLocalStorage(cfg.Storage.BasePath, cfg.Storage.BaseURL, logger)
+	if err != nil {
+		logger.Fatal("failed to init storage", zap.Error(err))
+	}
+	photoValidator := mediavalidator.NewPhotoValidatorWithConfig(&mediavalidator.Config{
+		MaxFileCount: model.MaxPhotos,
+	})
 
 	// Kafka producer (только если включен)
 	var publisher service.EventPublisher
@@ -62,19 +89,64 @@ func NewContainer(cfg *config.Config, db *gorm.DB, logger *zap.Logger) *Containe
 	logger.Info("Profile gRPC client initialized", zap.String("address", cfg.Profile.Address))
 	profileAdapter := profilegrpc.NewAdapter(profileClient)
 
+	// Mark gRPC client для проверки комментируемых меток (опционально)
+	var (
+		markClient  *pkgmark.Client
+		markChecker entity.Checker
+	)
+	if cfg.Mark.Address != "" {
+		c, err := pkgmark.NewClient(cfg.Mark)
+		if err != nil {
+			logger.Warn("mark client init failed, continuing without entity check", zap.Error(err))
+		} else {
+			markClient = c
+			markChecker = markgrpc.NewAdapter(c)
+			logger.Info("Mark gRPC client initialized", zap.String("address", cfg.Mark.Address))
+		}
+	}
+
+	// Комментируемые типы сущностей
+	entities := entity.NewRegistry().
+		Register(entity.Definition{Type: model.EntityMark, MaxDepth: 2, Checker: markChecker}).
+		Register(entity.Definition{
+			Type:     model.EntityProfile,
+			MaxDepth: 1,
+			Checker:  profileAdapter,
+			Policy:   entity.NewFriendsOnly(profileAdapter),
+		})
+	// Достижения регистрируются, когда у gamification-service появится API их состояния:
+	// без проверки существования к ним можно было бы писать комментарии для любого id
+
+	// Живые подписки на обсуждения получают события раньше Kafka
+	liveHub := live.NewHub(profileAdapter, entities, logger)
+	publisher = live.NewPublisher(publisher, liveHub, profileAdapter, entities, logger)
+
 	// Сервисы
-	commentService := comment.NewCommentService(commentRepo, reactionRepo, publisher, txManager, profileAdapter, logger)
+	reactionTypes := make([]model.ReactionType, 0, len(cfg.Comments.Reactions))
+	for _, t := range cfg.Comments.Reactions {
+		reactionTypes = append(reactionTypes, model.ReactionType(t))
+	}
+	commentService := comment.NewCommentService(commentRepo, reactionRepo, mentionRepo, revisionRepo, publisher, txManager, profileAdapter, entities, store, photoValidator, cfg.Comments.EditWindow, reactionTypes, logger)
+	moderationService := moderation.NewService(commentRepo, moderationRepo, revisionRepo, publisher, txManager, logger)
 	statRepo := postgres.NewPgStatisticRepositoryRepository(db, logger)
 	statService := stats.NewCommentStatsService(statRepo, logger)
 
+	// gRPC
+	commentStatGrpc := grpcstat.NewHandler(statService, logger)
+
 	return &Container{
-		CommentService: commentService,
-		EventPublisher: publisher,
-		ProfileAdapter: profileAdapter,
-		profileClient:  profileClient,
-		StatService:    statService,
-		DB:             db,
-		Logger:         logger,
+		CommentService:    commentService,
+		ModerationService: moderationService,
+		EventPublisher:    publisher,
+		ProfileAdapter:    profileAdapter,
+		LiveHub:           liveHub,
+		profileClient:     profileClient,
+		markClient:        markClient,
+		StatService:       statService,
+		DB:                db,
+		Logger:            logger,
+
+		CommentStatServer: commentStatGrpc,
 	}
 }
 
@@ -84,6 +156,11 @@ func (c *Container) Close() error {
 			c.Logger.Warn("profile gRPC client close failed", zap.Error(err))
 		}
 	}
+	if c.markClient != nil {
+		if err := c.markClient.Close(); err != nil {
+			c.Logger.Warn("mark gRPC client close failed", zap.Error(err))
+		}
+	}
 	if closer, ok := c.EventPublisher.(interface{ Close() error }); ok {
 		return closer.Close()
 	}
@@ -3,7 +3,10 @@ package config
 import (
 	"time"
 
+	pkgmark "github.com/RealTimeMap/RealTimeMap-backend/pkg/clients/mark"
 	pkgconfig "github.com/RealTimeMap/RealTimeMap-backend/pkg/config"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/storage"
+	servergrpc "github.com/RealTimeMap/RealTimeMap-backend/pkg/transport/grpc"
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/transport/http"
 )
 
@@ -27,12 +30,26 @@ type ProfileGRPC struct {
 	Timeout time.Duration `yaml:"timeout" env:"PROFILE_GRPC_TIMEOUT" env-default:"3s"`
 }
 
+type Comments struct {
+	// EditWindow сколько после публикации комментарий можно редактировать, 0 - без ограничений
+	EditWindow time.Duration `yaml:"editWindow" env:"COMMENT_EDIT_WINDOW" env-default:"0s"`
+	// Reactions допустимые типы реакций, порядок не важен
+	Reactions []string `yaml:"reactions" env:"COMMENT_REACTIONS" env-separator:"," env-default:"like,dislike,heart,laugh,wow,sad"`
+}
+
 type Config struct {
 	Env      string      `yaml:"env" env:"APP_ENV"`
 	Database Database    `yaml:"database"`
 	HTTP     http.Config `yaml:"http"`
 	Kafka    Kafka       `yaml:"kafka"`
+	Comments Comments    `yaml:"comments"`
 	Profile  ProfileGRPC `yaml:"profile"`
+	// Mark без адреса - комментарии принимаются без проверки метки
+	Mark pkgmark.Config `yaml:"mark"`
+
+	Storage storage.StorageConfig `yaml:"storage"`
+
+	GrpcServer servergrpc.Config `yaml:"grpcServer"`
 }
 
 func MustLoad() *Config {
@@ -1,6 +1,11 @@
 package domainerrors
 
-import "github.com/RealTimeMap/RealTimeMap-backend/pkg/apperror"
+import (
+	"fmt"
+	"time"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/apperror"
+)
 
 var (
 	ContentSooLong = func(inputLength int) error {
@@ -23,11 +28,69 @@ var (
 		return apperror.NewConflictError("comment.status", "is deleted", "")
 	}
 
+	EditWindowExpired = func(window time.Duration) error {
+		return apperror.NewConflictError("comment.edit", fmt.Sprintf("comment can be edited only within %s after posting", window), "")
+	}
+
+	ReactionNotAllowed = func(reaction any) error {
+		return apperror.NewFieldValidationError("type", "reaction type not allowed", "value_error.reaction.type", reaction)
+	}
+
 	NotCommentOwner = func() error {
 		return apperror.NewForbiddenError("you are not the owner")
 	}
 
+	ModerationReasonRequired = func() error {
+		return apperror.NewRequiredError("reason")
+	}
+
+	CommentNotDeleted = func() error {
+		return apperror.NewConflictError("comment.status", "is not deleted", "")
+	}
+
+	// CommentNotRestorable комментарий удален автором, исходный текст не сохранился
+	CommentNotRestorable = func(id uint) error {
+		return apperror.NewConflictError("comment.content", "removed by owner, cannot be restored", id)
+	}
+
+	TooManyPhotos = func(count, max int) error {
+		return apperror.NewFieldValidationError("photos", fmt.Sprintf("maximum %d photos allowed, got %d", max, count), "value_error.list.max_length", count)
+	}
+
+	InvalidPhoto = func(field, message, errType string, value any) error {
+		return apperror.NewFieldValidationError(field, message, errType, value)
+	}
+
+	EmptyComment = func() error {
+		return apperror.NewFieldValidationError("content", "content or at least one photo is required", "value_error.missing", nil)
+	}
+
+	PhotoUploadFailed = func(cause error) error {
+		return apperror.WrapInternalError("storage upload photos failed", cause)
+	}
+
 	ProfileUnavailable = func(cause error) error {
 		return apperror.NewServiceUnavailableError("profile-service", cause)
 	}
+
+	MarkServiceUnavailable = func(cause error) error {
+		return apperror.NewServiceUnavailableError("mark-service", cause)
+	}
+
+	EntityNotFound = func(entity string, id uint) error {
+		return apperror.NewNotFoundError(entity, "entityId", id)
+	}
+
+	CommentNotAllowed = func() error {
+		return apperror.NewForbiddenError("comments are not allowed for you")
+	}
+
+	ParentEntityMismatch = func() error {
+		return apperror.NewConflictError("parentId", "parent comment belongs to another entity", "")
+	}
+
+	// EntityEnded событие сущности закончилось, обсуждение закрыто
+	EntityEnded = func(entity string, id uint) error {
+		return apperror.NewConflictError("entityId", entity+" has ended, comments are closed", id)
+	}
 )
@@ -1,11 +1,19 @@
 package model
 
-import "gorm.io/gorm"
+import (
+	"time"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/types"
+	"gorm.io/gorm"
+)
 
 type EntityType string
 
 const (
-	EntityMark EntityType = "mark"
+	EntityMark    EntityType = "mark"
+	EntityProfile EntityType = "profile" // Стена профиля, id сущности - id пользователя
+
+	EntityAchievement EntityType = "achievement" // Открытие достижения, id сущности - id полученного пользователем достижения. Пока не зарегистрирован
 )
 
 const (
@@ -20,7 +28,7 @@ const (
 	CommentDeleted CommentStatus = "deleted"
 )
 
-const MaxDepth uint = 2 // Константа для обозначения максимальной глубины вложенности комментариев
+const MaxPhotos = 4 // Максимальное количество фотографий в одном комментарии
 
 type Comment struct {
 	gorm.Model
@@ -36,13 +44,23 @@ type Comment struct {
 	EntityType EntityType `gorm:"size:32;not null;index:idx_entity"`
 	EntityID   uint       `gorm:"not null;index:idx_entity"`
 
-	Status        CommentStatus `gorm:"type:varchar(20);not null;default:'active'"`
-	LikesCount    uint          `gorm:"default:0"`
-	DislikesCount uint          `gorm:"default:0"`
+	Status         CommentStatus  `gorm:"type:varchar(20);not null;default:'active'"`
+	ReactionCounts ReactionCounts `gorm:"type:jsonb"`
 
 	Depth uint `gorm:"not null; default:0"`
 
-	Author *UserProfile `gorm:"-" json:"-"`
+	EditedAt *time.Time // Время последней правки автором, nil - не редактировался
+
+	Mentions []Mention    `gorm:"foreignKey:CommentID"`
+	Photos   types.Photos `gorm:"type:jsonb"`
+
+	Author       *UserProfile `gorm:"-" json:"-"`
+	CanReply     bool         `gorm:"-" json:"-"` // Заполняется сервисом по глубине, допустимой для типа сущности
+	UserReaction ReactionType `gorm:"-" json:"-"` // Реакция просматривающего пользователя, пусто - не реагировал или аноним
+}
+
+func (c *Comment) IsEdited() bool {
+	return c.EditedAt != nil
 }
 
 func (c *Comment) IsDeleted() bool {
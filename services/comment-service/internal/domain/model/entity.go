@@ -0,0 +1,11 @@
+package model
+
+// EntityState состояние комментируемой сущности во внешнем сервисе.
+// Exists = false для отсутствующих, удаленных и скрытых
+type EntityState struct {
+	ID      uint
+	Exists  bool
+	Active  bool
+	Ended   bool // Событие прошло, новые комментарии не принимаются
+	OwnerID uint
+}
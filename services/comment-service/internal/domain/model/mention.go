@@ -0,0 +1,101 @@
+package model
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// MaxMentions сколько разных пользователей можно упомянуть в одном комментарии, остальные теги игнорируются
+const MaxMentions = 10
+
+// Mention упоминание пользователя в тексте комментария.
+// Offset и Length считаются в единицах UTF-16, как индексы строк в JS, чтобы клиент мог подсветить тег
+type Mention struct {
+	ID        uint   `gorm:"primarykey"`
+	CommentID uint   `gorm:"not null;index"`
+	UserID    uint   `gorm:"not null;index"`
+	Tag       string `gorm:"size:64;not null"`
+	Offset    int    `gorm:"not null"`
+	Length    int    `gorm:"not null"`
+}
+
+// MentionToken тег, найденный в тексте, до сопоставления с пользователем
+type MentionToken struct {
+	Tag    string // В нижнем регистре, вместе с @
+	Offset int
+	Length int
+}
+
+// ParseMentions находит теги вида @tag. Тег начинается после пробела, пунктуации или с начала строки,
+// точка в конце тега считается концом предложения
+func ParseMentions(content string) []MentionToken {
+	var (
+		tokens   []MentionToken
+		distinct = make(map[string]struct{})
+		prev     rune
+		pos      int
+	)
+	for i := 0; i < len(content); {
+		r, size := utf8.DecodeRuneInString(content[i:])
+		if r != '@' || (pos > 0 && isTagRune(prev)) {
+			prev = r
+			i += size
+			pos += utf16.RuneLen(r)
+			continue
+		}
+
+		// Собираем тег после @
+		j := i + size
+		for j < len(content) {
+			next, nextSize := utf8.DecodeRuneInString(content[j:])
+			if !isTagRune(next) {
+				break
+			}
+			j += nextSize
+		}
+		tag := strings.TrimRight(content[i:j], ".")
+		length := utf16Len(tag)
+
+		if length > 1 {
+			lowered := strings.ToLower(tag)
+			if _, ok := distinct[lowered]; ok || len(distinct) < MaxMentions {
+				distinct[lowered] = struct{}{}
+				tokens = append(tokens, MentionToken{Tag: lowered, Offset: pos, Length: length})
+			}
+		}
+
+		prev = '@'
+		pos += utf16Len(content[i:j])
+		i = j
+	}
+	return tokens
+}
+
+// DistinctTags уникальные теги для поиска профилей
+func DistinctTags(tokens []MentionToken) []string {
+	seen := make(map[string]struct{}, len(tokens))
+	tags := make([]string, 0, len(tokens))
+	for _, t := range tokens {
+		if _, ok := seen[t.Tag]; ok {
+			continue
+		}
+		seen[t.Tag] = struct{}{}
+		tags = append(tags, t.Tag)
+	}
+	return tags
+}
+
+// utf16Len длина строки в единицах UTF-16
+func utf16Len(s string) int {
+	n := 0
+	for _, r := range s {
+		n += utf16.RuneLen(r)
+	}
+	return n
+}
+
+func isTagRune(r rune) bool {
+	return r == '_' || r == '.' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseMentions(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []MentionToken
+	}{
+		{"без тегов", "просто текст", nil},
+		{"тег в начале строки", "@bob привет", []MentionToken{{Tag: "@bob", Offset: 0, Length: 4}}},
+		{"регистр приводится к нижнему", "привет, @Bob!", []MentionToken{{Tag: "@bob", Offset: 8, Length: 4}}},
+		{"точка в конце предложения", "спасибо @anna.", []MentionToken{{Tag: "@anna", Offset: 8, Length: 5}}},
+		{"точка внутри тега", "@john.doe пишет", []MentionToken{{Tag: "@john.doe", Offset: 0, Length: 9}}},
+		{"кириллический тег", "это @Мария", []MentionToken{{Tag: "@мария", Offset: 4, Length: 6}}},
+		{"email не тег", "пиши на mail@example.com", nil},
+		{"одиночный @", "встреча @ 10:00", nil},
+		{"emoji перед тегом занимает две единицы UTF-16", "😀 @bob", []MentionToken{{Tag: "@bob", Offset: 3, Length: 4}}},
+		{"повторный тег", "@bob и @bob", []MentionToken{
+			{Tag: "@bob", Offset: 0, Length: 4},
+			{Tag: "@bob", Offset: 7, Length: 4},
+		}},
+		{"тег после скобки", "(@bob)", []MentionToken{{Tag: "@bob", Offset: 1, Length: 4}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseMentions(tt.content)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseMentions(%q) = %+v, want %+v", tt.content, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseMentionsLimit(t *testing.T) {
+	var sb strings.Builder
+	for i := 0; i <= MaxMentions; i++ {
+		fmt.Fprintf(&sb, "@user%d ", i)
+	}
+	// Уже найденный тег засчитывается и после достижения лимита
+	sb.WriteString("@user0")
+
+	tokens := ParseMentions(sb.String())
+	if len(tokens) != MaxMentions+1 {
+		t.Fatalf("len(ParseMentions()) = %d, want %d", len(tokens), MaxMentions+1)
+	}
+	if tags := DistinctTags(tokens); len(tags) != MaxMentions {
+		t.Errorf("len(DistinctTags()) = %d, want %d", len(tags), MaxMentions)
+	}
+	if last := tokens[len(tokens)-1]; last.Tag != "@user0" {
+		t.Errorf("last tag = %s, want @user0", last.Tag)
+	}
+}
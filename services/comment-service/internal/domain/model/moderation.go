@@ -0,0 +1,43 @@
+package model
+
+import "time"
+
+type ModerationAction string
+
+const (
+	ModerationDeleted  ModerationAction = "deleted"
+	ModerationRestored ModerationAction = "restored"
+)
+
+// CommentModeration запись журнала действий модератора над комментарием
+type CommentModeration struct {
+	ID        uint             `gorm:"primarykey"`
+	CommentID uint             `gorm:"not null;index"`
+	Action    ModerationAction `gorm:"type:varchar(16);not null"`
+
+	ModeratorID   uint   `gorm:"not null;index"`
+	ModeratorName string `gorm:"not null;default:''"`
+	Reason        string `gorm:"type:varchar(500)"`
+
+	// Состояние комментария до действия, по нему восстанавливается удаленный текст
+	PrevStatus  CommentStatus `gorm:"type:varchar(20);not null"`
+	PrevContent string        `gorm:"not null"`
+
+	CreatedAt time.Time
+}
+
+// Moderator модератор, выполняющий действие
+type Moderator struct {
+	ID   uint
+	Name string
+}
+
+// AdminCommentFilter фильтр списка комментариев для модерации
+type AdminCommentFilter struct {
+	UserID      *uint
+	Entity      string
+	EntityID    *uint
+	Status      CommentStatus
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+}
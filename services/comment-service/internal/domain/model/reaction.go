@@ -1,25 +1,69 @@
 package model
 
-import "gorm.io/gorm"
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
 
-type ReactionType string
-
-const (
-	Like    ReactionType = "like"
-	Dislike ReactionType = "dislike"
+	"gorm.io/gorm"
 )
 
+// ReactionType ключ реакции (like, heart, ...). Допустимый набор задается конфигурацией
+type ReactionType string
+
 type Reaction struct {
 	gorm.Model
 
-	CommentID uint `gorm:"uniqueIndex:idx_user_comment"`
+	CommentID uint `gorm:"uniqueIndex:idx_user_comment;index:idx_comment_type"`
 	UserID    uint `gorm:"uniqueIndex:idx_user_comment"`
 
-	Type ReactionType
+	Type ReactionType `gorm:"index:idx_comment_type"`
+
+	User *UserProfile `gorm:"-" json:"-"`
+}
+
+// ReactionCounts количество реакций каждого типа. Типы без реакций не хранятся
+type ReactionCounts map[ReactionType]uint
+
+// Scan реализует интерфейс sql.Scanner для чтения ReactionCounts из БД
+func (c *ReactionCounts) Scan(val interface{}) error {
+	if val == nil {
+		*c = ReactionCounts{}
+		return nil
+	}
+
+	var data []byte
+	switch v := val.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into ReactionCounts", val)
+	}
+
+	return json.Unmarshal(data, c)
+}
+
+// Value реализует интерфейс driver.Valuer для записи ReactionCounts в БД
+func (c ReactionCounts) Value() (driver.Value, error) {
+	if len(c) == 0 {
+		return nil, nil
+	}
+
+	return json.Marshal(c)
 }
 
 type ToggleResult struct {
-	Reaction      *Reaction
-	LikesCount    uint
-	DislikesCount uint
+	Reaction *Reaction
+	Counts   ReactionCounts
+}
+
+// ReactionChange изменение реакции пользователя и счетчики комментария после него.
+// Пустой Prev - реакции не было, пустой Current - реакция снята
+type ReactionChange struct {
+	UserID  uint
+	Prev    ReactionType
+	Current ReactionType
+	Counts  ReactionCounts
 }
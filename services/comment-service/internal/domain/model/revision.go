@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+// CommentRevision текст комментария до правки. CreatedAt - время правки
+type CommentRevision struct {
+	ID        uint   `gorm:"primarykey"`
+	CommentID uint   `gorm:"not null;index"`
+	Content   string `gorm:"not null"`
+
+	CreatedAt time.Time
+}
@@ -0,0 +1,38 @@
+package model
+
+import (
+	"time"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/date"
+)
+
+// StatPeriod текущий и предыдущий периоды сравнения [Start, End). Нулевые границы - без ограничений
+type StatPeriod struct {
+	Start     time.Time
+	End       time.Time
+	PrevStart time.Time
+	PrevEnd   time.Time
+}
+
+// NewStatPeriod границы периода из запроса. Для AllTime период без границ
+func NewStatPeriod(params date.Resolved) StatPeriod {
+	var period StatPeriod
+	if start, end := params.Current(); start != nil && end != nil {
+		period.Start, period.End = *start, *end
+	}
+	if start, end := params.Previous(); start != nil && end != nil {
+		period.PrevStart, period.PrevEnd = *start, *end
+	}
+	return period
+}
+
+// IsAllTime период без границ, сравнивать не с чем
+func (p StatPeriod) IsAllTime() bool {
+	return p.Start.IsZero() || p.End.IsZero()
+}
+
+// PeriodCount значение за текущий период в сравнении с предыдущим
+type PeriodCount struct {
+	Count     int64
+	PrevCount int64
+}
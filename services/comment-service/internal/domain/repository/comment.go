@@ -3,6 +3,8 @@ package repository
 import (
 	"context"
 
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/pagination"
+
 	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/model"
 )
 
@@ -12,5 +14,12 @@ type CommentRepository interface {
 	GetByID(ctx context.Context, id uint) (*model.Comment, error)
 	GetComments(ctx context.Context, filters model.CommentFilter) ([]*model.Comment, bool, error)
 	Update(ctx context.Context, comment *model.Comment) (*model.Comment, error)
-	IncrementCounter(ctx context.Context, commentID uint, column string, delta int) error
+	// IncrementReaction меняет счетчик реакции типа t на delta, обнулившийся счетчик удаляется
+	IncrementReaction(ctx context.Context, commentID uint, t model.ReactionType, delta int) error
+
+	// GetAdminComments список комментариев для модерации, включая удаленные
+	GetAdminComments(ctx context.Context, filter model.AdminCommentFilter, params pagination.Params) ([]*model.Comment, int64, error)
+	GetActiveByUser(ctx context.Context, userID uint) ([]*model.Comment, error)
+	// MarkDeleted помечает комментарии удаленными с заменой текста
+	MarkDeleted(ctx context.Context, ids []uint, content string) error
 }
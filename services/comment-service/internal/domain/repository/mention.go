@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/model"
+)
+
+type MentionRepository interface {
+	// Replace заменяет упоминания комментария новым набором
+	Replace(ctx context.Context, commentID uint, mentions []model.Mention) error
+}
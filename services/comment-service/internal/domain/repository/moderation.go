@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/model"
+)
+
+type ModerationRepository interface {
+	Create(ctx context.Context, records ...*model.CommentModeration) error
+	// GetLastDeletion последнее удаление комментария модератором, nil если его не было
+	GetLastDeletion(ctx context.Context, commentID uint) (*model.CommentModeration, error)
+	GetByComment(ctx context.Context, commentID uint) ([]*model.CommentModeration, error)
+}
@@ -3,6 +3,8 @@ package repository
 import (
 	"context"
 
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/pagination"
+
 	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/model"
 )
 
@@ -11,4 +13,8 @@ type ReactionRepository interface {
 	Create(ctx context.Context, reaction *model.Reaction) error
 	Delete(ctx context.Context, id uint) error
 	UpdateType(ctx context.Context, id uint, newType model.ReactionType) error
+	// GetUserReactions реакции пользователя на комментарии, ключ - id комментария
+	GetUserReactions(ctx context.Context, userID uint, commentIDs []uint) (map[uint]model.ReactionType, error)
+	// GetByCommentAndType реакции типа t на комментарий, новые первыми
+	GetByCommentAndType(ctx context.Context, commentID uint, t model.ReactionType, params pagination.Params) ([]*model.Reaction, int64, error)
 }
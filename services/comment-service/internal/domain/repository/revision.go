@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/model"
+)
+
+type RevisionRepository interface {
+	Create(ctx context.Context, revision *model.CommentRevision) error
+	// GetByComment ревизии комментария, новые первыми
+	GetByComment(ctx context.Context, commentID uint) ([]*model.CommentRevision, error)
+}
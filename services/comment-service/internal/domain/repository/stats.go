@@ -3,10 +3,10 @@ package repository
 import (
 	"context"
 
-	"github.com/RealTimeMap/RealTimeMap-backend/pkg/date"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/model"
 )
 
 type StatisticRepository interface {
-	GetCountsByPeriod(ctx context.Context, userID uint, params date.Resolved) (int64, int64, error)
+	GetCountsByPeriod(ctx context.Context, userID uint, period model.StatPeriod) (model.PeriodCount, error)
 	GetAllUsersComments(ctx context.Context, userID uint) (int64, error)
 }
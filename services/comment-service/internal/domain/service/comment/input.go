@@ -1,6 +1,9 @@
 package comment
 
-import "github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/model"
+import (
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/mediavalidator"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/model"
+)
 
 type CreateInput struct {
 	Content    string
@@ -8,10 +11,13 @@ type CreateInput struct {
 	EntityID   uint
 
 	ParentID *uint
+	Photos   []mediavalidator.PhotoInput
 }
 
 type UpdateInput struct {
-	Content string
+	Content        string
+	Photos         []mediavalidator.PhotoInput // Новые фотографии, добавляются к оставшимся
+	PhotosToDelete []string                    // URL фотографий, которые нужно убрать
 }
 
 type ToggleReactionInput struct {
@@ -0,0 +1,90 @@
+package comment
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/mediavalidator"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/storage"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/types"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/domainerrors"
+	"go.uber.org/zap"
+)
+
+const photoMaxSize = 5 * 1024 * 1024 // 5MB
+
+// uploadPhotos валидирует и загружает фотографии комментария вместе с миниатюрами.
+// Если часть файлов не загрузилась, уже загруженные удаляются
+func (s *Service) uploadPhotos(ctx context.Context, photos []mediavalidator.PhotoInput) (types.Photos, error) {
+	if len(photos) == 0 {
+		return nil, nil
+	}
+	if err := s.photoValidator.ValidatePhotos(photos); err != nil {
+		var photoErr mediavalidator.PhotoValidationError
+		if errors.As(err, &photoErr) {
+			v := photoErr.ToValidationError()
+			return nil, domainerrors.InvalidPhoto(fmt.Sprint(v.Loc[len(v.Loc)-1]), v.Msg, v.ErrType, v.Input)
+		}
+		return nil, err
+	}
+
+	fileUploads := make([]storage.FileUpload, 0, len(photos))
+	for _, photo := range photos {
+		fileUploads = append(fileUploads, storage.FileUpload{
+			Reader: bytes.NewReader(photo.Data),
+			Options: storage.UploadOptions{
+				FileName:      photo.FileName,
+				Category:      storage.CategoryCommentPhoto,
+				MaxSize:       photoMaxSize,
+				GenerateThumb: true,
+				Optimize:      true,
+			},
+		})
+	}
+
+	uploaded, err := s.store.UploadMultiple(ctx, fileUploads)
+	if err != nil {
+		return nil, domainerrors.PhotoUploadFailed(err)
+	}
+	// UploadMultiple пропускает файлы, которые не удалось сохранить
+	if len(uploaded) != len(photos) {
+		s.removePhotos(ctx, uploaded)
+		return nil, domainerrors.PhotoUploadFailed(fmt.Errorf("uploaded %d of %d photos", len(uploaded), len(photos)))
+	}
+	return uploaded, nil
+}
+
+// removePhotos удаляет файлы фотографий из хранилища. Ошибки только логируются:
+// файл мог быть удален раньше, а запись в БД уже изменена
+func (s *Service) removePhotos(ctx context.Context, photos types.Photos) {
+	keys := make([]string, 0, len(photos))
+	for _, photo := range photos {
+		if photo.StorageKey != "" {
+			keys = append(keys, photo.StorageKey)
+		}
+	}
+	if len(keys) == 0 {
+		return
+	}
+	if err := s.store.DeleteMultiple(ctx, keys); err != nil {
+		s.logger.Warn("failed to delete comment photos", zap.Strings("storage_keys", keys), zap.Error(err))
+	}
+}
+
+// splitPhotos делит фотографии комментария на оставшиеся и удаляемые по URL
+func splitPhotos(current types.Photos, toDelete []string) (kept, removed types.Photos) {
+	deleteSet := make(map[string]struct{}, len(toDelete))
+	for _, url := range toDelete {
+		deleteSet[url] = struct{}{}
+	}
+	for _, photo := range current {
+		if _, ok := deleteSet[photo.URL]; ok {
+			removed = append(removed, photo)
+		} else {
+			kept = append(kept, photo)
+		}
+	}
+	return kept, removed
+}
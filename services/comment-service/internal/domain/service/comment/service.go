@@ -2,13 +2,18 @@ package comment
 
 import (
 	"context"
+	"strings"
 	"time"
 
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/mediavalidator"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/pagination"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/storage"
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/utils"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/domainerrors"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/model"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/repository"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/service"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/service/entity"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/infrastructure/grpc/profile"
 	"go.uber.org/zap"
 )
@@ -16,37 +21,67 @@ import (
 type Service struct {
 	commentRepo  repository.CommentRepository
 	reactionRepo repository.ReactionRepository
+	mentionRepo  repository.MentionRepository
+	revisionRepo repository.RevisionRepository
 	producer     service.EventPublisher
 	txManager    service.TxManager
 
 	profileAdapter *profile.Adapter
+	entities       *entity.Registry
+	store          storage.Storage
+	photoValidator *mediavalidator.PhotoValidator
+	editWindow     time.Duration // Сколько после публикации можно редактировать, 0 - без ограничений
+	reactionTypes  map[model.ReactionType]struct{}
 	logger         *zap.Logger
 }
 
 func NewCommentService(
 	commentRepo repository.CommentRepository,
 	reactionRepo repository.ReactionRepository,
+	mentionRepo repository.MentionRepository,
+	revisionRepo repository.RevisionRepository,
 	producer service.EventPublisher,
 	txManager service.TxManager,
 	profileAdapter *profile.Adapter,
+	entities *entity.Registry,
+	store storage.Storage,
+	photoValidator *mediavalidator.PhotoValidator,
+	editWindow time.Duration,
+	reactionTypes []model.ReactionType,
 	logger *zap.Logger,
 ) *Service {
+	allowed := make(map[model.ReactionType]struct{}, len(reactionTypes))
+	for _, t := range reactionTypes {
+		allowed[t] = struct{}{}
+	}
 	return &Service{
 		commentRepo:    commentRepo,
 		reactionRepo:   reactionRepo,
+		mentionRepo:    mentionRepo,
+		revisionRepo:   revisionRepo,
 		producer:       producer,
 		txManager:      txManager,
 		profileAdapter: profileAdapter,
+		entities:       entities,
+		store:          store,
+		photoValidator: photoValidator,
+		editWindow:     editWindow,
+		reactionTypes:  allowed,
 		logger:         logger,
 	}
 }
 
-// TODO В будущем добавить проверку существавание записи выбранной модели через gRPC
-
 func (s *Service) Create(ctx context.Context, input CreateInput, userID uint, username string) (*model.Comment, error) {
 	if err := s.validateCreateInput(input); err != nil {
 		return nil, err
 	}
+	def, err := s.entities.Get(model.EntityType(input.EntityType))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkEntity(ctx, def, input.EntityID, userID); err != nil {
+		return nil, err
+	}
 
 	comment := &model.Comment{
 		UserID:     userID,
@@ -63,32 +98,47 @@ func (s *Service) Create(ctx context.Context, input CreateInput, userID uint, us
 			return nil, err
 		}
 
-		if parent.Depth >= model.MaxDepth {
+		// Ответ в чужое обсуждение обошел бы проверки сущности
+		if parent.EntityType != comment.EntityType || parent.EntityID != comment.EntityID {
+			return nil, domainerrors.ParentEntityMismatch()
+		}
+		if !def.CanReply(parent.Depth) {
 			return nil, domainerrors.CommentMaxDepthReached()
 		}
 		comment.ParentID = input.ParentID
 		comment.Depth = parent.Depth + 1
 	}
 
-	newComment, err := s.commentRepo.Create(ctx, comment)
+	// Упоминания сохраняются вместе с комментарием
+	comment.Mentions = s.resolveMentions(ctx, comment.Content, userID)
+
+	photos, err := s.uploadPhotos(ctx, input.Photos)
 	if err != nil {
 		return nil, err
 	}
+	comment.Photos = photos
 
-	go func() {
-		publishCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-
-		if err := s.producer.PublishCommentCreated(publishCtx, newComment); err != nil {
-			s.logger.Warn("Failed to publish comment created event", zap.Error(err))
-		}
-	}()
+	newComment, err := s.commentRepo.Create(ctx, comment)
+	if err != nil {
+		s.removePhotos(ctx, photos)
+		return nil, err
+	}
 
+	// Автор загружается до публикации, чтобы живые подписки не запрашивали его повторно
 	s.attachAuthors(ctx, []*model.Comment{newComment})
+	s.markReplyable([]*model.Comment{newComment})
+
+	// Событие получает копию комментария
+	created := *newComment
+	s.publishEvent(ctx, "comment created", func(ctx context.Context) error {
+		return s.producer.PublishCommentCreated(ctx, &created)
+	})
+	s.notifyMentioned(ctx, newComment, nil)
 	return newComment, nil
 }
 
-func (s *Service) GetComments(ctx context.Context, filters model.CommentFilter) ([]*model.Comment, bool, error) {
+// GetComments страница комментариев. viewerID - просматривающий пользователь, 0 для анонимов
+func (s *Service) GetComments(ctx context.Context, filters model.CommentFilter, viewerID uint) ([]*model.Comment, bool, error) {
 	s.logger.Info("start CommentService.GetComments")
 
 	comments, hasMore, err := s.commentRepo.GetComments(ctx, filters)
@@ -97,6 +147,8 @@ func (s *Service) GetComments(ctx context.Context, filters model.CommentFilter)
 	}
 
 	s.attachAuthors(ctx, comments)
+	s.markReplyable(comments)
+	s.attachUserReactions(ctx, comments, viewerID)
 	return comments, hasMore, nil
 }
 
@@ -115,14 +167,22 @@ func (s *Service) SoftDelete(ctx context.Context, userID, commentID uint) error
 		return err
 	}
 
+	// В событие уходит комментарий в состоянии до удаления
+	deleted := *comment
 	comment.Content = model.OwnerDeletedContent
 	comment.Status = model.CommentDeleted
+	// Удаление автором окончательное, фотографии больше не нужны
+	comment.Photos = nil
 
 	_, err = s.commentRepo.Update(ctx, comment)
 	if err != nil {
 		return err
 	}
-	// TODO kafka message
+	s.removePhotos(ctx, deleted.Photos)
+
+	s.publishEvent(ctx, "comment deleted", func(ctx context.Context) error {
+		return s.producer.PublishCommentDeleted(ctx, &deleted, userID, "")
+	})
 	return nil
 }
 
@@ -138,20 +198,94 @@ func (s *Service) UpdateComment(ctx context.Context, input UpdateInput, userID,
 	if err := s.checkIsDeleted(comment); err != nil {
 		return nil, err
 	}
+	if s.editWindow > 0 && time.Since(comment.CreatedAt) > s.editWindow {
+		return nil, domainerrors.EditWindowExpired(s.editWindow)
+	}
+
+	kept, removed := splitPhotos(comment.Photos, input.PhotosToDelete)
+	total := len(kept) + len(input.Photos)
+	if total > model.MaxPhotos {
+		return nil, domainerrors.TooManyPhotos(total, model.MaxPhotos)
+	}
+	if strings.TrimSpace(input.Content) == "" && total == 0 {
+		return nil, domainerrors.EmptyComment()
+	}
+	uploaded, err := s.uploadPhotos(ctx, input.Photos)
+	if err != nil {
+		return nil, err
+	}
+
+	prevContent := comment.Content
+	prevMentions := comment.Mentions
+	mentions := s.resolveMentions(ctx, input.Content, userID)
+
+	contentChanged := prevContent != input.Content
+	if contentChanged || len(removed) > 0 || len(uploaded) > 0 {
+		now := time.Now()
+		comment.EditedAt = &now
+	}
 
 	comment.Content = input.Content
-	newComment, err := s.commentRepo.Update(ctx, comment)
+	comment.Photos = append(kept, uploaded...)
+	// Упоминания заменяются отдельно, иначе Save сохранит старые
+	comment.Mentions = nil
+
+	var newComment *model.Comment
+	err = s.txManager.WithTx(ctx, func(txCtx context.Context) error {
+		var err error
+		if newComment, err = s.commentRepo.Update(txCtx, comment); err != nil {
+			return err
+		}
+		// Прежний текст сохраняется ревизией, чтобы правка была видна автору и модераторам
+		if contentChanged {
+			revision := &model.CommentRevision{CommentID: newComment.ID, Content: prevContent}
+			if err := s.revisionRepo.Create(txCtx, revision); err != nil {
+				return err
+			}
+		}
+		return s.mentionRepo.Replace(txCtx, newComment.ID, mentions)
+	})
 	if err != nil {
+		s.removePhotos(ctx, uploaded)
 		return nil, err
 	}
+	newComment.Mentions = mentions
+	// Убранные фотографии удаляются только после сохранения комментария
+	s.removePhotos(ctx, removed)
 
 	s.attachAuthors(ctx, []*model.Comment{newComment})
+	s.markReplyable([]*model.Comment{newComment})
+
+	updated := *newComment
+	s.publishEvent(ctx, "comment updated", func(ctx context.Context) error {
+		return s.producer.PublishCommentUpdated(ctx, &updated, prevContent)
+	})
+	// Уведомляем только тех, кто не был упомянут до правки
+	s.notifyMentioned(ctx, &updated, prevMentions)
+
+	s.attachUserReactions(ctx, []*model.Comment{newComment}, userID)
 	return newComment, nil
 }
 
+// GetRevisions прежние версии текста комментария, доступны только автору
+func (s *Service) GetRevisions(ctx context.Context, userID, commentID uint) ([]*model.CommentRevision, error) {
+	s.logger.Info("start CommentService.GetRevisions")
+	comment, err := s.commentRepo.GetByID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkOwnerShip(userID, comment); err != nil {
+		return nil, err
+	}
+	return s.revisionRepo.GetByComment(ctx, commentID)
+}
+
 func (s *Service) ToggleReaction(ctx context.Context, input ToggleReactionInput, userID, commentID uint) (*model.ToggleResult, error) {
 	s.logger.Info("start CommentService.ToggleReaction")
 
+	if err := s.checkReactionType(input.Type); err != nil {
+		return nil, err
+	}
 	comment, err := s.commentRepo.GetByID(ctx, commentID)
 	if err != nil {
 		return nil, err
@@ -160,7 +294,10 @@ func (s *Service) ToggleReaction(ctx context.Context, input ToggleReactionInput,
 		return nil, domainerrors.CommentIsDeleted()
 	}
 
-	var result model.ToggleResult
+	var (
+		result model.ToggleResult
+		prev   model.ReactionType
+	)
 
 	err = s.txManager.WithTx(ctx, func(txCtx context.Context) error {
 		existing, err := s.reactionRepo.FindByUserAndComment(txCtx, userID, commentID)
@@ -178,29 +315,31 @@ func (s *Service) ToggleReaction(ctx context.Context, input ToggleReactionInput,
 			if err := s.reactionRepo.Create(txCtx, reaction); err != nil {
 				return err
 			}
-			if err := s.commentRepo.IncrementCounter(txCtx, commentID, counterColumn(input.Type), 1); err != nil {
+			if err := s.commentRepo.IncrementReaction(txCtx, commentID, input.Type, 1); err != nil {
 				return err
 			}
 			result.Reaction = reaction
 		} else if existing.Type == input.Type {
 			// Та же реакция → удаляем
+			prev = existing.Type
 			if err := s.reactionRepo.Delete(txCtx, existing.ID); err != nil {
 				return err
 			}
-			if err := s.commentRepo.IncrementCounter(txCtx, commentID, counterColumn(input.Type), -1); err != nil {
+			if err := s.commentRepo.IncrementReaction(txCtx, commentID, input.Type, -1); err != nil {
 				return err
 			}
 			result.Reaction = nil
 		} else {
 			// Другая реакция → переключаем
 			oldType := existing.Type
+			prev = oldType
 			if err := s.reactionRepo.UpdateType(txCtx, existing.ID, input.Type); err != nil {
 				return err
 			}
-			if err := s.commentRepo.IncrementCounter(txCtx, commentID, counterColumn(oldType), -1); err != nil {
+			if err := s.commentRepo.IncrementReaction(txCtx, commentID, oldType, -1); err != nil {
 				return err
 			}
-			if err := s.commentRepo.IncrementCounter(txCtx, commentID, counterColumn(input.Type), 1); err != nil {
+			if err := s.commentRepo.IncrementReaction(txCtx, commentID, input.Type, 1); err != nil {
 				return err
 			}
 			existing.Type = input.Type
@@ -212,8 +351,7 @@ func (s *Service) ToggleReaction(ctx context.Context, input ToggleReactionInput,
 		if err != nil {
 			return err
 		}
-		result.LikesCount = updated.LikesCount
-		result.DislikesCount = updated.DislikesCount
+		result.Counts = updated.ReactionCounts
 
 		return nil
 	})
@@ -221,9 +359,144 @@ func (s *Service) ToggleReaction(ctx context.Context, input ToggleReactionInput,
 	if err != nil {
 		return nil, err
 	}
+
+	change := model.ReactionChange{
+		UserID: userID,
+		Prev:   prev,
+		Counts: result.Counts,
+	}
+	if result.Reaction != nil {
+		change.Current = result.Reaction.Type
+	}
+	s.publishEvent(ctx, "reaction toggled", func(ctx context.Context) error {
+		return s.producer.PublishReactionToggled(ctx, comment, change)
+	})
 	return &result, nil
 }
 
+// GetReactors пользователи, поставившие комментарию реакцию типа t, с профилями
+func (s *Service) GetReactors(ctx context.Context, commentID uint, t model.ReactionType, params pagination.Params) ([]*model.Reaction, int64, error) {
+	s.logger.Info("start CommentService.GetReactors")
+
+	if err := s.checkReactionType(t); err != nil {
+		return nil, 0, err
+	}
+	comment, err := s.commentRepo.GetByID(ctx, commentID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := s.checkIsDeleted(comment); err != nil {
+		return nil, 0, err
+	}
+
+	reactions, count, err := s.reactionRepo.GetByCommentAndType(ctx, commentID, t, params)
+	if err != nil {
+		return nil, 0, err
+	}
+	s.attachReactors(ctx, reactions)
+	return reactions, count, nil
+}
+
+// checkEntity проверяет, что сущность существует, обсуждение не закрыто и пользователю можно комментировать.
+// Если сервис-владелец недоступен, существование не проверяется, но права проверяются всегда
+func (s *Service) checkEntity(ctx context.Context, def entity.Definition, entityID, userID uint) error {
+	state := model.EntityState{ID: entityID, Exists: true}
+	if def.Checker != nil {
+		states, err := def.Checker.GetEntityStates(ctx, []uint{entityID})
+		switch {
+		case entity.Unavailable(err):
+			s.logger.Warn("entity owner service degraded, skipping entity check",
+				zap.String("entity_type", string(def.Type)), zap.Uint("entity_id", entityID), zap.Error(err))
+		case err != nil:
+			return err
+		case len(states) == 0 || !states[0].Exists:
+			return domainerrors.EntityNotFound(string(def.Type), entityID)
+		default:
+			state = states[0]
+		}
+	}
+	if state.Ended {
+		return domainerrors.EntityEnded(string(def.Type), entityID)
+	}
+	if def.Policy != nil {
+		return def.Policy.CanComment(ctx, userID, state)
+	}
+	return nil
+}
+
+// markReplyable отмечает комментарии, на которые еще можно ответить при глубине, допустимой для их типа
+func (s *Service) markReplyable(comments []*model.Comment) {
+	for _, c := range comments {
+		def, err := s.entities.Get(c.EntityType)
+		c.CanReply = err == nil && def.CanReply(c.Depth)
+	}
+}
+
+// resolveMentions сопоставляет теги из текста с пользователями. Пользователи в блокировке с автором
+// не находятся. Без profile-service комментарий сохраняется без упоминаний
+func (s *Service) resolveMentions(ctx context.Context, content string, authorID uint) []model.Mention {
+	tokens := model.ParseMentions(content)
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	profiles, err := s.profileAdapter.GetUserProfilesByTags(ctx, model.DistinctTags(tokens), authorID)
+	if err != nil {
+		s.logger.Warn("profile-service degraded, skipping mentions", zap.Error(err))
+		return nil
+	}
+	byTag := make(map[string]*model.UserProfile, len(profiles))
+	for _, p := range profiles {
+		byTag[strings.ToLower(p.Tag)] = p
+	}
+
+	mentions := make([]model.Mention, 0, len(tokens))
+	for _, token := range tokens {
+		p, ok := byTag[token.Tag]
+		if !ok {
+			continue
+		}
+		mentions = append(mentions, model.Mention{
+			UserID: p.ID,
+			Tag:    p.Tag,
+			Offset: token.Offset,
+			Length: token.Length,
+		})
+	}
+	return mentions
+}
+
+// notifyMentioned публикует comment.mentioned для каждого упомянутого пользователя, кроме автора и уже упомянутых ранее
+func (s *Service) notifyMentioned(ctx context.Context, comment *model.Comment, prev []model.Mention) {
+	skip := map[uint]struct{}{comment.UserID: {}}
+	for _, m := range prev {
+		skip[m.UserID] = struct{}{}
+	}
+
+	var users []uint
+	for _, m := range comment.Mentions {
+		if _, ok := skip[m.UserID]; ok {
+			continue
+		}
+		skip[m.UserID] = struct{}{}
+		users = append(users, m.UserID)
+	}
+
+	for _, userID := range users {
+		s.publishEvent(ctx, "comment mentioned", func(ctx context.Context) error {
+			return s.producer.PublishCommentMentioned(ctx, comment, userID)
+		})
+	}
+}
+
+// publishEvent передает событие издателю. Издатель не ждет брокер: живым подпискам событие отдается
+// сразу, в Kafka - в фоне (см. live.Publisher), поэтому порядок событий совпадает с порядком изменений
+func (s *Service) publishEvent(ctx context.Context, name string, publish func(ctx context.Context) error) {
+	if err := publish(ctx); err != nil {
+		s.logger.Warn("Failed to publish "+name+" event", zap.Error(err))
+	}
+}
+
 func (s *Service) checkOwnerShip(userID uint, comment *model.Comment) error {
 	if comment.UserID != userID {
 		return domainerrors.NotCommentOwner()
@@ -242,14 +515,18 @@ func (s *Service) validateCreateInput(input CreateInput) error {
 	if len(input.Content) > 1024 {
 		return domainerrors.ContentSooLong(len(input.Content))
 	}
+	// Комментарий может состоять только из фотографий
+	if strings.TrimSpace(input.Content) == "" && len(input.Photos) == 0 {
+		return domainerrors.EmptyComment()
+	}
 	return nil
 }
 
-func counterColumn(t model.ReactionType) string {
-	if t == model.Like {
-		return "likes_count"
+func (s *Service) checkReactionType(t model.ReactionType) error {
+	if _, ok := s.reactionTypes[t]; !ok {
+		return domainerrors.ReactionNotAllowed(t)
 	}
-	return "dislikes_count"
+	return nil
 }
 
 func (s *Service) getUsersIDs(comments []*model.Comment) []uint {
@@ -292,3 +569,54 @@ func localFallback(c *model.Comment) *model.UserProfile {
 		Username: c.Username,
 	}
 }
+
+// attachUserReactions проставляет реакции просматривающего пользователя. Ошибка не мешает
+// отдать комментарии, поэтому только логируется
+func (s *Service) attachUserReactions(ctx context.Context, comments []*model.Comment, viewerID uint) {
+	if viewerID == 0 || len(comments) == 0 {
+		return
+	}
+
+	ids := make([]uint, len(comments))
+	for i, c := range comments {
+		ids[i] = c.ID
+	}
+	reactions, err := s.reactionRepo.GetUserReactions(ctx, viewerID, ids)
+	if err != nil {
+		s.logger.Warn("failed to load user reactions", zap.Uint("user_id", viewerID), zap.Error(err))
+		return
+	}
+	for _, c := range comments {
+		c.UserReaction = reactions[c.ID]
+	}
+}
+
+// attachReactors подставляет профили поставивших реакции, при недоступности profile-service - только id
+func (s *Service) attachReactors(ctx context.Context, reactions []*model.Reaction) {
+	if len(reactions) == 0 {
+		return
+	}
+
+	ids := make([]uint, len(reactions))
+	for i, r := range reactions {
+		ids[i] = r.UserID
+	}
+	profiles, err := s.profileAdapter.GetUserProfileByIDs(ctx, utils.UniqueValues(ids))
+
+	byID := make(map[uint]*model.UserProfile, len(profiles))
+	if err != nil {
+		s.logger.Warn("profile-service degraded, reactors returned without profiles", zap.Error(err))
+	} else {
+		for _, p := range profiles {
+			byID[p.ID] = p
+		}
+	}
+
+	for _, r := range reactions {
+		if p, ok := byID[r.UserID]; ok {
+			r.User = p
+		} else {
+			r.User = &model.UserProfile{ID: r.UserID}
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"context"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/domainerrors"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/model"
+)
+
+// FriendChecker проверяет дружбу между пользователями
+type FriendChecker interface {
+	AreFriends(ctx context.Context, userID, otherID uint) (bool, error)
+}
+
+// FriendsOnly комментировать может владелец сущности и его друзья.
+// Для стены профиля владелец - пользователь с id сущности
+type FriendsOnly struct {
+	friends FriendChecker
+}
+
+func NewFriendsOnly(friends FriendChecker) *FriendsOnly {
+	return &FriendsOnly{friends: friends}
+}
+
+func (p *FriendsOnly) CanComment(ctx context.Context, userID uint, state model.EntityState) error {
+	ownerID := state.OwnerID
+	if ownerID == 0 {
+		ownerID = state.ID
+	}
+	if userID == ownerID {
+		return nil
+	}
+
+	friends, err := p.friends.AreFriends(ctx, userID, ownerID)
+	if err != nil {
+		return err
+	}
+	if !friends {
+		return domainerrors.CommentNotAllowed()
+	}
+	return nil
+}
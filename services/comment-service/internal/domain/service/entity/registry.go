@@ -0,0 +1,64 @@
+package entity
+
+import (
+	"context"
+	"errors"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/apperror"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/domainerrors"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/model"
+)
+
+// Checker получает состояние комментируемых сущностей из сервиса-владельца
+type Checker interface {
+	GetEntityStates(ctx context.Context, ids []uint) ([]model.EntityState, error)
+}
+
+// Unavailable сервис-владелец не ответил: недоступен или истек таймаут.
+// Только в этом случае проверку сущности можно пропустить, остальные ошибки возвращаются
+func Unavailable(err error) bool {
+	var unavailable *apperror.ServiceUnavailableError
+	return errors.As(err, &unavailable) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// Policy решает, может ли пользователь комментировать сущность
+type Policy interface {
+	CanComment(ctx context.Context, userID uint, state model.EntityState) error
+}
+
+// Definition описание комментируемого типа. Новый тип подключается регистрацией в Registry
+type Definition struct {
+	Type     model.EntityType
+	MaxDepth uint    // Максимальная глубина ответов, 0 - ответы запрещены
+	Checker  Checker // Проверка существования, nil - без проверки
+	Policy   Policy  // Права на комментирование, nil - доступно всем
+}
+
+// CanReply можно ли ответить на комментарий глубины depth
+func (d Definition) CanReply(depth uint) bool {
+	return depth < d.MaxDepth
+}
+
+// Registry зарегистрированные типы сущностей, которые можно комментировать
+type Registry struct {
+	defs map[model.EntityType]Definition
+}
+
+func NewRegistry() *Registry {
+	return &Registry{defs: make(map[model.EntityType]Definition)}
+}
+
+// Register добавляет тип, повторная регистрация заменяет описание
+func (r *Registry) Register(def Definition) *Registry {
+	r.defs[def.Type] = def
+	return r
+}
+
+// Get описание типа, для незарегистрированных - EntityTypeNotAllowed
+func (r *Registry) Get(entityType model.EntityType) (Definition, error) {
+	def, ok := r.defs[entityType]
+	if !ok {
+		return Definition{}, domainerrors.EntityTypeNotAllowed(string(entityType))
+	}
+	return def, nil
+}
@@ -0,0 +1,121 @@
+package entity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/apperror"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/model"
+)
+
+func TestRegistryGet(t *testing.T) {
+	registry := NewRegistry().
+		Register(Definition{Type: model.EntityMark, MaxDepth: 2}).
+		Register(Definition{Type: model.EntityProfile, MaxDepth: 1}).
+		Register(Definition{Type: model.EntityMark, MaxDepth: 3})
+
+	tests := []struct {
+		name         string
+		entityType   model.EntityType
+		wantErr      bool
+		wantMaxDepth uint
+	}{
+		{"повторная регистрация заменяет описание", model.EntityMark, false, 3},
+		{"зарегистрированный тип", model.EntityProfile, false, 1},
+		{"незарегистрированный тип", model.EntityAchievement, true, 0},
+		{"пустой тип", "", true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			def, err := registry.Get(tt.entityType)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Get(%q) error = %v, wantErr %v", tt.entityType, err, tt.wantErr)
+			}
+			if def.MaxDepth != tt.wantMaxDepth {
+				t.Errorf("Get(%q).MaxDepth = %d, want %d", tt.entityType, def.MaxDepth, tt.wantMaxDepth)
+			}
+		})
+	}
+}
+
+func TestDefinitionCanReply(t *testing.T) {
+	tests := []struct {
+		name     string
+		maxDepth uint
+		depth    uint
+		want     bool
+	}{
+		{"ответы запрещены", 0, 0, false},
+		{"ответ на корневой", 1, 0, true},
+		{"достигнута глубина", 1, 1, false},
+		{"вложенный ответ", 2, 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (Definition{MaxDepth: tt.maxDepth}).CanReply(tt.depth); got != tt.want {
+				t.Errorf("CanReply(%d) = %v, want %v", tt.depth, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnavailable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"сервис недоступен", fmt.Errorf("get states: %w", apperror.NewServiceUnavailableError("mark-service", nil)), true},
+		{"истек таймаут", context.DeadlineExceeded, true},
+		{"другая ошибка", errors.New("invalid argument"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Unavailable(tt.err); got != tt.want {
+				t.Errorf("Unavailable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+type fakeFriends struct {
+	friends map[[2]uint]bool
+	err     error
+}
+
+func (f fakeFriends) AreFriends(_ context.Context, userID, otherID uint) (bool, error) {
+	return f.friends[[2]uint{userID, otherID}], f.err
+}
+
+func TestFriendsOnlyCanComment(t *testing.T) {
+	errUnavailable := errors.New("profile service unavailable")
+	friends := map[[2]uint]bool{{2, 1}: true}
+
+	tests := []struct {
+		name    string
+		checker fakeFriends
+		userID  uint
+		state   model.EntityState
+		wantErr bool
+	}{
+		{"владелец стены", fakeFriends{}, 1, model.EntityState{ID: 1}, false},
+		{"друг владельца стены", fakeFriends{friends: friends}, 2, model.EntityState{ID: 1}, false},
+		{"не друг", fakeFriends{friends: friends}, 3, model.EntityState{ID: 1}, true},
+		{"владелец указан явно", fakeFriends{friends: friends}, 2, model.EntityState{ID: 10, OwnerID: 1}, false},
+		{"ошибка сервиса профилей", fakeFriends{err: errUnavailable}, 2, model.EntityState{ID: 1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewFriendsOnly(tt.checker).CanComment(context.Background(), tt.userID, tt.state)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CanComment() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package live
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/domainerrors"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/model"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/service/entity"
+	"go.uber.org/zap"
+)
+
+const subscriptionBuffer = 32
+
+type EventType string
+
+const (
+	EventCreated  EventType = "commentCreated"
+	EventUpdated  EventType = "commentUpdated"
+	EventDeleted  EventType = "commentDeleted"
+	EventRestored EventType = "commentRestored"
+	EventReaction EventType = "reactionChanged"
+)
+
+// Event изменение в обсуждении сущности. Comment - отдельная копия, подписчики ее не меняют
+type Event struct {
+	Type    EventType
+	Comment *model.Comment
+	Counts  model.ReactionCounts // Только для EventReaction
+}
+
+// Topic обсуждение, на которое подписывается клиент, например mark:42
+type Topic struct {
+	EntityType model.EntityType
+	EntityID   uint
+}
+
+func (t Topic) String() string {
+	return fmt.Sprintf("%s:%d", t.EntityType, t.EntityID)
+}
+
+// Hub раздает события комментариев подпискам на их сущность в пределах одного экземпляра сервиса.
+// События берутся из Publisher этого же экземпляра, а не из Kafka: подписчик видит только изменения,
+// прошедшие через его реплику. Пока хаб не читает события из брокера, comment-service с живыми
+// подписками разворачивается одной репликой (или с привязкой обсуждения к реплике на балансировщике)
+type Hub struct {
+	mu     sync.RWMutex
+	topics map[Topic]map[*Subscription]struct{}
+
+	blocks   BlockChecker
+	entities *entity.Registry
+	logger   *zap.Logger
+}
+
+func NewHub(blocks BlockChecker, entities *entity.Registry, logger *zap.Logger) *Hub {
+	return &Hub{
+		topics:   make(map[Topic]map[*Subscription]struct{}),
+		blocks:   blocks,
+		entities: entities,
+		logger:   logger,
+	}
+}
+
+// Subscribe подписывает viewerID на обсуждение существующей сущности. Подписку нужно закрыть через Unsubscribe.
+// Если сервис-владелец недоступен, существование не проверяется, как и при создании комментария
+func (h *Hub) Subscribe(ctx context.Context, topic Topic, viewerID uint) (*Subscription, error) {
+	def, err := h.entities.Get(topic.EntityType)
+	if err != nil {
+		return nil, err
+	}
+	if def.Checker != nil {
+		states, err := def.Checker.GetEntityStates(ctx, []uint{topic.EntityID})
+		switch {
+		case entity.Unavailable(err):
+			h.logger.Warn("entity owner service degraded, skipping entity check",
+				zap.String("topic", topic.String()), zap.Error(err))
+		case err != nil:
+			return nil, err
+		case len(states) == 0 || !states[0].Exists:
+			return nil, domainerrors.EntityNotFound(string(topic.EntityType), topic.EntityID)
+		}
+	}
+	sub := newSubscription(topic, viewerID, h.blocks, h.logger)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	subs, ok := h.topics[topic]
+	if !ok {
+		subs = make(map[*Subscription]struct{})
+		h.topics[topic] = subs
+	}
+	subs[sub] = struct{}{}
+	return sub, nil
+}
+
+func (h *Hub) Unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	subs := h.topics[sub.topic]
+	delete(subs, sub)
+	if len(subs) == 0 {
+		delete(h.topics, sub.topic)
+	}
+}
+
+// TopicOf обсуждение, к которому относится комментарий
+func TopicOf(comment *model.Comment) Topic {
+	return Topic{EntityType: comment.EntityType, EntityID: comment.EntityID}
+}
+
+// HasSubscribers на обсуждение подписан хотя бы один клиент этого экземпляра
+func (h *Hub) HasSubscribers(topic Topic) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.topics[topic]) > 0
+}
+
+// Publish отправляет событие подписчикам его сущности. Медленный подписчик событие теряет,
+// чтобы не задерживать остальных
+func (h *Hub) Publish(event Event) {
+	topic := TopicOf(event.Comment)
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for sub := range h.topics[topic] {
+		select {
+		case sub.events <- event:
+		default:
+			h.logger.Warn("live subscriber is lagging, event dropped",
+				zap.String("topic", topic.String()),
+				zap.Uint("viewer_id", sub.viewerID),
+				zap.String("event", string(event.Type)))
+		}
+	}
+}
@@ -0,0 +1,252 @@
+package live
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/apperror"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/model"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/service"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/service/entity"
+	"go.uber.org/zap"
+)
+
+type fakeBlocks struct {
+	blocked map[uint]bool // автор -> заблокирован
+	err     error
+	calls   int
+}
+
+func (f *fakeBlocks) IsBlocked(_ context.Context, _, otherID uint) (bool, error) {
+	f.calls++
+	return f.blocked[otherID], f.err
+}
+
+type fakeChecker struct {
+	states []model.EntityState
+	err    error
+}
+
+func (f fakeChecker) GetEntityStates(context.Context, []uint) ([]model.EntityState, error) {
+	return f.states, f.err
+}
+
+type fakeProfiles struct {
+	calls int
+}
+
+func (f *fakeProfiles) GetUserProfileByID(_ context.Context, id uint) (*model.UserProfile, error) {
+	f.calls++
+	return &model.UserProfile{ID: id}, nil
+}
+
+// recordingPublisher запоминает порядок событий, дошедших до Kafka
+type recordingPublisher struct {
+	service.NoOpEventPublisher
+
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *recordingPublisher) record(name string, comment *model.Comment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, name+":"+comment.Content)
+	return nil
+}
+
+func (r *recordingPublisher) PublishCommentCreated(_ context.Context, comment *model.Comment) error {
+	return r.record("created", comment)
+}
+
+func (r *recordingPublisher) PublishCommentUpdated(_ context.Context, comment *model.Comment, _ string) error {
+	return r.record("updated", comment)
+}
+
+func commentEvent(eventType EventType, authorID uint) Event {
+	return Event{Type: eventType, Comment: &model.Comment{UserID: authorID, EntityType: model.EntityMark, EntityID: 1}}
+}
+
+func TestSubscriptionVisible(t *testing.T) {
+	const viewerID = 1
+	errUnavailable := errors.New("social-service unavailable")
+
+	tests := []struct {
+		name   string
+		blocks *fakeBlocks
+		event  Event
+		want   bool
+	}{
+		{"собственный комментарий", &fakeBlocks{blocked: map[uint]bool{viewerID: true}}, commentEvent(EventCreated, viewerID), true},
+		{"комментарий без блокировки", &fakeBlocks{}, commentEvent(EventCreated, 2), true},
+		{"комментарий заблокированного", &fakeBlocks{blocked: map[uint]bool{2: true}}, commentEvent(EventUpdated, 2), false},
+		{"реакция на комментарий заблокированного", &fakeBlocks{blocked: map[uint]bool{2: true}}, commentEvent(EventReaction, 2), false},
+		{"удаление отдается всегда", &fakeBlocks{blocked: map[uint]bool{2: true}}, commentEvent(EventDeleted, 2), true},
+		{"social-service недоступен", &fakeBlocks{err: errUnavailable}, commentEvent(EventCreated, 2), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub := newSubscription(Topic{EntityType: model.EntityMark, EntityID: 1}, viewerID, tt.blocks, zap.NewNop())
+			if got := sub.Visible(context.Background(), tt.event); got != tt.want {
+				t.Errorf("Visible() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubscriptionVisibleCache(t *testing.T) {
+	blocks := &fakeBlocks{blocked: map[uint]bool{2: true}}
+	sub := newSubscription(Topic{EntityType: model.EntityMark, EntityID: 1}, 1, blocks, zap.NewNop())
+
+	for i := 0; i < 3; i++ {
+		sub.Visible(context.Background(), commentEvent(EventCreated, 2))
+	}
+	if blocks.calls != 1 {
+		t.Errorf("IsBlocked calls = %d, want 1", blocks.calls)
+	}
+
+	// Ошибка проверки не кэшируется
+	failing := &fakeBlocks{err: errors.New("timeout")}
+	sub = newSubscription(Topic{EntityType: model.EntityMark, EntityID: 1}, 1, failing, zap.NewNop())
+	sub.Visible(context.Background(), commentEvent(EventCreated, 2))
+	sub.Visible(context.Background(), commentEvent(EventCreated, 2))
+	if failing.calls != 2 {
+		t.Errorf("IsBlocked calls after error = %d, want 2", failing.calls)
+	}
+}
+
+func TestHubSubscribe(t *testing.T) {
+	tests := []struct {
+		name    string
+		def     entity.Definition
+		topic   Topic
+		wantErr bool
+	}{
+		{"незарегистрированный тип", entity.Definition{Type: model.EntityMark}, Topic{EntityType: model.EntityProfile, EntityID: 1}, true},
+		{"без проверки существования", entity.Definition{Type: model.EntityMark}, Topic{EntityType: model.EntityMark, EntityID: 1}, false},
+		{"сущность существует", entity.Definition{
+			Type:    model.EntityMark,
+			Checker: fakeChecker{states: []model.EntityState{{ID: 1, Exists: true}}},
+		}, Topic{EntityType: model.EntityMark, EntityID: 1}, false},
+		{"сущность удалена", entity.Definition{
+			Type:    model.EntityMark,
+			Checker: fakeChecker{states: []model.EntityState{{ID: 1}}},
+		}, Topic{EntityType: model.EntityMark, EntityID: 1}, true},
+		{"сущность не найдена", entity.Definition{
+			Type:    model.EntityMark,
+			Checker: fakeChecker{},
+		}, Topic{EntityType: model.EntityMark, EntityID: 1}, true},
+		{"сервис-владелец недоступен", entity.Definition{
+			Type:    model.EntityMark,
+			Checker: fakeChecker{err: apperror.NewServiceUnavailableError("mark-service", errors.New("connection refused"))},
+		}, Topic{EntityType: model.EntityMark, EntityID: 1}, false},
+		{"ошибка сервиса-владельца", entity.Definition{
+			Type:    model.EntityMark,
+			Checker: fakeChecker{err: errors.New("invalid argument")},
+		}, Topic{EntityType: model.EntityMark, EntityID: 1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hub := NewHub(&fakeBlocks{}, entity.NewRegistry().Register(tt.def), zap.NewNop())
+			sub, err := hub.Subscribe(context.Background(), tt.topic, 1)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Subscribe() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil {
+				hub.Unsubscribe(sub)
+			}
+		})
+	}
+}
+
+func TestPublisherOrder(t *testing.T) {
+	registry := entity.NewRegistry().Register(entity.Definition{Type: model.EntityMark, MaxDepth: 2})
+	hub := NewHub(&fakeBlocks{}, registry, zap.NewNop())
+	next := &recordingPublisher{}
+	publisher := NewPublisher(next, hub, &fakeProfiles{}, registry, zap.NewNop())
+
+	sub, err := hub.Subscribe(context.Background(), Topic{EntityType: model.EntityMark, EntityID: 1}, 1)
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	defer hub.Unsubscribe(sub)
+
+	comment := &model.Comment{UserID: 2, EntityType: model.EntityMark, EntityID: 1, Content: "v1"}
+	_ = publisher.PublishCommentCreated(context.Background(), comment)
+	comment.Content = "v2"
+	_ = publisher.PublishCommentUpdated(context.Background(), comment, "v1")
+	comment.Content = "v3"
+	_ = publisher.PublishCommentUpdated(context.Background(), comment, "v2")
+
+	// Подписчик получил события до возврата из Publish*, в порядке изменений
+	for _, want := range []string{"v1", "v2", "v3"} {
+		select {
+		case event := <-sub.Events():
+			if event.Comment.Content != want {
+				t.Errorf("live event content = %s, want %s", event.Comment.Content, want)
+			}
+			if event.Comment.Author == nil || !event.Comment.CanReply {
+				t.Errorf("live event comment is not prepared: %+v", event.Comment)
+			}
+		default:
+			t.Fatalf("live event %s was not delivered synchronously", want)
+		}
+	}
+
+	if err := publisher.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	want := []string{"created:v1", "updated:v2", "updated:v3"}
+	if len(next.events) != len(want) {
+		t.Fatalf("forwarded events = %v, want %v", next.events, want)
+	}
+	for i := range want {
+		if next.events[i] != want[i] {
+			t.Errorf("forwarded events = %v, want %v", next.events, want)
+			break
+		}
+	}
+}
+
+func TestPublisherProfileLookups(t *testing.T) {
+	registry := entity.NewRegistry().Register(entity.Definition{Type: model.EntityMark, MaxDepth: 2})
+	topic := Topic{EntityType: model.EntityMark, EntityID: 1}
+
+	tests := []struct {
+		name      string
+		subscribe bool
+		author    *model.UserProfile
+		wantCalls int
+	}{
+		{"нет подписчиков", false, nil, 0},
+		{"автор уже загружен", true, &model.UserProfile{ID: 2, Username: "bob"}, 0},
+		{"автор не загружен", true, nil, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hub := NewHub(&fakeBlocks{}, registry, zap.NewNop())
+			profiles := &fakeProfiles{}
+			publisher := NewPublisher(&recordingPublisher{}, hub, profiles, registry, zap.NewNop())
+			defer publisher.Close()
+
+			if tt.subscribe {
+				sub, err := hub.Subscribe(context.Background(), topic, 1)
+				if err != nil {
+					t.Fatalf("Subscribe() error = %v", err)
+				}
+				defer hub.Unsubscribe(sub)
+			}
+
+			comment := &model.Comment{UserID: 2, EntityType: model.EntityMark, EntityID: 1, Author: tt.author}
+			_ = publisher.PublishCommentCreated(context.Background(), comment)
+			if profiles.calls != tt.wantCalls {
+				t.Errorf("GetUserProfileByID calls = %d, want %d", profiles.calls, tt.wantCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package live
+
+import (
+	"context"
+	"time"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/model"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/service"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/service/entity"
+	"go.uber.org/zap"
+)
+
+// ProfileGetter профили авторов для комментариев, отправляемых подписчикам
+type ProfileGetter interface {
+	GetUserProfileByID(ctx context.Context, id uint) (*model.UserProfile, error)
+}
+
+const (
+	forwardQueueSize = 1024
+	forwardTimeout   = 5 * time.Second
+)
+
+type forwardTask struct {
+	name    string
+	comment *model.Comment
+	publish func(ctx context.Context, comment *model.Comment) error
+}
+
+// Publisher отдает события комментариев живым подпискам и передает их дальше в next (Kafka).
+// Подписчики получают событие синхронно, до возврата из метода, поэтому видят изменения в порядке их совершения.
+// В next события уходят в фоне одной горутиной в том же порядке, чтобы недоступность брокера не влияла на ответ
+type Publisher struct {
+	next     service.EventPublisher
+	hub      *Hub
+	profiles ProfileGetter
+	entities *entity.Registry
+	logger   *zap.Logger
+
+	queue chan forwardTask
+	done  chan struct{}
+}
+
+func NewPublisher(next service.EventPublisher, hub *Hub, profiles ProfileGetter, entities *entity.Registry, logger *zap.Logger) *Publisher {
+	p := &Publisher{
+		next:     next,
+		hub:      hub,
+		profiles: profiles,
+		entities: entities,
+		logger:   logger,
+		queue:    make(chan forwardTask, forwardQueueSize),
+		done:     make(chan struct{}),
+	}
+	go p.run()
+	return p
+}
+
+// Close дожидается отправки накопленных событий и закрывает next. После Close публиковать нельзя
+func (p *Publisher) Close() error {
+	close(p.queue)
+	<-p.done
+	if closer, ok := p.next.(interface{ Close() error }); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+func (p *Publisher) PublishCommentCreated(ctx context.Context, comment *model.Comment) error {
+	p.publishLive(comment, func() Event {
+		return Event{Type: EventCreated, Comment: p.prepare(ctx, comment)}
+	})
+	p.forward("comment created", comment, func(ctx context.Context, c *model.Comment) error {
+		return p.next.PublishCommentCreated(ctx, c)
+	})
+	return nil
+}
+
+func (p *Publisher) PublishCommentUpdated(ctx context.Context, comment *model.Comment, prevContent string) error {
+	p.publishLive(comment, func() Event {
+		return Event{Type: EventUpdated, Comment: p.prepare(ctx, comment)}
+	})
+	p.forward("comment updated", comment, func(ctx context.Context, c *model.Comment) error {
+		return p.next.PublishCommentUpdated(ctx, c, prevContent)
+	})
+	return nil
+}
+
+func (p *Publisher) PublishCommentDeleted(ctx context.Context, comment *model.Comment, deletedBy uint, reason string) error {
+	p.publishLive(comment, func() Event {
+		deleted := *comment
+		return Event{Type: EventDeleted, Comment: &deleted}
+	})
+	p.forward("comment deleted", comment, func(ctx context.Context, c *model.Comment) error {
+		return p.next.PublishCommentDeleted(ctx, c, deletedBy, reason)
+	})
+	return nil
+}
+
+func (p *Publisher) PublishCommentRestored(ctx context.Context, comment *model.Comment, restoredBy uint, reason string) error {
+	p.publishLive(comment, func() Event {
+		return Event{Type: EventRestored, Comment: p.prepare(ctx, comment)}
+	})
+	p.forward("comment restored", comment, func(ctx context.Context, c *model.Comment) error {
+		return p.next.PublishCommentRestored(ctx, c, restoredBy, reason)
+	})
+	return nil
+}
+
+func (p *Publisher) PublishReactionToggled(ctx context.Context, comment *model.Comment, change model.ReactionChange) error {
+	p.publishLive(comment, func() Event {
+		reacted := *comment
+		reacted.ReactionCounts = change.Counts
+		return Event{Type: EventReaction, Comment: &reacted, Counts: change.Counts}
+	})
+	p.forward("reaction toggled", comment, func(ctx context.Context, c *model.Comment) error {
+		return p.next.PublishReactionToggled(ctx, c, change)
+	})
+	return nil
+}
+
+// PublishCommentMentioned адресовано упомянутому пользователю, в обсуждение не транслируется
+func (p *Publisher) PublishCommentMentioned(ctx context.Context, comment *model.Comment, mentionedUserID uint) error {
+	p.forward("comment mentioned", comment, func(ctx context.Context, c *model.Comment) error {
+		return p.next.PublishCommentMentioned(ctx, c, mentionedUserID)
+	})
+	return nil
+}
+
+// publishLive отдает событие подписчикам обсуждения. Событие собирается, только если подписчики есть:
+// иначе профиль автора запрашивался бы при каждом изменении впустую
+func (p *Publisher) publishLive(comment *model.Comment, build func() Event) {
+	if !p.hub.HasSubscribers(TopicOf(comment)) {
+		return
+	}
+	p.hub.Publish(build())
+}
+
+// forward ставит отправку копии комментария в next в очередь.
+// При переполненной очереди событие теряется, ответ не ждет брокер
+func (p *Publisher) forward(name string, comment *model.Comment, publish func(ctx context.Context, comment *model.Comment) error) {
+	c := *comment
+	select {
+	case p.queue <- forwardTask{name: name, comment: &c, publish: publish}:
+	default:
+		p.logger.Warn("event queue is full, " + name + " event dropped")
+	}
+}
+
+func (p *Publisher) run() {
+	defer close(p.done)
+	for task := range p.queue {
+		ctx, cancel := context.WithTimeout(context.Background(), forwardTimeout)
+		if err := task.publish(ctx, task.comment); err != nil {
+			p.logger.Warn("Failed to publish "+task.name+" event", zap.Error(err))
+		}
+		cancel()
+	}
+}
+
+// prepare копия комментария с автором и признаком ответа, как в ответах HTTP.
+// Автор, уже загруженный сервисом, переиспользуется. Реакция пользователя у общей копии не заполняется
+func (p *Publisher) prepare(ctx context.Context, comment *model.Comment) *model.Comment {
+	c := *comment
+	c.UserReaction = ""
+	if c.Author == nil {
+		author, err := p.profiles.GetUserProfileByID(ctx, c.UserID)
+		if err != nil {
+			p.logger.Warn("profile-service degraded, live event uses local author", zap.Error(err))
+			author = &model.UserProfile{ID: c.UserID, Username: c.Username}
+		}
+		c.Author = author
+	}
+	if def, err := p.entities.Get(c.EntityType); err == nil {
+		c.CanReply = def.CanReply(c.Depth)
+	}
+	return &c
+}
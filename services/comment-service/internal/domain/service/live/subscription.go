@@ -0,0 +1,74 @@
+package live
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const blockCacheTTL = time.Minute
+
+// BlockChecker проверяет блокировку между пользователями в любую сторону
+type BlockChecker interface {
+	IsBlocked(ctx context.Context, userID, otherID uint) (bool, error)
+}
+
+type blockEntry struct {
+	blocked   bool
+	checkedAt time.Time
+}
+
+// Subscription подписка одного клиента. Читается из одной горутины, поэтому кэш блокировок без мьютекса
+type Subscription struct {
+	topic    Topic
+	viewerID uint
+	events   chan Event
+
+	blocks BlockChecker
+	cache  map[uint]blockEntry
+	logger *zap.Logger
+}
+
+func newSubscription(topic Topic, viewerID uint, blocks BlockChecker, logger *zap.Logger) *Subscription {
+	return &Subscription{
+		topic:    topic,
+		viewerID: viewerID,
+		events:   make(chan Event, subscriptionBuffer),
+		blocks:   blocks,
+		cache:    make(map[uint]blockEntry),
+		logger:   logger,
+	}
+}
+
+func (s *Subscription) Topic() Topic {
+	return s.topic
+}
+
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Visible скрывает комментарии и реакции на комментарии пользователей, с которыми есть блокировка.
+// Удаление отдается всегда: оно не раскрывает содержимого, а клиент мог получить комментарий списком
+func (s *Subscription) Visible(ctx context.Context, event Event) bool {
+	if event.Type == EventDeleted {
+		return true
+	}
+	authorID := event.Comment.UserID
+	if authorID == s.viewerID {
+		return true
+	}
+
+	if entry, ok := s.cache[authorID]; ok && time.Since(entry.checkedAt) < blockCacheTTL {
+		return !entry.blocked
+	}
+	blocked, err := s.blocks.IsBlocked(ctx, s.viewerID, authorID)
+	if err != nil {
+		// Список комментариев блокировки тоже не учитывает, поэтому без social-service событие отдается
+		s.logger.Warn("block check failed, event delivered", zap.Uint("viewer_id", s.viewerID), zap.Error(err))
+		return true
+	}
+	s.cache[authorID] = blockEntry{blocked: blocked, checkedAt: time.Now()}
+	return !blocked
+}
@@ -0,0 +1,201 @@
+package moderation
+
+import (
+	"context"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/pagination"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/domainerrors"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/model"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/repository"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/service"
+	"go.uber.org/zap"
+)
+
+// Service действия модераторов над комментариями. Каждое действие пишется в журнал и публикуется событием
+type Service struct {
+	commentRepo    repository.CommentRepository
+	moderationRepo repository.ModerationRepository
+	revisionRepo   repository.RevisionRepository
+	producer       service.EventPublisher
+	txManager      service.TxManager
+
+	logger *zap.Logger
+}
+
+func NewService(
+	commentRepo repository.CommentRepository,
+	moderationRepo repository.ModerationRepository,
+	revisionRepo repository.RevisionRepository,
+	producer service.EventPublisher,
+	txManager service.TxManager,
+	logger *zap.Logger,
+) *Service {
+	return &Service{
+		commentRepo:    commentRepo,
+		moderationRepo: moderationRepo,
+		revisionRepo:   revisionRepo,
+		producer:       producer,
+		txManager:      txManager,
+		logger:         logger,
+	}
+}
+
+func (s *Service) GetComments(ctx context.Context, filter model.AdminCommentFilter, params pagination.Params) ([]*model.Comment, int64, error) {
+	s.logger.Info("start ModerationService.GetComments")
+	return s.commentRepo.GetAdminComments(ctx, filter, params)
+}
+
+// GetHistory журнал действий модераторов над комментарием
+func (s *Service) GetHistory(ctx context.Context, commentID uint) ([]*model.CommentModeration, error) {
+	s.logger.Info("start ModerationService.GetHistory", zap.Uint("comment_id", commentID))
+	if _, err := s.commentRepo.GetByID(ctx, commentID); err != nil {
+		return nil, err
+	}
+	return s.moderationRepo.GetByComment(ctx, commentID)
+}
+
+// GetRevisions прежние версии текста комментария, включая удаленные комментарии
+func (s *Service) GetRevisions(ctx context.Context, commentID uint) ([]*model.CommentRevision, error) {
+	s.logger.Info("start ModerationService.GetRevisions", zap.Uint("comment_id", commentID))
+	if _, err := s.commentRepo.GetByID(ctx, commentID); err != nil {
+		return nil, err
+	}
+	return s.revisionRepo.GetByComment(ctx, commentID)
+}
+
+// Delete удаляет любой комментарий с указанием причины. Исходный текст сохраняется в журнале,
+// фотографии остаются в хранилище, чтобы вернуться при восстановлении
+func (s *Service) Delete(ctx context.Context, moderator model.Moderator, commentID uint, reason string) error {
+	s.logger.Info("start ModerationService.Delete", zap.Uint("comment_id", commentID))
+	if reason == "" {
+		return domainerrors.ModerationReasonRequired()
+	}
+
+	comment, err := s.commentRepo.GetByID(ctx, commentID)
+	if err != nil {
+		return err
+	}
+	if comment.IsDeleted() {
+		return domainerrors.CommentIsDeleted()
+	}
+
+	deleted := *comment
+	record := newRecord(comment, model.ModerationDeleted, moderator, reason)
+	comment.Content = model.ModeratorDeletedContent
+	comment.Status = model.CommentDeleted
+
+	err = s.txManager.WithTx(ctx, func(txCtx context.Context) error {
+		if _, err := s.commentRepo.Update(txCtx, comment); err != nil {
+			return err
+		}
+		return s.moderationRepo.Create(txCtx, record)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.publishEvent(ctx, "comment deleted", func(ctx context.Context) error {
+		return s.producer.PublishCommentDeleted(ctx, &deleted, moderator.ID, reason)
+	})
+	return nil
+}
+
+// Restore возвращает текст комментария, удаленного модератором. Удаленные автором не восстанавливаются
+func (s *Service) Restore(ctx context.Context, moderator model.Moderator, commentID uint, reason string) (*model.Comment, error) {
+	s.logger.Info("start ModerationService.Restore", zap.Uint("comment_id", commentID))
+
+	comment, err := s.commentRepo.GetByID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if !comment.IsDeleted() {
+		return nil, domainerrors.CommentNotDeleted()
+	}
+
+	deletion, err := s.moderationRepo.GetLastDeletion(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if deletion == nil || comment.Content != model.ModeratorDeletedContent {
+		return nil, domainerrors.CommentNotRestorable(commentID)
+	}
+
+	record := newRecord(comment, model.ModerationRestored, moderator, reason)
+	comment.Content = deletion.PrevContent
+	comment.Status = deletion.PrevStatus
+
+	err = s.txManager.WithTx(ctx, func(txCtx context.Context) error {
+		if _, err := s.commentRepo.Update(txCtx, comment); err != nil {
+			return err
+		}
+		return s.moderationRepo.Create(txCtx, record)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	restored := *comment
+	s.publishEvent(ctx, "comment restored", func(ctx context.Context) error {
+		return s.producer.PublishCommentRestored(ctx, &restored, moderator.ID, reason)
+	})
+	return comment, nil
+}
+
+// RemoveUserComments удаляет все активные комментарии пользователя и возвращает их количество
+func (s *Service) RemoveUserComments(ctx context.Context, moderator model.Moderator, userID uint, reason string) (int, error) {
+	s.logger.Info("start ModerationService.RemoveUserComments", zap.Uint("user_id", userID))
+	if reason == "" {
+		return 0, domainerrors.ModerationReasonRequired()
+	}
+
+	var comments []*model.Comment
+	err := s.txManager.WithTx(ctx, func(txCtx context.Context) error {
+		var err error
+		comments, err = s.commentRepo.GetActiveByUser(txCtx, userID)
+		if err != nil || len(comments) == 0 {
+			return err
+		}
+
+		ids := make([]uint, len(comments))
+		records := make([]*model.CommentModeration, len(comments))
+		for i, comment := range comments {
+			ids[i] = comment.ID
+			records[i] = newRecord(comment, model.ModerationDeleted, moderator, reason)
+		}
+		if err := s.commentRepo.MarkDeleted(txCtx, ids, model.ModeratorDeletedContent); err != nil {
+			return err
+		}
+		return s.moderationRepo.Create(txCtx, records...)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// Событие на каждый комментарий, чтобы счетчики потребителей остались верными
+	for _, comment := range comments {
+		if err := s.producer.PublishCommentDeleted(ctx, comment, moderator.ID, reason); err != nil {
+			s.logger.Warn("Failed to publish comment deleted event", zap.Uint("comment_id", comment.ID), zap.Error(err))
+		}
+	}
+	return len(comments), nil
+}
+
+func newRecord(comment *model.Comment, action model.ModerationAction, moderator model.Moderator, reason string) *model.CommentModeration {
+	return &model.CommentModeration{
+		CommentID:     comment.ID,
+		Action:        action,
+		ModeratorID:   moderator.ID,
+		ModeratorName: moderator.Name,
+		Reason:        reason,
+		PrevStatus:    comment.Status,
+		PrevContent:   comment.Content,
+	}
+}
+
+// publishEvent передает событие издателю. Издатель не ждет брокер: живым подпискам событие отдается
+// сразу, в Kafka - в фоне (см. live.Publisher), поэтому порядок событий совпадает с порядком изменений
+func (s *Service) publishEvent(ctx context.Context, name string, publish func(ctx context.Context) error) {
+	if err := publish(ctx); err != nil {
+		s.logger.Warn("Failed to publish "+name+" event", zap.Error(err))
+	}
+}
@@ -8,6 +8,13 @@ import (
 
 type EventPublisher interface {
 	PublishCommentCreated(ctx context.Context, comment *model.Comment) error
+	// PublishCommentUpdated comment уже содержит новый текст
+	PublishCommentUpdated(ctx context.Context, comment *model.Comment, prevContent string) error
+	// PublishCommentDeleted comment в состоянии до удаления, deletedBy - кто удалил, reason - причина модератора
+	PublishCommentDeleted(ctx context.Context, comment *model.Comment, deletedBy uint, reason string) error
+	PublishCommentRestored(ctx context.Context, comment *model.Comment, restoredBy uint, reason string) error
+	PublishReactionToggled(ctx context.Context, comment *model.Comment, change model.ReactionChange) error
+	PublishCommentMentioned(ctx context.Context, comment *model.Comment, mentionedUserID uint) error
 }
 
 type NoOpEventPublisher struct{}
@@ -16,10 +23,22 @@ func (n *NoOpEventPublisher) PublishCommentCreated(ctx context.Context, comment
 	return nil
 }
 
-func (n *NoOpEventPublisher) PublishCommentUpdated(ctx context.Context, comment *model.Comment) error {
+func (n *NoOpEventPublisher) PublishCommentUpdated(ctx context.Context, comment *model.Comment, prevContent string) error {
 	return nil
 }
 
-func (n *NoOpEventPublisher) PublishCommentDeleted(ctx context.Context, comment *model.Comment) error {
+func (n *NoOpEventPublisher) PublishCommentDeleted(ctx context.Context, comment *model.Comment, deletedBy uint, reason string) error {
+	return nil
+}
+
+func (n *NoOpEventPublisher) PublishCommentRestored(ctx context.Context, comment *model.Comment, restoredBy uint, reason string) error {
+	return nil
+}
+
+func (n *NoOpEventPublisher) PublishReactionToggled(ctx context.Context, comment *model.Comment, change model.ReactionChange) error {
+	return nil
+}
+
+func (n *NoOpEventPublisher) PublishCommentMentioned(ctx context.Context, comment *model.Comment, mentionedUserID uint) error {
 	return nil
 }
@@ -3,7 +3,7 @@ package stats
 import (
 	"context"
 
-	"github.com/RealTimeMap/RealTimeMap-backend/pkg/date"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/model"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/repository"
 	"go.uber.org/zap"
 )
@@ -21,12 +21,12 @@ func NewCommentStatsService(statRepo repository.StatisticRepository, logger *zap
 	}
 }
 
-func (s *CommentStatsService) GetStat(ctx context.Context, userID uint, params date.Resolved) (int64, int64, error) {
-
-	c1, c2, err := s.statRepo.GetCountsByPeriod(ctx, userID, params)
+// GetStat количество комментариев пользователя за период в сравнении с предыдущим
+func (s *CommentStatsService) GetStat(ctx context.Context, userID uint, period model.StatPeriod) (model.PeriodCount, error) {
+	counts, err := s.statRepo.GetCountsByPeriod(ctx, userID, period)
 	if err != nil {
 		s.logger.Error("GetStat err", zap.Error(err))
-		return 0, 0, err
+		return model.PeriodCount{}, err
 	}
-	return c1, c2, nil
+	return counts, nil
 }
@@ -0,0 +1,45 @@
+package mark
+
+import (
+	"context"
+	"errors"
+
+	pkgmark "github.com/RealTimeMap/RealTimeMap-backend/pkg/clients/mark"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/domainerrors"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/model"
+)
+
+type Adapter struct {
+	client *pkgmark.Client
+}
+
+func NewAdapter(client *pkgmark.Client) *Adapter {
+	return &Adapter{
+		client: client,
+	}
+}
+
+func (a *Adapter) GetEntityStates(ctx context.Context, ids []uint) ([]model.EntityState, error) {
+	states, err := a.client.GetMarksState(ctx, ids)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	out := make([]model.EntityState, 0, len(states))
+	for _, s := range states {
+		out = append(out, model.EntityState{
+			ID:      s.ID,
+			Exists:  s.Exists,
+			Active:  s.Active,
+			Ended:   s.Ended,
+			OwnerID: s.OwnerID,
+		})
+	}
+	return out, nil
+}
+
+func mapError(err error) error {
+	if errors.Is(err, pkgmark.ErrServiceUnavailable) {
+		return domainerrors.MarkServiceUnavailable(err)
+	}
+	return err
+}
@@ -39,6 +39,54 @@ func (a *Adapter) GetUserProfileByIDs(ctx context.Context, ids []uint) ([]*model
 	return out, nil
 }
 
+func (a *Adapter) GetUserProfilesByTags(ctx context.Context, tags []string, viewerID uint) ([]*model.UserProfile, error) {
+	ps, err := a.client.GetUserProfilesByTags(ctx, tags, viewerID)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	out := make([]*model.UserProfile, 0, len(ps))
+	for _, p := range ps {
+		out = append(out, toProfile(p))
+	}
+	return out, nil
+}
+
+// GetEntityStates состояние стен профилей: стена существует, если есть профиль
+func (a *Adapter) GetEntityStates(ctx context.Context, ids []uint) ([]model.EntityState, error) {
+	ps, err := a.client.GetUserProfileByIDs(ctx, ids)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	found := make(map[uint]struct{}, len(ps))
+	for _, p := range ps {
+		found[p.ID] = struct{}{}
+	}
+	out := make([]model.EntityState, 0, len(ids))
+	for _, id := range ids {
+		_, ok := found[id]
+		out = append(out, model.EntityState{ID: id, Exists: ok, Active: ok, OwnerID: id})
+	}
+	return out, nil
+}
+
+// AreFriends дружба без блокировки в любую сторону
+func (a *Adapter) AreFriends(ctx context.Context, userID, otherID uint) (bool, error) {
+	relation, err := a.client.GetRelation(ctx, userID, otherID)
+	if err != nil {
+		return false, mapError(err)
+	}
+	return relation.Friends && !relation.Blocked, nil
+}
+
+// IsBlocked блокировка в любую сторону
+func (a *Adapter) IsBlocked(ctx context.Context, userID, otherID uint) (bool, error) {
+	relation, err := a.client.GetRelation(ctx, userID, otherID)
+	if err != nil {
+		return false, mapError(err)
+	}
+	return relation.Blocked, nil
+}
+
 func mapError(err error) error {
 	if errors.Is(err, pkgprofile.ErrUnavailable) {
 		return domainerrors.ProfileUnavailable(err)
@@ -22,33 +22,80 @@ func NewCommentPublisher(p *producer.Producer, logger *zap.Logger) service.Event
 }
 
 func (p *CommentPublisher) PublishCommentCreated(ctx context.Context, comment *model.Comment) error {
-	payload := events.NewCommentPayload(
-		comment.ID,
-		comment.UserID,
-		comment.EntityID,
-		string(comment.EntityType),
-		comment.ParentID,
-		comment.Content,
-	)
+	event := events.NewCommentCreated(newCommentPayload(comment))
+	return p.publish(ctx, p.buildMeta(events.CommentCreated, comment.UserID, comment), event)
+}
+
+func (p *CommentPublisher) PublishCommentUpdated(ctx context.Context, comment *model.Comment, prevContent string) error {
+	event := events.NewCommentUpdated(newCommentPayload(comment), prevContent)
+	return p.publish(ctx, p.buildMeta(events.CommentUpdated, comment.UserID, comment), event)
+}
+
+func (p *CommentPublisher) PublishCommentDeleted(ctx context.Context, comment *model.Comment, deletedBy uint, reason string) error {
+	event := events.NewCommentDeleted(newCommentPayload(comment), deletedBy, reason)
+	return p.publish(ctx, p.buildMeta(events.CommentDeleted, deletedBy, comment), event)
+}
+
+func (p *CommentPublisher) PublishCommentRestored(ctx context.Context, comment *model.Comment, restoredBy uint, reason string) error {
+	event := events.NewCommentRestored(newCommentPayload(comment), restoredBy, reason)
+	return p.publish(ctx, p.buildMeta(events.CommentRestored, restoredBy, comment), event)
+}
 
-	event := events.NewCommentCreated(payload)
+func (p *CommentPublisher) PublishReactionToggled(ctx context.Context, comment *model.Comment, change model.ReactionChange) error {
+	counts := make(map[string]uint, len(change.Counts))
+	for t, n := range change.Counts {
+		counts[string(t)] = n
+	}
+	event := events.NewCommentReactionToggled(events.CommentReactionPayload{
+		CommentID:  comment.ID,
+		OwnerID:    comment.UserID,
+		UserID:     change.UserID,
+		EntityType: string(comment.EntityType),
+		EntityID:   comment.EntityID,
+		Type:       string(change.Current),
+		PrevType:   string(change.Prev),
+		Counts:     counts,
+
+		LikesCount:    counts["like"],
+		DislikesCount: counts["dislike"],
+	})
+	return p.publish(ctx, p.buildMeta(events.CommentReactionToggled, change.UserID, comment), event)
+}
 
-	if err := p.producer.PublishWithMeta(ctx, p.buildMeta(events.CommentCreated, comment), event); err != nil {
-		p.logger.Error("failed to publish comment.created",
-			zap.Uint("commentID", comment.ID),
+func (p *CommentPublisher) PublishCommentMentioned(ctx context.Context, comment *model.Comment, mentionedUserID uint) error {
+	event := events.NewCommentMentioned(newCommentPayload(comment), mentionedUserID)
+	return p.publish(ctx, p.buildMeta(events.CommentMentioned, comment.UserID, comment), event)
+}
+
+func (p *CommentPublisher) publish(ctx context.Context, meta producer.EventMeta, event any) error {
+	if err := p.producer.PublishWithMeta(ctx, meta, event); err != nil {
+		p.logger.Error("failed to publish "+meta.EventType,
+			zap.String("commentID", meta.SourceID),
 			zap.Error(err),
 		)
 		return err
 	}
 
-	p.logger.Debug("published comment.created", zap.Uint("commentID", comment.ID))
+	p.logger.Debug("published "+meta.EventType, zap.String("commentID", meta.SourceID))
 	return nil
 }
 
-func (p *CommentPublisher) buildMeta(eventType string, comment *model.Comment) producer.EventMeta {
+func newCommentPayload(comment *model.Comment) events.CommentPayload {
+	return events.NewCommentPayload(
+		comment.ID,
+		comment.UserID,
+		comment.EntityID,
+		string(comment.EntityType),
+		comment.ParentID,
+		comment.Content,
+	)
+}
+
+// buildMeta userID - инициатор события
+func (p *CommentPublisher) buildMeta(eventType string, userID uint, comment *model.Comment) producer.EventMeta {
 	return producer.EventMeta{
 		EventType: eventType,
-		UserID:    strconv.FormatUint(uint64(comment.UserID), 10),
+		UserID:    strconv.FormatUint(uint64(userID), 10),
 		SourceID:  strconv.FormatUint(uint64(comment.ID), 10),
 		Timestamp: time.Now().Format(time.RFC3339),
 	}
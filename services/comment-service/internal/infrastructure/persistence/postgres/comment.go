@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/pagination"
+
 	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/domainerrors"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/model"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/repository"
@@ -37,7 +39,7 @@ func (r *PgCommentRepository) Create(ctx context.Context, comment *model.Comment
 func (r *PgCommentRepository) GetByID(ctx context.Context, id uint) (*model.Comment, error) {
 	r.logger.Info("start PgCommentRepository.GetByID")
 	var comment *model.Comment
-	err := DBFromCtx(ctx, r.db).Preload("Parent").First(&comment, id).Error
+	err := DBFromCtx(ctx, r.db).Preload("Parent").Preload("Mentions").First(&comment, id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, domainerrors.CommentNotFound(id)
@@ -52,6 +54,7 @@ func (r *PgCommentRepository) GetComments(ctx context.Context, filters model.Com
 	var comments []*model.Comment
 
 	query := DBFromCtx(ctx, r.db).
+		Preload("Mentions").
 		Select("*, (SELECT COUNT(*) FROM comments r WHERE r.parent_id = comments.id AND r.deleted_at IS NULL AND r.status = ?) AS replies_count", model.CommentActive).
 		Where("entity_type = ? AND entity_id = ?", filters.Entity, filters.EntityID)
 
@@ -87,11 +90,18 @@ func (r *PgCommentRepository) Update(ctx context.Context, comment *model.Comment
 	return comment, nil
 }
 
-func (r *PgCommentRepository) IncrementCounter(ctx context.Context, commentID uint, column string, delta int) error {
-	r.logger.Info("start PgCommentRepository.IncrementCounter")
+func (r *PgCommentRepository) IncrementReaction(ctx context.Context, commentID uint, t model.ReactionType, delta int) error {
+	r.logger.Info("start PgCommentRepository.IncrementReaction")
+	key := string(t)
 	return DBFromCtx(ctx, r.db).Model(&model.Comment{}).
 		Where("id = ?", commentID).
-		Update(column, gorm.Expr(column+" + ?", delta)).Error
+		Update("reaction_counts", gorm.Expr(
+			`CASE WHEN COALESCE((reaction_counts->>?::text)::int, 0) + ?::int <= 0
+				THEN COALESCE(reaction_counts, '{}'::jsonb) - ?::text
+				ELSE jsonb_set(COALESCE(reaction_counts, '{}'::jsonb), ARRAY[?::text], to_jsonb(COALESCE((reaction_counts->>?::text)::int, 0) + ?::int))
+			END`,
+			key, delta, key, key, key, delta,
+		)).Error
 }
 
 func (r *PgCommentRepository) CountRelies(ctx context.Context, id uint) (int64, error) {
@@ -106,3 +116,65 @@ func (r *PgCommentRepository) CountRelies(ctx context.Context, id uint) (int64,
 	}
 	return count, nil
 }
+
+func (r *PgCommentRepository) GetAdminComments(ctx context.Context, filter model.AdminCommentFilter, params pagination.Params) ([]*model.Comment, int64, error) {
+	r.logger.Info("start PgCommentRepository.GetAdminComments")
+
+	query := DBFromCtx(ctx, r.db).Model(&model.Comment{})
+	if filter.UserID != nil {
+		query = query.Where("user_id = ?", *filter.UserID)
+	}
+	if filter.Entity != "" {
+		query = query.Where("entity_type = ?", filter.Entity)
+	}
+	if filter.EntityID != nil {
+		query = query.Where("entity_id = ?", *filter.EntityID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		query = query.Where("created_at < ?", *filter.CreatedTo)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		r.logger.Error("error PgCommentRepository.GetAdminComments count", zap.Error(err))
+		return nil, 0, err
+	}
+
+	var comments []*model.Comment
+	err := query.Order("id DESC").Offset(params.Offset()).Limit(params.PageSize).Find(&comments).Error
+	if err != nil {
+		r.logger.Error("error PgCommentRepository.GetAdminComments", zap.Error(err))
+		return nil, 0, err
+	}
+	return comments, count, nil
+}
+
+func (r *PgCommentRepository) GetActiveByUser(ctx context.Context, userID uint) ([]*model.Comment, error) {
+	r.logger.Info("start PgCommentRepository.GetActiveByUser", zap.Uint("user_id", userID))
+	var comments []*model.Comment
+	err := DBFromCtx(ctx, r.db).
+		Where("user_id = ? AND status = ?", userID, model.CommentActive).
+		Order("id").
+		Find(&comments).Error
+	if err != nil {
+		r.logger.Error("error PgCommentRepository.GetActiveByUser", zap.Error(err))
+		return nil, err
+	}
+	return comments, nil
+}
+
+func (r *PgCommentRepository) MarkDeleted(ctx context.Context, ids []uint, content string) error {
+	r.logger.Info("start PgCommentRepository.MarkDeleted", zap.Int("count", len(ids)))
+	if len(ids) == 0 {
+		return nil
+	}
+	return DBFromCtx(ctx, r.db).Model(&model.Comment{}).
+		Where("id IN ?", ids).
+		Updates(map[string]any{"status": model.CommentDeleted, "content": content}).Error
+}
@@ -0,0 +1,44 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/model"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/repository"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type PgMentionRepository struct {
+	db *gorm.DB
+
+	logger *zap.Logger
+}
+
+func NewPgMentionRepository(db *gorm.DB, logger *zap.Logger) repository.MentionRepository {
+	return &PgMentionRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *PgMentionRepository) Replace(ctx context.Context, commentID uint, mentions []model.Mention) error {
+	r.logger.Info("start PgMentionRepository.Replace", zap.Uint("comment_id", commentID))
+	db := DBFromCtx(ctx, r.db)
+	if err := db.Where("comment_id = ?", commentID).Delete(&model.Mention{}).Error; err != nil {
+		r.logger.Error("error PgMentionRepository.Replace delete", zap.Error(err))
+		return err
+	}
+	if len(mentions) == 0 {
+		return nil
+	}
+	for i := range mentions {
+		mentions[i].ID = 0
+		mentions[i].CommentID = commentID
+	}
+	if err := db.Create(&mentions).Error; err != nil {
+		r.logger.Error("error PgMentionRepository.Replace create", zap.Error(err))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/model"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/repository"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type PgModerationRepository struct {
+	db *gorm.DB
+
+	logger *zap.Logger
+}
+
+func NewPgModerationRepository(db *gorm.DB, logger *zap.Logger) repository.ModerationRepository {
+	return &PgModerationRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *PgModerationRepository) Create(ctx context.Context, records ...*model.CommentModeration) error {
+	r.logger.Info("start PgModerationRepository.Create", zap.Int("count", len(records)))
+	if len(records) == 0 {
+		return nil
+	}
+	if err := DBFromCtx(ctx, r.db).CreateInBatches(records, 500).Error; err != nil {
+		r.logger.Error("error PgModerationRepository.Create", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *PgModerationRepository) GetLastDeletion(ctx context.Context, commentID uint) (*model.CommentModeration, error) {
+	r.logger.Info("start PgModerationRepository.GetLastDeletion", zap.Uint("comment_id", commentID))
+	var record model.CommentModeration
+	err := DBFromCtx(ctx, r.db).
+		Where("comment_id = ? AND action = ?", commentID, model.ModerationDeleted).
+		Order("id DESC").
+		First(&record).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		r.logger.Error("error PgModerationRepository.GetLastDeletion", zap.Error(err))
+		return nil, err
+	}
+	return &record, nil
+}
+
+func (r *PgModerationRepository) GetByComment(ctx context.Context, commentID uint) ([]*model.CommentModeration, error) {
+	r.logger.Info("start PgModerationRepository.GetByComment", zap.Uint("comment_id", commentID))
+	var records []*model.CommentModeration
+	err := DBFromCtx(ctx, r.db).
+		Where("comment_id = ?", commentID).
+		Order("id DESC").
+		Find(&records).Error
+	if err != nil {
+		r.logger.Error("error PgModerationRepository.GetByComment", zap.Error(err))
+		return nil, err
+	}
+	return records, nil
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/pagination"
+
 	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/model"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/repository"
 	"go.uber.org/zap"
@@ -56,3 +58,73 @@ func (r *PgReactionRepository) UpdateType(ctx context.Context, id uint, newType
 		Where("id = ?", id).
 		Update("type", newType).Error
 }
+
+func (r *PgReactionRepository) GetUserReactions(ctx context.Context, userID uint, commentIDs []uint) (map[uint]model.ReactionType, error) {
+	r.logger.Info("start PgReactionRepository.GetUserReactions")
+	res := make(map[uint]model.ReactionType, len(commentIDs))
+	if len(commentIDs) == 0 {
+		return res, nil
+	}
+
+	var reactions []*model.Reaction
+	err := DBFromCtx(ctx, r.db).
+		Select("comment_id", "type").
+		Where("user_id = ? AND comment_id IN ?", userID, commentIDs).
+		Find(&reactions).Error
+	if err != nil {
+		r.logger.Error("error PgReactionRepository.GetUserReactions", zap.Error(err))
+		return nil, err
+	}
+	for _, reaction := range reactions {
+		res[reaction.CommentID] = reaction.Type
+	}
+	return res, nil
+}
+
+func (r *PgReactionRepository) GetByCommentAndType(ctx context.Context, commentID uint, t model.ReactionType, params pagination.Params) ([]*model.Reaction, int64, error) {
+	r.logger.Info("start PgReactionRepository.GetByCommentAndType", zap.Uint("comment_id", commentID))
+
+	query := DBFromCtx(ctx, r.db).Model(&model.Reaction{}).
+		Where("comment_id = ? AND type = ?", commentID, t)
+
+	var count int64
+	if err := query.Session(&gorm.Session{}).Count(&count).Error; err != nil {
+		r.logger.Error("error PgReactionRepository.GetByCommentAndType count", zap.Error(err))
+		return nil, 0, err
+	}
+
+	var reactions []*model.Reaction
+	err := query.Session(&gorm.Session{}).
+		Order("id DESC").
+		Offset(params.Offset()).
+		Limit(params.Limit()).
+		Find(&reactions).Error
+	if err != nil {
+		r.logger.Error("error PgReactionRepository.GetByCommentAndType", zap.Error(err))
+		return nil, 0, err
+	}
+	return reactions, count, nil
+}
+
+// MigrateReactionCounts однократно переносит счетчики из прежних колонок likes_count/dislikes_count
+// в reaction_counts: значения пересчитываются по таблице реакций, после чего прежние колонки удаляются.
+// Если колонок уже нет, миграция выполнена раньше или база создана с новой схемой
+func MigrateReactionCounts(db *gorm.DB) error {
+	if !db.Migrator().HasColumn("comments", "likes_count") {
+		return nil
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(`
+			UPDATE comments c SET reaction_counts = r.counts
+			FROM (
+				SELECT comment_id, jsonb_object_agg(type, cnt) AS counts
+				FROM (SELECT comment_id, type, count(*) AS cnt FROM reactions GROUP BY comment_id, type) t
+				GROUP BY comment_id
+			) r
+			WHERE c.id = r.comment_id`).Error
+		if err != nil {
+			return err
+		}
+		return tx.Exec(`ALTER TABLE comments DROP COLUMN IF EXISTS likes_count, DROP COLUMN IF EXISTS dislikes_count`).Error
+	})
+}
@@ -0,0 +1,46 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/model"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/repository"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type PgRevisionRepository struct {
+	db *gorm.DB
+
+	logger *zap.Logger
+}
+
+func NewPgRevisionRepository(db *gorm.DB, logger *zap.Logger) repository.RevisionRepository {
+	return &PgRevisionRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *PgRevisionRepository) Create(ctx context.Context, revision *model.CommentRevision) error {
+	r.logger.Info("start PgRevisionRepository.Create", zap.Uint("comment_id", revision.CommentID))
+	if err := DBFromCtx(ctx, r.db).Create(revision).Error; err != nil {
+		r.logger.Error("error PgRevisionRepository.Create", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *PgRevisionRepository) GetByComment(ctx context.Context, commentID uint) ([]*model.CommentRevision, error) {
+	r.logger.Info("start PgRevisionRepository.GetByComment", zap.Uint("comment_id", commentID))
+	var revisions []*model.CommentRevision
+	err := DBFromCtx(ctx, r.db).
+		Where("comment_id = ?", commentID).
+		Order("id DESC").
+		Find(&revisions).Error
+	if err != nil {
+		r.logger.Error("error PgRevisionRepository.GetByComment", zap.Error(err))
+		return nil, err
+	}
+	return revisions, nil
+}
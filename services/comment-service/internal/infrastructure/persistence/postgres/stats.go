@@ -3,7 +3,6 @@ package postgres
 import (
 	"context"
 
-	"github.com/RealTimeMap/RealTimeMap-backend/pkg/date"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/model"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/repository"
 	"go.uber.org/zap"
@@ -23,35 +22,34 @@ func NewPgStatisticRepositoryRepository(db *gorm.DB, logger *zap.Logger) reposit
 	}
 }
 
-func (r *PgStatisticRepositoryRepository) GetCountsByPeriod(ctx context.Context, userID uint, params date.Resolved) (int64, int64, error) {
+func (r *PgStatisticRepositoryRepository) GetCountsByPeriod(ctx context.Context, userID uint, period model.StatPeriod) (model.PeriodCount, error) {
 	r.logger.Info("PgStatisticRepositoryRepository.GetCountsByPeriod start", zap.Uint("user_id", userID))
 
-	currentStart, currentEnd := params.Current()
-	prevStart, prevEnd := params.Previous()
-	if currentStart == nil || currentEnd == nil || prevStart == nil || prevEnd == nil {
-		return 0, 0, date.ErrInvalidPeriod
-	}
-
 	var row struct {
 		CurrentCount int64
 		PrevCount    int64
 	}
 
-	err := r.db.WithContext(ctx).Model(&model.Comment{}).
-		Select(
-			"COUNT(*) FILTER (WHERE created_at >= ? AND created_at < ?) AS current_count, "+
-				"COUNT(*) FILTER (WHERE created_at >= ? AND created_at < ?) AS prev_count",
-			currentStart, currentEnd, prevStart, prevEnd,
-		).
-		Where("user_id = ?", userID).
-		Where("created_at >= ? AND created_at < ?", prevStart, currentEnd).
-		Scan(&row).Error
-	if err != nil {
+	query := r.db.WithContext(ctx).Model(&model.Comment{}).
+		Where("user_id = ?", userID)
+	if period.IsAllTime() {
+		query = query.Select("COUNT(*) AS current_count, 0 AS prev_count")
+	} else {
+		query = query.
+			Select(
+				"COUNT(*) FILTER (WHERE created_at >= ? AND created_at < ?) AS current_count, "+
+					"COUNT(*) FILTER (WHERE created_at >= ? AND created_at < ?) AS prev_count",
+				period.Start, period.End, period.PrevStart, period.PrevEnd,
+			).
+			Where("created_at >= LEAST(?::timestamptz, ?::timestamptz) AND created_at < GREATEST(?::timestamptz, ?::timestamptz)",
+				period.Start, period.PrevStart, period.End, period.PrevEnd)
+	}
+	if err := query.Scan(&row).Error; err != nil {
 		r.logger.Error("PgStatisticRepositoryRepository.GetCountsByPeriod error", zap.Error(err))
-		return 0, 0, err
+		return model.PeriodCount{}, err
 	}
 
-	return row.CurrentCount, row.PrevCount, nil
+	return model.PeriodCount{Count: row.CurrentCount, PrevCount: row.PrevCount}, nil
 }
 
 func (r *PgStatisticRepositoryRepository) GetAllUsersComments(ctx context.Context, userID uint) (int64, error) {
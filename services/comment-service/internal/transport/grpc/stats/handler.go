@@ -0,0 +1,51 @@
+package stats
+
+import (
+	"context"
+
+	commentstat "github.com/RealTimeMap/RealTimeMap-backend/pkg/pb/comment"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/utils"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/model"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/service/stats"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type Handler struct {
+	commentstat.UnimplementedCommentStatsServiceServer
+
+	service *stats.CommentStatsService
+	logger  *zap.Logger
+}
+
+func NewHandler(service *stats.CommentStatsService, logger *zap.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *Handler) GetUserCommentStat(ctx context.Context, req *commentstat.CommentCountRequest) (*commentstat.CommentStatsResponse, error) {
+	// Без границ периода считаются все комментарии
+	period := model.StatPeriod{}
+	if req.GetStartDate() != nil && req.GetEndDate() != nil {
+		period = model.StatPeriod{
+			Start:     req.GetStartDate().AsTime(),
+			End:       req.GetEndDate().AsTime(),
+			PrevStart: req.GetPrevStartDate().AsTime(),
+			PrevEnd:   req.GetPrevEndDate().AsTime(),
+		}
+	}
+
+	counts, err := h.service.GetStat(ctx, uint(req.GetUserId()), period)
+	if err != nil {
+		h.logger.Error("GetUserCommentStat error", zap.Error(err))
+		return nil, status.Error(codes.Internal, "internal err")
+	}
+	return &commentstat.CommentStatsResponse{
+		Count:     uint64(counts.Count),
+		PrevCount: uint64(counts.PrevCount),
+		Direction: utils.Direction(counts.Count, counts.PrevCount),
+	}, nil
+}
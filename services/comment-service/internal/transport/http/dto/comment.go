@@ -1,7 +1,13 @@
 package dto
 
 import (
+	"mime/multipart"
+	"time"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/pagination"
+
 	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/model"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/service/live"
 )
 
 type CommentParams struct {
@@ -33,38 +39,87 @@ func (p CommentParams) ToFilter(entityID uint, parentID *uint) model.CommentFilt
 
 }
 
+// ReactionRequest тип реакции, допустимые типы проверяет сервис по конфигурации
 type ReactionRequest struct {
-	Type string `json:"type" binding:"required,oneof=like dislike"`
+	Type string `json:"type" binding:"required,max=32"`
 }
 
 type ReactionResponse struct {
-	UserReaction  *string `json:"userReaction"`
-	LikesCount    uint    `json:"likesCount"`
-	DislikesCount uint    `json:"dislikesCount"`
+	UserReaction *string                     `json:"userReaction"`
+	Reactions    map[model.ReactionType]uint `json:"reactions"`
 }
 
 func NewReactionResponse(result *model.ToggleResult) ReactionResponse {
-	var userReaction *string
+	var userReaction model.ReactionType
 	if result.Reaction != nil {
-		t := string(result.Reaction.Type)
-		userReaction = &t
+		userReaction = result.Reaction.Type
 	}
 	return ReactionResponse{
-		UserReaction:  userReaction,
-		LikesCount:    result.LikesCount,
-		DislikesCount: result.DislikesCount,
+		UserReaction: newUserReaction(userReaction),
+		Reactions:    newReactionCounts(result.Counts),
+	}
+}
+
+func newUserReaction(t model.ReactionType) *string {
+	if t == "" {
+		return nil
+	}
+	s := string(t)
+	return &s
+}
+
+// newReactionCounts всегда отдает объект, даже если реакций нет
+func newReactionCounts(counts model.ReactionCounts) map[model.ReactionType]uint {
+	if counts == nil {
+		return map[model.ReactionType]uint{}
+	}
+	return counts
+}
+
+// ReactorParams фильтр списка поставивших реакцию
+type ReactorParams struct {
+	Type     string `form:"type" binding:"required,max=32"`
+	Page     int    `form:"page"`
+	PageSize int    `form:"pageSize"`
+}
+
+func (p ReactorParams) Pagination() pagination.Params {
+	params := pagination.Params{Page: p.Page, PageSize: p.PageSize}
+	params.Defaults()
+	return params
+}
+
+type ReactorResponse struct {
+	User      AuthorResponse     `json:"user"`
+	Type      model.ReactionType `json:"type"`
+	ReactedAt time.Time          `json:"reactedAt"`
+}
+
+func NewMultipleReactorResponse(reactions []*model.Reaction) []ReactorResponse {
+	res := make([]ReactorResponse, 0, len(reactions))
+	for _, r := range reactions {
+		res = append(res, ReactorResponse{
+			User:      NewAuthorResponse(r.User),
+			Type:      r.Type,
+			ReactedAt: r.UpdatedAt,
+		})
 	}
+	return res
 }
 
 type CommentRequest struct {
-	Content  string `form:"content" json:"content" binding:"required"`
+	Content  string `form:"content" json:"content"` // Может быть пустым, если есть фотографии
 	EntityID uint   `form:"entityId" json:"entityId" binding:"required"`
 	Entity   string `form:"entity" json:"entity" binding:"required"`
 	ParentID *uint  `form:"parentId" json:"parentId"`
+
+	Photos []*multipart.FileHeader `form:"photos" json:"-"`
 }
 
 type CommentUpdateRequest struct {
-	Content string `form:"content" json:"content" binding:"required"`
+	Content        string                  `form:"content" json:"content"`
+	PhotosToDelete []string                `form:"photosToDelete" json:"photosToDelete"`
+	Photos         []*multipart.FileHeader `form:"photos" json:"-"`
 }
 
 type Meta struct {
@@ -77,7 +132,7 @@ type Meta struct {
 func NewMeta(c *model.Comment) Meta {
 
 	return Meta{
-		CanReply:     c.Depth <= model.MaxDepth,
+		CanReply:     c.CanReply,
 		HaveReplies:  c.RepliesCount > 0,
 		RepliesCount: c.RepliesCount,
 		Status:       c.Status,
@@ -104,23 +159,68 @@ func NewAuthorResponse(p *model.UserProfile) AuthorResponse {
 	}
 }
 
+// MentionResponse упоминание для подсветки: позиция и длина тега в единицах UTF-16 (индексы строк JS)
+type MentionResponse struct {
+	UserID uint   `json:"userId"`
+	Tag    string `json:"tag"`
+	Offset int    `json:"offset"`
+	Length int    `json:"length"`
+}
+
+func NewMultipleMentionResponse(c *model.Comment) []MentionResponse {
+	res := make([]MentionResponse, 0, len(c.Mentions))
+	// У удаленного комментария текст заменен, позиции больше не совпадают
+	if c.IsDeleted() {
+		return res
+	}
+	for _, m := range c.Mentions {
+		res = append(res, MentionResponse{UserID: m.UserID, Tag: m.Tag, Offset: m.Offset, Length: m.Length})
+	}
+	return res
+}
+
+type PhotoResponse struct {
+	URL       string `json:"url"`
+	Thumbnail string `json:"thumbnail"`
+}
+
+func NewMultiplePhotoResponse(c *model.Comment) []PhotoResponse {
+	res := make([]PhotoResponse, 0, len(c.Photos))
+	// Фотографии удаленного модератором комментария хранятся до восстановления, но не отдаются
+	if c.IsDeleted() {
+		return res
+	}
+	for _, p := range c.Photos {
+		res = append(res, PhotoResponse{URL: p.URL, Thumbnail: p.Thumbnail})
+	}
+	return res
+}
+
 type CommentResponse struct {
-	ID       uint           `json:"id"`
-	Content  string         `json:"content"`
-	Author   AuthorResponse `json:"author"`
-	Likes    uint           `json:"likes"`
-	Dislikes uint           `json:"dislikes"`
-	Meta     Meta           `json:"meta"`
+	ID           uint                        `json:"id"`
+	Content      string                      `json:"content"`
+	Author       AuthorResponse              `json:"author"`
+	Mentions     []MentionResponse           `json:"mentions"`
+	Photos       []PhotoResponse             `json:"photos"`
+	Reactions    map[model.ReactionType]uint `json:"reactions"`
+	UserReaction *string                     `json:"userReaction"`
+	Edited       bool                        `json:"edited"`
+	EditedAt     *time.Time                  `json:"editedAt"`
+	Meta         Meta                        `json:"meta"`
 }
 
 func NewCommentResponse(comment *model.Comment) CommentResponse {
 	return CommentResponse{
-		ID:       comment.ID,
-		Content:  comment.Content,
-		Author:   NewAuthorResponse(comment.Author),
-		Likes:    comment.LikesCount,
-		Dislikes: comment.DislikesCount,
-		Meta:     NewMeta(comment),
+		ID:           comment.ID,
+		Content:      comment.Content,
+		Author:       NewAuthorResponse(comment.Author),
+		Mentions:     NewMultipleMentionResponse(comment),
+		Photos:       NewMultiplePhotoResponse(comment),
+		Reactions:    newReactionCounts(comment.ReactionCounts),
+		UserReaction: newUserReaction(comment.UserReaction),
+		Edited:       comment.IsEdited(),
+		EditedAt:     comment.EditedAt,
+		Meta:         NewMeta(comment),
 	}
 }
 
@@ -144,3 +244,51 @@ func NewCursorPaginateResponse(items []*model.Comment, hasMore bool) CursorPagin
 		HasMore: hasMore,
 	}
 }
+
+// RevisionResponse прежняя версия текста, editedAt - когда ее заменила правка
+type RevisionResponse struct {
+	Content  string    `json:"content"`
+	EditedAt time.Time `json:"editedAt"`
+}
+
+func NewMultipleRevisionResponse(revisions []*model.CommentRevision) []RevisionResponse {
+	res := make([]RevisionResponse, 0, len(revisions))
+	for _, r := range revisions {
+		res = append(res, RevisionResponse{Content: r.Content, EditedAt: r.CreatedAt})
+	}
+	return res
+}
+
+// StreamParams тип сущности, обсуждение которой транслируется
+type StreamParams struct {
+	Entity string `form:"entity" binding:"required"`
+}
+
+// LiveCommentResponse комментарий в живой ленте, parentId нужен клиенту, чтобы вставить ответ в ветку
+type LiveCommentResponse struct {
+	CommentResponse
+	ParentID *uint `json:"parentId"`
+}
+
+// LiveDeletedResponse удаление передается без содержимого комментария
+type LiveDeletedResponse struct {
+	ID       uint  `json:"id"`
+	ParentID *uint `json:"parentId"`
+}
+
+type LiveReactionResponse struct {
+	ID        uint                        `json:"id"`
+	Reactions map[model.ReactionType]uint `json:"reactions"`
+}
+
+func NewLiveEventResponse(event live.Event) any {
+	c := event.Comment
+	switch event.Type {
+	case live.EventDeleted:
+		return LiveDeletedResponse{ID: c.ID, ParentID: c.ParentID}
+	case live.EventReaction:
+		return LiveReactionResponse{ID: c.ID, Reactions: newReactionCounts(event.Counts)}
+	default:
+		return LiveCommentResponse{CommentResponse: NewCommentResponse(c), ParentID: c.ParentID}
+	}
+}
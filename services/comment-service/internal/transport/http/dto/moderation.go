@@ -0,0 +1,107 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/model"
+)
+
+// ModerationRequest причина действия модератора
+type ModerationRequest struct {
+	Reason string `json:"reason" binding:"max=500"`
+}
+
+// AdminCommentParams фильтры списка комментариев для модерации
+type AdminCommentParams struct {
+	UserID      *uint      `form:"userId" binding:"omitempty,min=1"`
+	Entity      string     `form:"entity"`
+	EntityID    *uint      `form:"entityId" binding:"omitempty,min=1"`
+	Status      string     `form:"status" binding:"omitempty,oneof=active deleted"`
+	CreatedFrom *time.Time `form:"createdFrom" time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedTo   *time.Time `form:"createdTo" time_format:"2006-01-02T15:04:05Z07:00"`
+	Page        int        `form:"page"`
+	PageSize    int        `form:"pageSize"`
+}
+
+func (p AdminCommentParams) ToFilter() model.AdminCommentFilter {
+	return model.AdminCommentFilter{
+		UserID:      p.UserID,
+		Entity:      p.Entity,
+		EntityID:    p.EntityID,
+		Status:      model.CommentStatus(p.Status),
+		CreatedFrom: p.CreatedFrom,
+		CreatedTo:   p.CreatedTo,
+	}
+}
+
+type AdminCommentResponse struct {
+	ID         uint                `json:"id"`
+	UserID     uint                `json:"userId"`
+	Username   string              `json:"username"`
+	Content    string              `json:"content"`
+	EntityType model.EntityType    `json:"entityType"`
+	EntityID   uint                `json:"entityId"`
+	ParentID   *uint               `json:"parentId"`
+	Status     model.CommentStatus `json:"status"`
+	CreatedAt  time.Time           `json:"createdAt"`
+	UpdatedAt  time.Time           `json:"updatedAt"`
+	EditedAt   *time.Time          `json:"editedAt"`
+}
+
+func NewAdminCommentResponse(c *model.Comment) AdminCommentResponse {
+	return AdminCommentResponse{
+		ID:         c.ID,
+		UserID:     c.UserID,
+		Username:   c.Username,
+		Content:    c.Content,
+		EntityType: c.EntityType,
+		EntityID:   c.EntityID,
+		ParentID:   c.ParentID,
+		Status:     c.Status,
+		CreatedAt:  c.CreatedAt,
+		UpdatedAt:  c.UpdatedAt,
+		EditedAt:   c.EditedAt,
+	}
+}
+
+func NewMultipleAdminCommentResponse(comments []*model.Comment) []AdminCommentResponse {
+	res := make([]AdminCommentResponse, 0, len(comments))
+	for _, c := range comments {
+		res = append(res, NewAdminCommentResponse(c))
+	}
+	return res
+}
+
+// ModerationRecordResponse запись журнала модерации
+type ModerationRecordResponse struct {
+	ID            uint                   `json:"id"`
+	Action        model.ModerationAction `json:"action"`
+	ModeratorID   uint                   `json:"moderatorId"`
+	ModeratorName string                 `json:"moderatorName"`
+	Reason        string                 `json:"reason"`
+	PrevStatus    model.CommentStatus    `json:"prevStatus"`
+	PrevContent   string                 `json:"prevContent"`
+	CreatedAt     time.Time              `json:"createdAt"`
+}
+
+func NewMultipleModerationRecordResponse(records []*model.CommentModeration) []ModerationRecordResponse {
+	res := make([]ModerationRecordResponse, 0, len(records))
+	for _, r := range records {
+		res = append(res, ModerationRecordResponse{
+			ID:            r.ID,
+			Action:        r.Action,
+			ModeratorID:   r.ModeratorID,
+			ModeratorName: r.ModeratorName,
+			Reason:        r.Reason,
+			PrevStatus:    r.PrevStatus,
+			PrevContent:   r.PrevContent,
+			CreatedAt:     r.CreatedAt,
+		})
+	}
+	return res
+}
+
+// BulkRemoveResponse результат удаления всех комментариев пользователя
+type BulkRemoveResponse struct {
+	Removed int `json:"removed"`
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/helpers/context"
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/middleware/auth"
 	errorhandler "github.com/RealTimeMap/RealTimeMap-backend/pkg/middleware/error"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/pagination"
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/validation"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/model"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/service/comment"
@@ -41,8 +42,14 @@ func NewCommentRoute(g *gin.RouterGroup, service *comment.Service, logger *zap.L
 		r.PATCH("/:id/comments", auth.AuthRequired(), h.UpdateComment)
 		r.PATCH("/:id/comments/", auth.AuthRequired(), h.UpdateComment)
 
+		r.GET("/:id/comments/revisions", auth.AuthRequired(), h.GetRevisions)
+		r.GET("/:id/comments/revisions/", auth.AuthRequired(), h.GetRevisions)
+
 		r.POST("/:id/comments/reaction", auth.AuthRequired(), h.ToggleReaction)
 		r.POST("/:id/comments/reaction/", auth.AuthRequired(), h.ToggleReaction)
+
+		r.GET("/:id/comments/reactions", h.GetReactors)
+		r.GET("/:id/comments/reactions/", h.GetReactors)
 	}
 }
 
@@ -55,12 +62,19 @@ func (h *Handler) CreateComment(c *gin.Context) {
 		return
 	}
 
-	if err := c.ShouldBindJSON(&req); err != nil {
+	// JSON для текстовых комментариев, multipart - если есть фотографии
+	if err := c.ShouldBind(&req); err != nil {
+		errorhandler.HandleError(c, err, h.logger)
+		return
+	}
+
+	photos, err := readPhotos(req.Photos)
+	if err != nil {
 		errorhandler.HandleError(c, err, h.logger)
 		return
 	}
 
-	newComment, err := h.service.Create(c.Request.Context(), comment.CreateInput{Content: req.Content, ParentID: req.ParentID, EntityID: req.EntityID, EntityType: req.Entity}, uint(userData.UserID), userData.UserName)
+	newComment, err := h.service.Create(c.Request.Context(), comment.CreateInput{Content: req.Content, ParentID: req.ParentID, EntityID: req.EntityID, EntityType: req.Entity, Photos: photos}, uint(userData.UserID), userData.UserName)
 	if err != nil {
 		errorhandler.HandleError(c, err, h.logger)
 		return
@@ -71,7 +85,7 @@ func (h *Handler) CreateComment(c *gin.Context) {
 func (h *Handler) GetComments(c *gin.Context) {
 	var req dto.CommentParams
 
-	entityID, err := h.parseIDParam(c, "id")
+	entityID, err := parseIDParam(c, "id")
 	if err != nil {
 		errorhandler.HandleError(c, err, h.logger)
 		return
@@ -82,7 +96,7 @@ func (h *Handler) GetComments(c *gin.Context) {
 		return
 	}
 
-	comments, hasMore, err := h.service.GetComments(c.Request.Context(), req.ToFilter(entityID, nil))
+	comments, hasMore, err := h.service.GetComments(c.Request.Context(), req.ToFilter(entityID, nil), viewerID(c))
 	if err != nil {
 		errorhandler.HandleError(c, err, h.logger)
 		return
@@ -94,13 +108,13 @@ func (h *Handler) GetComments(c *gin.Context) {
 func (h *Handler) GetReplies(c *gin.Context) {
 	var req dto.CommentParams
 
-	entityID, err := h.parseIDParam(c, "id")
+	entityID, err := parseIDParam(c, "id")
 	if err != nil {
 		errorhandler.HandleError(c, err, h.logger)
 		return
 	}
 
-	parentID, err := h.parseIDParam(c, "parentID")
+	parentID, err := parseIDParam(c, "parentID")
 	if err != nil {
 		errorhandler.HandleError(c, err, h.logger)
 		return
@@ -111,7 +125,7 @@ func (h *Handler) GetReplies(c *gin.Context) {
 		return
 	}
 
-	replies, hasMore, err := h.service.GetComments(c.Request.Context(), req.ToFilter(entityID, &parentID))
+	replies, hasMore, err := h.service.GetComments(c.Request.Context(), req.ToFilter(entityID, &parentID), viewerID(c))
 	if err != nil {
 		errorhandler.HandleError(c, err, h.logger)
 		return
@@ -120,7 +134,7 @@ func (h *Handler) GetReplies(c *gin.Context) {
 }
 
 func (h *Handler) DeleteComment(c *gin.Context) {
-	commentID, err := h.parseIDParam(c, "id")
+	commentID, err := parseIDParam(c, "id")
 	if err != nil {
 		errorhandler.HandleError(c, err, h.logger)
 		return
@@ -140,8 +154,29 @@ func (h *Handler) DeleteComment(c *gin.Context) {
 
 }
 
+// GetRevisions история правок комментария, доступна только автору
+func (h *Handler) GetRevisions(c *gin.Context) {
+	commentID, err := parseIDParam(c, "id")
+	if err != nil {
+		errorhandler.HandleError(c, err, h.logger)
+		return
+	}
+	userID, err := context.GetUserID(c)
+	if err != nil {
+		errorhandler.HandleError(c, err, h.logger)
+		return
+	}
+
+	revisions, err := h.service.GetRevisions(c.Request.Context(), uint(userID), commentID)
+	if err != nil {
+		errorhandler.HandleError(c, err, h.logger)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NewMultipleRevisionResponse(revisions))
+}
+
 func (h *Handler) UpdateComment(c *gin.Context) {
-	commentID, err := h.parseIDParam(c, "id")
+	commentID, err := parseIDParam(c, "id")
 	if err != nil {
 		errorhandler.HandleError(c, err, h.logger)
 		return
@@ -158,7 +193,13 @@ func (h *Handler) UpdateComment(c *gin.Context) {
 		return
 	}
 
-	uComment, err := h.service.UpdateComment(c.Request.Context(), comment.UpdateInput{Content: req.Content}, uint(userID), commentID)
+	photos, err := readPhotos(req.Photos)
+	if err != nil {
+		errorhandler.HandleError(c, err, h.logger)
+		return
+	}
+
+	uComment, err := h.service.UpdateComment(c.Request.Context(), comment.UpdateInput{Content: req.Content, Photos: photos, PhotosToDelete: req.PhotosToDelete}, uint(userID), commentID)
 	if err != nil {
 		errorhandler.HandleError(c, err, h.logger)
 		return
@@ -168,7 +209,7 @@ func (h *Handler) UpdateComment(c *gin.Context) {
 }
 
 func (h *Handler) ToggleReaction(c *gin.Context) {
-	commentID, err := h.parseIDParam(c, "id")
+	commentID, err := parseIDParam(c, "id")
 	if err != nil {
 		errorhandler.HandleError(c, err, h.logger)
 		return
@@ -195,7 +236,39 @@ func (h *Handler) ToggleReaction(c *gin.Context) {
 	c.JSON(http.StatusOK, dto.NewReactionResponse(result))
 }
 
-func (h *Handler) parseIDParam(c *gin.Context, param string) (uint, error) {
+// GetReactors кто поставил комментарию реакцию указанного типа
+func (h *Handler) GetReactors(c *gin.Context) {
+	commentID, err := parseIDParam(c, "id")
+	if err != nil {
+		errorhandler.HandleError(c, err, h.logger)
+		return
+	}
+
+	var req dto.ReactorParams
+	if err := c.ShouldBindQuery(&req); err != nil {
+		validation.AbortWithBindingError(c, err)
+		return
+	}
+	params := req.Pagination()
+
+	reactions, count, err := h.service.GetReactors(c.Request.Context(), commentID, model.ReactionType(req.Type), params)
+	if err != nil {
+		errorhandler.HandleError(c, err, h.logger)
+		return
+	}
+	c.JSON(http.StatusOK, pagination.NewResponse(dto.NewMultipleReactorResponse(reactions), params, count))
+}
+
+// viewerID пользователь публичного запроса. Эндпоинт без авторизации, поэтому
+// пользователь определяется только если шлюз его передал, иначе 0
+func viewerID(c *gin.Context) uint {
+	if userID, err := strconv.Atoi(c.GetHeader("X-User-ID")); err == nil && userID > 0 {
+		return uint(userID)
+	}
+	return 0
+}
+
+func parseIDParam(c *gin.Context, param string) (uint, error) {
 	id, err := strconv.ParseUint(c.Param(param), 10, 64)
 	if err != nil {
 		return 0, apperror.NewFieldValidationError("id", "id must be a number", "value_error", c.Param("id"))
@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/helpers/context"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/middleware/auth"
+	errorhandler "github.com/RealTimeMap/RealTimeMap-backend/pkg/middleware/error"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/pagination"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/validation"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/model"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/service/moderation"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/transport/http/dto"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type ModerationHandler struct {
+	service *moderation.Service
+
+	logger *zap.Logger
+}
+
+func RegisterModerationHandler(g *gin.RouterGroup, service *moderation.Service, logger *zap.Logger) {
+	h := &ModerationHandler{service: service, logger: logger}
+	r := g.Group("/admin/comments", auth.AdminOnly())
+	{
+		r.GET("", h.GetComments)
+		r.GET("/:id/history", h.GetHistory)
+		r.GET("/:id/revisions", h.GetRevisions)
+		r.DELETE("/:id", h.Delete)
+		r.POST("/:id/restore", h.Restore)
+		r.DELETE("/users/:userID", h.RemoveUserComments)
+	}
+}
+
+func (h *ModerationHandler) GetComments(c *gin.Context) {
+	var req dto.AdminCommentParams
+	if err := c.ShouldBindQuery(&req); err != nil {
+		validation.AbortWithBindingError(c, err)
+		return
+	}
+	params := pagination.Params{Page: req.Page, PageSize: req.PageSize}
+	params.Defaults()
+
+	comments, count, err := h.service.GetComments(c.Request.Context(), req.ToFilter(), params)
+	if err != nil {
+		errorhandler.HandleError(c, err, h.logger)
+		return
+	}
+	c.JSON(http.StatusOK, pagination.NewResponse(dto.NewMultipleAdminCommentResponse(comments), params, count))
+}
+
+func (h *ModerationHandler) GetHistory(c *gin.Context) {
+	commentID, err := parseIDParam(c, "id")
+	if err != nil {
+		errorhandler.HandleError(c, err, h.logger)
+		return
+	}
+
+	records, err := h.service.GetHistory(c.Request.Context(), commentID)
+	if err != nil {
+		errorhandler.HandleError(c, err, h.logger)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NewMultipleModerationRecordResponse(records))
+}
+
+func (h *ModerationHandler) GetRevisions(c *gin.Context) {
+	commentID, err := parseIDParam(c, "id")
+	if err != nil {
+		errorhandler.HandleError(c, err, h.logger)
+		return
+	}
+
+	revisions, err := h.service.GetRevisions(c.Request.Context(), commentID)
+	if err != nil {
+		errorhandler.HandleError(c, err, h.logger)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NewMultipleRevisionResponse(revisions))
+}
+
+func (h *ModerationHandler) Delete(c *gin.Context) {
+	commentID, err := parseIDParam(c, "id")
+	if err != nil {
+		errorhandler.HandleError(c, err, h.logger)
+		return
+	}
+	req, ok := h.bindReason(c)
+	if !ok {
+		return
+	}
+	moderator, err := moderatorFromCtx(c)
+	if err != nil {
+		errorhandler.HandleError(c, err, h.logger)
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), moderator, commentID, req.Reason); err != nil {
+		errorhandler.HandleError(c, err, h.logger)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *ModerationHandler) Restore(c *gin.Context) {
+	commentID, err := parseIDParam(c, "id")
+	if err != nil {
+		errorhandler.HandleError(c, err, h.logger)
+		return
+	}
+	req, ok := h.bindReason(c)
+	if !ok {
+		return
+	}
+	moderator, err := moderatorFromCtx(c)
+	if err != nil {
+		errorhandler.HandleError(c, err, h.logger)
+		return
+	}
+
+	restored, err := h.service.Restore(c.Request.Context(), moderator, commentID, req.Reason)
+	if err != nil {
+		errorhandler.HandleError(c, err, h.logger)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NewAdminCommentResponse(restored))
+}
+
+func (h *ModerationHandler) RemoveUserComments(c *gin.Context) {
+	userID, err := parseIDParam(c, "userID")
+	if err != nil {
+		errorhandler.HandleError(c, err, h.logger)
+		return
+	}
+	req, ok := h.bindReason(c)
+	if !ok {
+		return
+	}
+	moderator, err := moderatorFromCtx(c)
+	if err != nil {
+		errorhandler.HandleError(c, err, h.logger)
+		return
+	}
+
+	removed, err := h.service.RemoveUserComments(c.Request.Context(), moderator, userID, req.Reason)
+	if err != nil {
+		errorhandler.HandleError(c, err, h.logger)
+		return
+	}
+	c.JSON(http.StatusOK, dto.BulkRemoveResponse{Removed: removed})
+}
+
+// bindReason тело с причиной необязательно, обязательность проверяет сервис
+func (h *ModerationHandler) bindReason(c *gin.Context) (dto.ModerationRequest, bool) {
+	var req dto.ModerationRequest
+	if c.Request.ContentLength == 0 {
+		return req, true
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		validation.AbortWithBindingError(c, err)
+		return req, false
+	}
+	return req, true
+}
+
+// moderatorFromCtx данные модератора из контекста. Имя может отсутствовать у админских запросов
+func moderatorFromCtx(c *gin.Context) (model.Moderator, error) {
+	userID, err := context.GetUserID(c)
+	if err != nil {
+		return model.Moderator{}, err
+	}
+	userName, _ := context.GetUserName(c)
+	return model.Moderator{ID: uint(userID), Name: userName}, nil
+}
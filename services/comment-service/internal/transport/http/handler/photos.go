@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"mime/multipart"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/apperror"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/mediavalidator"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/domainerrors"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/model"
+)
+
+// readPhotos читает загруженные файлы в память. Размер и тип проверяет сервис
+func readPhotos(fileHeaders []*multipart.FileHeader) ([]mediavalidator.PhotoInput, error) {
+	if len(fileHeaders) == 0 {
+		return nil, nil
+	}
+	// Лишние файлы отсекаем до чтения
+	if len(fileHeaders) > model.MaxPhotos {
+		return nil, domainerrors.TooManyPhotos(len(fileHeaders), model.MaxPhotos)
+	}
+
+	photos := make([]mediavalidator.PhotoInput, 0, len(fileHeaders))
+	for i, header := range fileHeaders {
+		data, err := readFile(header)
+		if err != nil {
+			return nil, apperror.NewFieldValidationError(
+				fmt.Sprintf("photos[%d]", i),
+				"failed to read uploaded file",
+				"value_error.file.read",
+				nil,
+			)
+		}
+		photos = append(photos, mediavalidator.PhotoInput{Data: data, FileName: header.Filename})
+	}
+	return photos, nil
+}
+
+func readFile(header *multipart.FileHeader) ([]byte, error) {
+	f, err := header.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return io.ReadAll(f)
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/date"
 	errorhandler "github.com/RealTimeMap/RealTimeMap-backend/pkg/middleware/error"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/model"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/service/stats"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
@@ -40,13 +41,13 @@ func (h *StatHandler) Stat(c *gin.Context) {
 		return
 	}
 
-	c1, c2, err := h.service.GetStat(c.Request.Context(), 1, period)
+	counts, err := h.service.GetStat(c.Request.Context(), 1, model.NewStatPeriod(period))
 	if err != nil {
 		errorhandler.HandleError(c, err, h.logger)
 		return
 	}
 	c.JSON(200, gin.H{
-		"stat1": c1,
-		"stat2": c2,
+		"stat1": counts.Count,
+		"stat2": counts.PrevCount,
 	})
 }
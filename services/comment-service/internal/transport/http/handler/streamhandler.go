@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/helpers/context"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/middleware/auth"
+	errorhandler "github.com/RealTimeMap/RealTimeMap-backend/pkg/middleware/error"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/validation"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/model"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/domain/service/live"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/comment-service/internal/transport/http/dto"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// streamHeartbeat интервал пустых сообщений, чтобы прокси не закрывали простаивающее соединение
+const streamHeartbeat = 25 * time.Second
+
+type StreamHandler struct {
+	hub *live.Hub
+
+	logger *zap.Logger
+}
+
+func RegisterStreamHandler(g *gin.RouterGroup, hub *live.Hub, logger *zap.Logger) {
+	h := &StreamHandler{hub: hub, logger: logger}
+	g.GET("/:id/comments/stream", auth.AuthRequired(), h.Stream)
+	g.GET("/:id/comments/stream/", auth.AuthRequired(), h.Stream)
+}
+
+// Stream живая лента обсуждения сущности (Server-Sent Events).
+// События: subscribed, commentCreated, commentUpdated, commentDeleted, commentRestored, reactionChanged
+func (h *StreamHandler) Stream(c *gin.Context) {
+	entityID, err := parseIDParam(c, "id")
+	if err != nil {
+		errorhandler.HandleError(c, err, h.logger)
+		return
+	}
+	var req dto.StreamParams
+	if err := c.ShouldBindQuery(&req); err != nil {
+		validation.AbortWithBindingError(c, err)
+		return
+	}
+	userID, err := context.GetUserID(c)
+	if err != nil {
+		errorhandler.HandleError(c, err, h.logger)
+		return
+	}
+
+	topic := live.Topic{EntityType: model.EntityType(req.Entity), EntityID: entityID}
+	sub, err := h.hub.Subscribe(c.Request.Context(), topic, uint(userID))
+	if err != nil {
+		errorhandler.HandleError(c, err, h.logger)
+		return
+	}
+	defer h.hub.Unsubscribe(sub)
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.SSEvent("subscribed", gin.H{"topic": topic.String()})
+	c.Writer.Flush()
+
+	ctx := c.Request.Context()
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(c.Writer, ": ping\n\n")
+		case event := <-sub.Events():
+			if !sub.Visible(ctx, event) {
+				continue
+			}
+			c.SSEvent(string(event.Type), dto.NewLiveEventResponse(event))
+		}
+		c.Writer.Flush()
+	}
+}
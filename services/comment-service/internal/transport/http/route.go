@@ -11,7 +11,9 @@ func RegisterRoutes(g *gin.Engine, container *app.Container) {
 	api := g.Group("/api/v2")
 
 	handler.NewCommentRoute(api, container.CommentService, container.Logger)
+	handler.RegisterStreamHandler(api, container.LiveHub, container.Logger)
 	handler.RegisterStatHandler(api, container.StatService, container.Logger)
+	handler.RegisterModerationHandler(api, container.ModerationService, container.Logger)
 
 	health := http.HealthHandler("comment-service", container.DB)
 	g.GET("/comment/health", health)
@@ -30,7 +30,7 @@ func main() {
 		DBName:   cfg.Database.DBName,
 	}, log)
 	defer database.Close(db)
-	db.AutoMigrate(&model.Mark{}, &model.Category{}, &model.MarkReaction{})
+	db.AutoMigrate(&model.Mark{}, &model.Category{}, &model.MarkReaction{}, &model.MarkCheckIn{}, &model.MarkRSVP{}, &model.MarkHistory{}, &model.MarkReport{}, &model.ReporterReputation{}, &model.SavedArea{}, &model.MarkShareOpen{})
 
 	container := app.MustContainer(cfg, db, log)
 
@@ -40,13 +40,14 @@ func main() {
 
 	grpcServer, err := grpcserver.NewServer(cfg.GrpcServer, log, func(s *grpc.Server) {
 		markstat.RegisterMarkStatsServiceServer(s, container.MarkStatServer)
+		markstat.RegisterMarkQueryServiceServer(s, container.MarkQueryServer)
 	})
 
 	if err != nil {
 		log.Fatal("Failed to start Mark Service", zap.Error(err))
 	}
 
-	if err := runner.Run(log, httpServer, grpcServer); err != nil {
+	if err := runner.Run(log, httpServer, grpcServer, container.TrashPurger); err != nil {
 		log.Error("Server error", zap.Error(err))
 	}
 
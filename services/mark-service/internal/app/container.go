@@ -2,6 +2,7 @@ package app
 
 import (
 	pkgprofile "github.com/RealTimeMap/RealTimeMap-backend/pkg/clients/profile"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/database/txmanager"
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/mediavalidator"
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/storage"
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/transport/kafka/producer"
@@ -9,11 +10,18 @@ import (
 	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/repository"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/service"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/service/accrual"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/service/area"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/service/checkin"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/service/history"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/service/rsvp"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/service/share"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/service/stats"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/infrastructure/grpc/profile"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/infrastructure/persistence/postgres"
+	grpcquery "github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/transport/grpc/query"
 	grpcstat "github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/transport/grpc/stats"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/transport/socket"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/worker"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
@@ -25,12 +33,25 @@ type Container struct {
 	CategoryRepo repository.CategoryRepository
 	MarkRepo     repository.MarkRepository
 	AccrualRepo  repository.AccrualRepository
+	CheckInRepo  repository.CheckInRepository
+	RSVPRepo     repository.RSVPRepository
+	HistoryRepo  repository.MarkHistoryRepository
+	ReportRepo   repository.ReportRepository
+	AreaRepo     repository.SavedAreaRepository
+	ShareRepo    repository.ShareRepository
 
 	// Сервисы для пользовательский кейсов
 	MarkService      *service.UserMarkService
 	MarkStatsService *stats.MarkStatsService
 	CategoryService  *service.CategoryService
 	AccrualService   *accrual.Service
+	CheckInService   *checkin.Service
+	RSVPService      *rsvp.Service
+	HistoryService   *history.Service
+	TrashService     *service.TrashService
+	ReportService    *service.ReportService
+	AreaService      *area.Service
+	ShareService     *share.Service
 
 	// Сервисы для админских кейсов
 	AdminMarkService *service.AdminMarkService
@@ -39,9 +60,13 @@ type Container struct {
 
 	Socket *socket.SocketServer
 
+	// Фоновые задачи
+	TrashPurger *worker.TrashPurger
+
 	// grpc
-	MarkStatServer *grpcstat.Handler
-	Logger         *zap.Logger
+	MarkStatServer  *grpcstat.Handler
+	MarkQueryServer *grpcquery.Handler
+	Logger          *zap.Logger
 }
 
 func MustContainer(cfg *config.Config, db *gorm.DB, log *zap.Logger) *Container {
@@ -51,6 +76,13 @@ func MustContainer(cfg *config.Config, db *gorm.DB, log *zap.Logger) *Container
 	markRepo := postgres.NewMarkRepository(db, log)
 	markStatRepo := postgres.NewMarkStatRepository(db, log)
 	accrualRepo := postgres.NewPgAccrualRepository(db, log)
+	checkInRepo := postgres.NewCheckInRepository(db, log)
+	rsvpRepo := postgres.NewRSVPRepository(db, log)
+	historyRepo := postgres.NewMarkHistoryRepository(db, log)
+	reportRepo := postgres.NewReportRepository(db, log)
+	areaRepo := postgres.NewSavedAreaRepository(db, log)
+	shareRepo := postgres.NewShareRepository(db, log)
+	txManager := txmanager.NewTxManager(db)
 
 	// Создание вспомогательных компонентов
 	imageValidator := mediavalidator.NewPhotoValidator()
@@ -81,17 +113,33 @@ func MustContainer(cfg *config.Config, db *gorm.DB, log *zap.Logger) *Container
 	profileAdapter := profile.NewAdapter(profileGrpcHandler)
 	// Создание сервисов
 	categoryService := service.NewCategoryService(categoryRepo, store)
-	markService := service.NewUserMarkService(markRepo, categoryRepo, store, p, imageValidator, profileAdapter)
+	areaService := area.NewService(areaRepo, categoryRepo, p, cfg.Areas.MaxPerUser, log)
+	markService := service.NewUserMarkService(markRepo, categoryRepo, store, p, imageValidator, profileAdapter, rsvpRepo, historyRepo, txManager, areaService)
 	markStatService := stats.NewMarkStatsService(markStatRepo, log)
 	accrualService := accrual.NewService(markRepo, accrualRepo, log)
+	checkInService := checkin.NewService(markRepo, checkInRepo, p, profileAdapter, cfg.CheckIn.Radius, log)
+	rsvpService := rsvp.NewService(markRepo, rsvpRepo, txManager, p, profileAdapter, log)
+	historyService := history.NewService(markRepo, historyRepo, log)
+	trashService := service.NewTrashService(markRepo, store, p, historyRepo, txManager, cfg.Trash.GracePeriod, cfg.Trash.PurgeBatch, log)
+	var shareService *share.Service
+	if cfg.Share.Secret != "" {
+		shareService = share.NewService(markRepo, shareRepo, p, cfg.Share.Secret, cfg.Share.LinkBaseURL, cfg.Share.RedirectURL, log)
+	} else {
+		log.Info("Share links disabled: SHARE_SECRET is not set")
+	}
+	reportService := service.NewReportService(markRepo, reportRepo, store, p, historyRepo, txManager, cfg.Reports.AutoHideThreshold, log)
 	// админские сервисы
-	adminMarkService := service.NewAdminMarkService(markRepo, categoryRepo, store, p, imageValidator)
+	adminMarkService := service.NewAdminMarkService(markRepo, categoryRepo, store, p, imageValidator, historyRepo, txManager)
 
 	// Сокеты
 	socketServer := socket.New(log, markService)
 
+	// Фоновые задачи
+	trashPurger := worker.NewTrashPurger(trashService, cfg.Trash.PurgeInterval, log)
+
 	// grpc
 	markStatGrpc := grpcstat.NewHandler(markStatService, log)
+	markQueryGrpc := grpcquery.NewHandler(markService, log)
 
 	// добавление
 	return &Container{
@@ -100,17 +148,33 @@ func MustContainer(cfg *config.Config, db *gorm.DB, log *zap.Logger) *Container
 		CategoryRepo: categoryRepo,
 		MarkRepo:     markRepo,
 		AccrualRepo:  accrualRepo,
+		CheckInRepo:  checkInRepo,
+		RSVPRepo:     rsvpRepo,
+		HistoryRepo:  historyRepo,
+		ReportRepo:   reportRepo,
+		AreaRepo:     areaRepo,
+		ShareRepo:    shareRepo,
 
 		MarkService:      markService,
 		MarkStatsService: markStatService,
 		CategoryService:  categoryService,
 		AccrualService:   accrualService,
+		CheckInService:   checkInService,
+		RSVPService:      rsvpService,
+		HistoryService:   historyService,
+		TrashService:     trashService,
+		ReportService:    reportService,
+		AreaService:      areaService,
+		ShareService:     shareService,
 
 		AdminMarkService: adminMarkService,
 
 		Socket: socketServer,
 
-		MarkStatServer: markStatGrpc,
+		TrashPurger: trashPurger,
+
+		MarkStatServer:  markStatGrpc,
+		MarkQueryServer: markQueryGrpc,
 
 		Logger: log,
 	}
@@ -35,6 +35,35 @@ type Profile struct {
 	Timeout time.Duration `yaml:"timeout" env:"PROBE_TIMEOUT" env-default:"3s"`
 }
 
+// CheckIn настройки отметок присутствия на метке
+type CheckIn struct {
+	Radius float64 `yaml:"radius" env:"CHECKIN_RADIUS" env-default:"200"` // Радиус в метрах от метки
+}
+
+// Trash настройки корзины удаленных меток
+type Trash struct {
+	GracePeriod   time.Duration `yaml:"gracePeriod" env:"TRASH_GRACE_PERIOD" env-default:"720h"`   // Сколько удаленная метка доступна для восстановления
+	PurgeInterval time.Duration `yaml:"purgeInterval" env:"TRASH_PURGE_INTERVAL" env-default:"1h"` // Как часто запускается очистка корзины
+	PurgeBatch    int           `yaml:"purgeBatch" env:"TRASH_PURGE_BATCH" env-default:"100"`      // Сколько меток удаляется за один проход
+}
+
+// Reports настройки жалоб на метки
+type Reports struct {
+	AutoHideThreshold float64 `yaml:"autoHideThreshold" env:"REPORTS_AUTO_HIDE_THRESHOLD" env-default:"5"` // Сумма весов жалоб, при которой метка скрывается до рассмотрения
+}
+
+// Areas настройки сохраненных областей
+type Areas struct {
+	MaxPerUser int `yaml:"maxPerUser" env:"AREAS_MAX_PER_USER" env-default:"10"` // Сколько областей может сохранить пользователь
+}
+
+// Share настройки ссылок на метки
+type Share struct {
+	Secret      string `yaml:"secret" env:"SHARE_SECRET"`                                                              // Ключ подписи токенов. Без него ссылки отключены
+	LinkBaseURL string `yaml:"linkBaseURL" env:"SHARE_LINK_BASE_URL" env-default:"http://localhost:8080/api/v2/share"` // Публичный адрес эндпоинта перехода
+	RedirectURL string `yaml:"redirectURL" env:"SHARE_REDIRECT_URL" env-default:"http://localhost:3000"`               // Клиент, на который перенаправляется переход
+}
+
 type Config struct {
 	Env        string                `env:"ENV" env-default:"local"`
 	Database   Database              `yaml:"database"`
@@ -44,6 +73,11 @@ type Config struct {
 	Kafka      Kafka                 `yaml:"kafka"`
 	Http       http.Config           `yaml:"http"`
 	Profile    Profile               `yaml:"profile"`
+	CheckIn    CheckIn               `yaml:"checkIn"`
+	Trash      Trash                 `yaml:"trash"`
+	Reports    Reports               `yaml:"reports"`
+	Areas      Areas                 `yaml:"areas"`
+	Share      Share                 `yaml:"share"`
 }
 
 func MustLoad() *Config {
@@ -0,0 +1,36 @@
+package domainerrors
+
+import (
+	"fmt"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/apperror"
+)
+
+// Saved area errors
+var (
+	ErrAreaNotFound = func(id uint) error {
+		return apperror.NewNotFoundErrorByID("area", id)
+	}
+
+	ErrAreaLimitReached = func(max int) error {
+		return apperror.NewConflictError("areas", fmt.Sprintf("cannot save more than %d areas", max), max)
+	}
+
+	ErrAreaShapeRequired = func() error {
+		return apperror.NewFieldValidationError(
+			"polygon",
+			"either polygon or center with radius is required",
+			"value_error.missing",
+			nil,
+		)
+	}
+
+	ErrAreaRadius = func(min, max float64) error {
+		return apperror.NewFieldValidationError(
+			"radius",
+			fmt.Sprintf("must be between %.0f and %.0f meters", min, max),
+			"value_error.number.range",
+			nil,
+		)
+	}
+)
@@ -0,0 +1,27 @@
+package domainerrors
+
+import (
+	"fmt"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/apperror"
+)
+
+// Check-in errors
+var (
+	ErrMarkNotActive = func(id int) error {
+		return apperror.NewConflictError("markId", "mark is not active", id)
+	}
+
+	ErrCheckInAlreadyExists = func(id int) error {
+		return apperror.NewConflictError("markId", "you have already checked in at this mark", id)
+	}
+
+	ErrCheckInTooFar = func(distance, radius float64) error {
+		return apperror.NewFieldValidationError(
+			"location",
+			fmt.Sprintf("you are %.0f m away from the mark, check-in is allowed within %.0f m", distance, radius),
+			"value_error.location.too_far",
+			distance,
+		)
+	}
+)
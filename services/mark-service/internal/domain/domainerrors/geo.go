@@ -0,0 +1,49 @@
+package domainerrors
+
+import (
+	"fmt"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/apperror"
+)
+
+// Geometry validation errors
+var (
+	ErrInvalidPolygon = func(message string) error {
+		return apperror.NewFieldValidationError("polygon", message, "value_error.polygon", nil)
+	}
+
+	ErrInvalidArea = func(message string) error {
+		return apperror.NewFieldValidationError("area", message, "value_error.area", nil)
+	}
+
+	ErrInvalidRoute = func(message string) error {
+		return apperror.NewFieldValidationError("route", message, "value_error.route", nil)
+	}
+
+	ErrInvalidRouteBuffer = func(maxMeters float64) error {
+		return apperror.NewFieldValidationError(
+			"buffer",
+			fmt.Sprintf("must be between 1 and %.0f meters", maxMeters),
+			"value_error.number.range",
+			nil,
+		)
+	}
+
+	ErrInvalidBoundingBox = func() error {
+		return apperror.NewFieldValidationError(
+			"screen",
+			"leftTop must not be below rightBottom",
+			"value_error.bbox",
+			nil,
+		)
+	}
+
+	ErrBoundingBoxTooLarge = func(maxLonSpan, maxLatSpan float64) error {
+		return apperror.NewFieldValidationError(
+			"screen",
+			fmt.Sprintf("must not exceed %g° in longitude and %g° in latitude", maxLonSpan, maxLatSpan),
+			"value_error.bbox.size",
+			nil,
+		)
+	}
+)
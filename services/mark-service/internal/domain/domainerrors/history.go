@@ -0,0 +1,10 @@
+package domainerrors
+
+import "github.com/RealTimeMap/RealTimeMap-backend/pkg/apperror"
+
+// History errors
+var (
+	ErrMarkVersionNotFound = func(version int) error {
+		return apperror.NewNotFoundError("markVersion", "version", version)
+	}
+)
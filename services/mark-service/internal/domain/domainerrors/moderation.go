@@ -0,0 +1,32 @@
+package domainerrors
+
+import "github.com/RealTimeMap/RealTimeMap-backend/pkg/apperror"
+
+// Moderation errors
+var (
+	ErrInvalidModerationAction = func(action string) error {
+		return apperror.NewFieldValidationError(
+			"action",
+			"must be one of: hide, unhide, end, delete",
+			"value_error.invalid_choice",
+			action,
+		)
+	}
+
+	ErrModerationReasonRequired = func() error {
+		return apperror.NewRequiredError("reason")
+	}
+
+	ErrModerationNoop = func(id int, message string) error {
+		return apperror.NewConflictError("markId", message, id)
+	}
+
+	ErrTooManyMarkIDs = func(count, max int) error {
+		return apperror.NewFieldValidationError(
+			"ids",
+			"too many ids in one request",
+			"value_error.list.max_items",
+			map[string]int{"count": count, "max": max},
+		)
+	}
+)
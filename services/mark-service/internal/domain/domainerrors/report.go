@@ -0,0 +1,27 @@
+package domainerrors
+
+import "github.com/RealTimeMap/RealTimeMap-backend/pkg/apperror"
+
+// Report errors
+var (
+	ErrInvalidReportReason = func(reason string) error {
+		return apperror.NewFieldValidationError(
+			"reason",
+			"must be one of: spam, dangerous, offensive, fake, other",
+			"value_error.invalid_choice",
+			reason,
+		)
+	}
+
+	ErrAlreadyReported = func(markID int) error {
+		return apperror.NewConflictError("markId", "you have already reported this mark", markID)
+	}
+
+	ErrReportOwnMark = func(markID int) error {
+		return apperror.NewConflictError("markId", "you cannot report your own mark", markID)
+	}
+
+	ErrNoPendingReports = func(markID int) error {
+		return apperror.NewNotFoundError("report", "markId", markID)
+	}
+)
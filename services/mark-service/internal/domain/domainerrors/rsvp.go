@@ -0,0 +1,32 @@
+package domainerrors
+
+import "github.com/RealTimeMap/RealTimeMap-backend/pkg/apperror"
+
+// RSVP errors
+var (
+	ErrInvalidRSVPStatus = func(status string) error {
+		return apperror.NewFieldValidationError(
+			"status",
+			"must be one of: going, interested, not_going",
+			"value_error.invalid_choice",
+			status,
+		)
+	}
+
+	ErrInvalidCapacity = func(capacity int) error {
+		return apperror.NewFieldValidationError(
+			"capacity",
+			"must be between 1 and 100000",
+			"value_error.number.range",
+			capacity,
+		)
+	}
+
+	ErrMarkEnded = func(id int) error {
+		return apperror.NewConflictError("markId", "mark has already ended", id)
+	}
+
+	ErrRSVPNotFound = func(id int) error {
+		return apperror.NewNotFoundError("rsvp", "markId", id)
+	}
+)
@@ -0,0 +1,10 @@
+package domainerrors
+
+import "github.com/RealTimeMap/RealTimeMap-backend/pkg/apperror"
+
+// Share errors
+var (
+	ErrInvalidShareToken = func(token string) error {
+		return apperror.NewNotFoundError("share", "token", token)
+	}
+)
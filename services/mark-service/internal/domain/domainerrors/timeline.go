@@ -0,0 +1,37 @@
+package domainerrors
+
+import (
+	"fmt"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/apperror"
+)
+
+// Timeline errors
+var (
+	ErrTimelineRange = func() error {
+		return apperror.NewFieldValidationError(
+			"to",
+			"must be after from",
+			"value_error.date.range",
+			nil,
+		)
+	}
+
+	ErrTimelineStep = func(minMinutes int) error {
+		return apperror.NewFieldValidationError(
+			"step",
+			fmt.Sprintf("must be at least %d minutes", minMinutes),
+			"value_error.number.min",
+			nil,
+		)
+	}
+
+	ErrTimelineTooManyBuckets = func(buckets, max int) error {
+		return apperror.NewFieldValidationError(
+			"step",
+			fmt.Sprintf("range produces %d buckets, maximum is %d", buckets, max),
+			"value_error.timeline.too_many_buckets",
+			buckets,
+		)
+	}
+)
@@ -0,0 +1,10 @@
+package domainerrors
+
+import "github.com/RealTimeMap/RealTimeMap-backend/pkg/apperror"
+
+// Trash errors
+var (
+	ErrRestorePeriodExpired = func(id int) error {
+		return apperror.NewConflictError("markId", "restore period for this mark has expired", id)
+	}
+)
@@ -0,0 +1,49 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/types"
+)
+
+type AreaKind string
+
+const (
+	AreaKindPolygon AreaKind = "polygon"
+	AreaKindCircle  AreaKind = "circle" // Центр + радиус, Geom хранит описанный многоугольник для индекса
+)
+
+// SavedArea сохраненная пользователем область, о новых метках в которой он получает уведомления
+type SavedArea struct {
+	ID     uint     `gorm:"primaryKey"`
+	UserID int      `gorm:"not null;index"`
+	Name   string   `gorm:"not null"`
+	Kind   AreaKind `gorm:"type:varchar(16);not null"`
+
+	Geom   types.Polygon `gorm:"type:geometry(POLYGON,4326);not null;index:idx_saved_areas_geom,type:gist"`
+	Center *types.Point  `gorm:"type:geometry(POINT,4326)"`
+	Radius *float64      // Радиус в метрах для AreaKindCircle
+
+	// Категории, о метках которых нужно уведомлять. Пустой список - все категории
+	CategoryIDs CategoryIDs `gorm:"type:jsonb"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type CategoryIDs []int
+
+// Scan реализует интерфейс sql.Scanner для чтения CategoryIDs из БД
+func (c *CategoryIDs) Scan(val interface{}) error {
+	return scanJSON(val, c)
+}
+
+// Value реализует интерфейс driver.Valuer для записи CategoryIDs в БД
+func (c CategoryIDs) Value() (driver.Value, error) {
+	if len(c) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(c)
+}
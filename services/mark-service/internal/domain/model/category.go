@@ -15,3 +15,10 @@ type CategoryStat struct {
 	Count        int64
 	Percent      float64
 }
+
+// CategoryMonthlySeries количество меток категории по месяцам года
+type CategoryMonthlySeries struct {
+	CategoryName string
+	Total        int64
+	Months       []MonthlyActivity
+}
@@ -0,0 +1,20 @@
+package model
+
+import (
+	"time"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/types"
+)
+
+// MarkCheckIn отметка пользователя о присутствии на метке
+type MarkCheckIn struct {
+	ID       uint        `gorm:"primaryKey"`
+	MarkID   int         `gorm:"uniqueIndex:idx_checkin_mark_user;not null"`
+	UserID   uint        `gorm:"uniqueIndex:idx_checkin_mark_user;not null"`
+	Geom     types.Point `gorm:"type:geometry(POINT,4326);not null"`
+	Distance float64     `gorm:"not null"` // Расстояние до метки в метрах на момент отметки
+
+	CreatedAt time.Time
+
+	User *UserProfile `gorm:"-"`
+}
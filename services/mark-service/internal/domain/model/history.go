@@ -0,0 +1,157 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+)
+
+type MarkAction string
+
+const (
+	MarkActionCreated  MarkAction = "created"
+	MarkActionUpdated  MarkAction = "updated"
+	MarkActionDeleted  MarkAction = "deleted"
+	MarkActionRestored MarkAction = "restored"
+	MarkActionPurged   MarkAction = "purged" // Окончательное удаление из корзины
+
+	// Действия модераторов
+	MarkActionHidden   MarkAction = "hidden"
+	MarkActionUnhidden MarkAction = "unhidden"
+	MarkActionEnded    MarkAction = "ended"
+)
+
+// MarkHistory версия метки в журнале изменений
+type MarkHistory struct {
+	ID      uint       `gorm:"primarykey"`
+	MarkID  int        `gorm:"not null;uniqueIndex:idx_history_mark_version,priority:1"`
+	Version int        `gorm:"not null;uniqueIndex:idx_history_mark_version,priority:2"`
+	Action  MarkAction `gorm:"type:varchar(16);not null"`
+
+	// Кто внес изменение
+	ActorID      int     `gorm:"not null;index"`
+	ActorName    string  `gorm:"not null"`
+	ActorIsAdmin bool    `gorm:"default:false"`
+	Reason       *string // Причина (для действий модераторов)
+
+	Changes  FieldChanges `gorm:"type:jsonb"`          // Изменившиеся поля относительно предыдущего состояния
+	Snapshot MarkSnapshot `gorm:"type:jsonb;not null"` // Состояние метки после изменения
+
+	CreatedAt time.Time
+}
+
+// MarkSnapshot состояние редактируемых полей метки на момент версии
+type MarkSnapshot struct {
+	MarkName       string    `json:"markName"`
+	AdditionalInfo *string   `json:"additionalInfo,omitempty"`
+	CategoryID     int       `json:"categoryId"`
+	StartAt        time.Time `json:"startAt"`
+	EndAt          time.Time `json:"endAt"`
+	IsEnded        bool      `json:"isEnded"`
+	IsHidden       bool      `json:"isHidden"`
+	Photos         []string  `json:"photos"`
+}
+
+func NewMarkSnapshot(m *Mark) MarkSnapshot {
+	photos := make([]string, 0, len(m.Photos))
+	for _, photo := range m.Photos {
+		photos = append(photos, photo.URL)
+	}
+	return MarkSnapshot{
+		MarkName:       m.MarkName,
+		AdditionalInfo: m.AdditionalInfo,
+		CategoryID:     m.CategoryID,
+		StartAt:        m.StartAt,
+		EndAt:          m.EndAt,
+		IsEnded:        m.IsEnded,
+		IsHidden:       m.IsHidden,
+		Photos:         photos,
+	}
+}
+
+// Diff возвращает список полей, изменившихся относительно prev
+func (s MarkSnapshot) Diff(prev MarkSnapshot) FieldChanges {
+	var changes FieldChanges
+	if s.MarkName != prev.MarkName {
+		changes = append(changes, FieldChange{Field: "markName", Old: prev.MarkName, New: s.MarkName})
+	}
+	if derefString(s.AdditionalInfo) != derefString(prev.AdditionalInfo) {
+		changes = append(changes, FieldChange{Field: "additionalInfo", Old: prev.AdditionalInfo, New: s.AdditionalInfo})
+	}
+	if s.CategoryID != prev.CategoryID {
+		changes = append(changes, FieldChange{Field: "categoryId", Old: prev.CategoryID, New: s.CategoryID})
+	}
+	if !s.StartAt.Equal(prev.StartAt) {
+		changes = append(changes, FieldChange{Field: "startAt", Old: prev.StartAt, New: s.StartAt})
+	}
+	if !s.EndAt.Equal(prev.EndAt) {
+		changes = append(changes, FieldChange{Field: "endAt", Old: prev.EndAt, New: s.EndAt})
+	}
+	if s.IsEnded != prev.IsEnded {
+		changes = append(changes, FieldChange{Field: "isEnded", Old: prev.IsEnded, New: s.IsEnded})
+	}
+	if s.IsHidden != prev.IsHidden {
+		changes = append(changes, FieldChange{Field: "isHidden", Old: prev.IsHidden, New: s.IsHidden})
+	}
+	if !slices.Equal(s.Photos, prev.Photos) {
+		changes = append(changes, FieldChange{Field: "photos", Old: prev.Photos, New: s.Photos})
+	}
+	return changes
+}
+
+// Scan реализует интерфейс sql.Scanner для чтения MarkSnapshot из БД
+func (s *MarkSnapshot) Scan(val interface{}) error {
+	return scanJSON(val, s)
+}
+
+// Value реализует интерфейс driver.Valuer для записи MarkSnapshot в БД
+func (s MarkSnapshot) Value() (driver.Value, error) {
+	return json.Marshal(s)
+}
+
+// FieldChange изменение одного поля
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   any    `json:"old"`
+	New   any    `json:"new"`
+}
+
+type FieldChanges []FieldChange
+
+// Scan реализует интерфейс sql.Scanner для чтения FieldChanges из БД
+func (c *FieldChanges) Scan(val interface{}) error {
+	return scanJSON(val, c)
+}
+
+// Value реализует интерфейс driver.Valuer для записи FieldChanges в БД
+func (c FieldChanges) Value() (driver.Value, error) {
+	if len(c) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(c)
+}
+
+func scanJSON(val interface{}, dest any) error {
+	if val == nil {
+		return nil
+	}
+	var data []byte
+	switch v := val.(type) {
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into %T", val, dest)
+	}
+	return json.Unmarshal(data, dest)
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestMarkSnapshotDiff(t *testing.T) {
+	info := "вход со двора"
+	start := time.Date(2024, 5, 9, 10, 0, 0, 0, time.UTC)
+	base := MarkSnapshot{
+		MarkName:   "Субботник",
+		CategoryID: 1,
+		StartAt:    start,
+		EndAt:      start.Add(2 * time.Hour),
+		Photos:     []string{"a.jpg"},
+	}
+
+	tests := []struct {
+		name   string
+		modify func(s *MarkSnapshot)
+		want   []string
+	}{
+		{"без изменений", func(s *MarkSnapshot) {}, nil},
+		{"то же время в другой зоне", func(s *MarkSnapshot) { s.StartAt = start.In(time.FixedZone("MSK", 3*3600)) }, nil},
+		{"название", func(s *MarkSnapshot) { s.MarkName = "Уборка парка" }, []string{"markName"}},
+		{"добавлено описание", func(s *MarkSnapshot) { s.AdditionalInfo = &info }, []string{"additionalInfo"}},
+		{"скрыта и завершена", func(s *MarkSnapshot) { s.IsHidden, s.IsEnded = true, true }, []string{"isEnded", "isHidden"}},
+		{"порядок фото", func(s *MarkSnapshot) { s.Photos = []string{"b.jpg", "a.jpg"} }, []string{"photos"}},
+		{"категория и окончание", func(s *MarkSnapshot) {
+			s.CategoryID = 2
+			s.EndAt = s.EndAt.Add(time.Hour)
+		}, []string{"categoryId", "endAt"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := base
+			next.Photos = slices.Clone(base.Photos)
+			tt.modify(&next)
+
+			var got []string
+			for _, change := range next.Diff(base) {
+				got = append(got, change.Field)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Diff() fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
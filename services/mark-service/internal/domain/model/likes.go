@@ -1,7 +1,11 @@
 package model
 
+import "time"
+
 type MarkReaction struct {
 	ID     uint `gorm:"primaryKey"`
 	MarkID uint `gorm:"uniqueIndex:idx_mark_user;not null"`
 	UserID uint `gorm:"uniqueIndex:idx_mark_user;not null"`
+
+	CreatedAt time.Time
 }
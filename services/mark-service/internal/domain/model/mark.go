@@ -38,8 +38,9 @@ type Mark struct {
 	EndAt   time.Time `gorm:"index:idx_marks_time,priority:2"`
 
 	// Флаги для быстрой провреки
-	IsTemp  bool `gorm:"default:false"`
-	IsEnded bool `gorm:"default:false"`
+	IsTemp   bool `gorm:"default:false"`
+	IsEnded  bool `gorm:"default:false"`
+	IsHidden bool `gorm:"default:false"` // Скрыта модератором
 
 	// Гео данные
 	Geom    types.Point  `gorm:"type:geometry(POINT,4326);not null"`
@@ -47,11 +48,17 @@ type Mark struct {
 	Photos  types.Photos `gorm:"type:jsonb"`
 
 	// Метрики
-	SharedCount int64 `gorm:"default:0"`
-	LikesCount  int64 `gorm:"-"`
-	IsLiked     bool  `gorm:"-"`
+	SharedCount  int64 `gorm:"default:0"`
+	CheckInCount int64 `gorm:"default:0"`
+	ReportsCount int64 `gorm:"default:0"`
+	LikesCount   int64 `gorm:"-"`
+	IsLiked      bool  `gorm:"-"`
+
+	// Вместимость события, nil - без ограничений
+	Capacity *int
 
 	Owner *UserProfile `gorm:"-" json:"-"`
+	RSVP  *RSVPSummary `gorm:"-" json:"-"`
 }
 
 func (m *Mark) BeforeCreate(_ *gorm.DB) (err error) {
@@ -115,6 +122,9 @@ func (m *Mark) DaysSinceStart() int {
 func (m *Mark) Status() string {
 	now := time.Now()
 
+	if m.IsEnded {
+		return ended
+	}
 	if now.Before(m.StartAt) {
 		return notStarted
 	}
@@ -124,6 +134,16 @@ func (m *Mark) Status() string {
 	return active
 }
 
+// IsActive метка идет в данный момент, не была завершена досрочно и не скрыта
+func (m *Mark) IsActive() bool {
+	return !m.IsEnded && !m.IsHidden && m.Status() == active
+}
+
+// HasEnded событие метки прошло или было завершено досрочно
+func (m *Mark) HasEnded() bool {
+	return m.IsEnded || m.Status() == ended
+}
+
 // DefaultEndAt метод добавляет время окончания для тех меток где не указано EndAt (Временные метки)
 func (m *Mark) DefaultEndAt() {
 	m.EndAt = m.StartAt.Add(time.Duration(1) * time.Hour)
@@ -143,11 +163,45 @@ type Cluster struct {
 	Count  int
 }
 
+// TimelineBucket количество меток, активных хотя бы часть интервала [Start, End)
+type TimelineBucket struct {
+	Start time.Time
+	End   time.Time
+	Count int64
+}
+
+// RouteMark метка в коридоре вдоль маршрута
+type RouteMark struct {
+	Mark     *Mark
+	Position float64 // Доля пройденного маршрута до ближайшей к метке точки, 0..1
+	Along    float64 // Расстояние от начала маршрута до этой точки в метрах
+	Distance float64 // Расстояние от метки до маршрута в метрах
+}
+
 type MonthlyActivity struct {
 	Month string
 	Count int64
 }
 
+// StatPeriod текущий и предыдущий периоды сравнения [Start, End). Нулевые границы - без ограничений
+type StatPeriod struct {
+	Start     time.Time
+	End       time.Time
+	PrevStart time.Time
+	PrevEnd   time.Time
+}
+
+// IsAllTime период без границ, сравнивать не с чем
+func (p StatPeriod) IsAllTime() bool {
+	return p.Start.IsZero() || p.End.IsZero()
+}
+
+// PeriodCount значение за текущий период в сравнении с предыдущим
+type PeriodCount struct {
+	Count     int64
+	PrevCount int64
+}
+
 type DayActivity struct {
 	Day   time.Time
 	Count int64
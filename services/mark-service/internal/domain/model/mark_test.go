@@ -0,0 +1,30 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMarkStatus(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name string
+		mark Mark
+		want string
+	}{
+		{"еще не началась", Mark{StartAt: now.Add(time.Hour), EndAt: now.Add(2 * time.Hour)}, notStarted},
+		{"идет", Mark{StartAt: now.Add(-time.Hour), EndAt: now.Add(time.Hour)}, active},
+		{"прошла", Mark{StartAt: now.Add(-2 * time.Hour), EndAt: now.Add(-time.Hour)}, ended},
+		{"завершена модератором", Mark{StartAt: now.Add(-time.Hour), EndAt: now.Add(time.Hour), IsEnded: true}, ended},
+		{"завершена до начала", Mark{StartAt: now.Add(time.Hour), EndAt: now.Add(2 * time.Hour), IsEnded: true}, ended},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.mark.Status(); got != tt.want {
+				t.Errorf("Status() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package model
+
+import "time"
+
+type ReportReason string
+
+const (
+	ReportReasonSpam      ReportReason = "spam"
+	ReportReasonDangerous ReportReason = "dangerous"
+	ReportReasonOffensive ReportReason = "offensive"
+	ReportReasonFake      ReportReason = "fake"
+	ReportReasonOther     ReportReason = "other"
+)
+
+func (r ReportReason) IsValid() bool {
+	switch r {
+	case ReportReasonSpam, ReportReasonDangerous, ReportReasonOffensive, ReportReasonFake, ReportReasonOther:
+		return true
+	default:
+		return false
+	}
+}
+
+type ReportStatus string
+
+const (
+	ReportPending  ReportStatus = "pending"
+	ReportAccepted ReportStatus = "accepted" // Жалоба подтверждена модератором
+	ReportRejected ReportStatus = "rejected" // Жалоба признана ложной
+)
+
+// MarkReport жалоба пользователя на метку. Один пользователь - одна жалоба на метку
+type MarkReport struct {
+	ID      uint         `gorm:"primaryKey"`
+	MarkID  int          `gorm:"uniqueIndex:idx_report_mark_user;index:idx_report_mark_status,priority:1;not null"`
+	UserID  int          `gorm:"uniqueIndex:idx_report_mark_user;index;not null"`
+	Reason  ReportReason `gorm:"type:varchar(16);not null"`
+	Comment *string
+	Status  ReportStatus `gorm:"type:varchar(16);index:idx_report_mark_status,priority:2;not null;default:pending"`
+	Weight  float64      `gorm:"not null;default:1"` // Вес жалобы по репутации автора на момент подачи
+
+	// Рассмотрение модератором
+	ReviewedBy *int
+	ReviewedAt *time.Time
+
+	CreatedAt time.Time
+}
+
+// ReportQueueItem метка в очереди на рассмотрение жалоб
+type ReportQueueItem struct {
+	MarkID       int
+	PendingCount int64
+	Score        float64 // Сумма весов нерассмотренных жалоб
+	LastReportAt time.Time
+
+	Mark *Mark `gorm:"-"`
+}
+
+// Границы веса жалобы, чтобы один пользователь не мог ни скрыть метку в одиночку, ни полностью обнулить свой голос
+const (
+	minReportWeight = 0.2
+	maxReportWeight = 2.0
+)
+
+// ReporterReputation статистика рассмотренных жалоб пользователя
+type ReporterReputation struct {
+	UserID   int   `gorm:"primaryKey;autoIncrement:false"`
+	Accepted int64 `gorm:"not null;default:0"`
+	Rejected int64 `gorm:"not null;default:0"` // Ложные жалобы
+
+	UpdatedAt time.Time
+}
+
+// Weight вес новой жалобы пользователя: растет с подтвержденными и падает с ложными жалобами.
+// Новый пользователь имеет вес 1
+func (r ReporterReputation) Weight() float64 {
+	weight := float64(r.Accepted+1) / float64(r.Rejected+1)
+	return min(max(weight, minReportWeight), maxReportWeight)
+}
@@ -0,0 +1,57 @@
+package model
+
+import "time"
+
+type RSVPStatus string
+
+const (
+	RSVPGoing      RSVPStatus = "going"
+	RSVPInterested RSVPStatus = "interested"
+	RSVPNotGoing   RSVPStatus = "not_going"
+	RSVPWaitlist   RSVPStatus = "waitlist" // Выставляется системой при заполненной вместимости
+)
+
+// IsSelectable статусы, которые пользователь может выбрать сам
+func (s RSVPStatus) IsSelectable() bool {
+	switch s {
+	case RSVPGoing, RSVPInterested, RSVPNotGoing:
+		return true
+	default:
+		return false
+	}
+}
+
+// MarkRSVP ответ пользователя на приглашение к событию метки
+type MarkRSVP struct {
+	ID     uint       `gorm:"primaryKey"`
+	MarkID int        `gorm:"uniqueIndex:idx_rsvp_mark_user;index:idx_rsvp_mark_status,priority:1;not null"`
+	UserID uint       `gorm:"uniqueIndex:idx_rsvp_mark_user;not null"`
+	Status RSVPStatus `gorm:"type:varchar(16);index:idx_rsvp_mark_status,priority:2;not null"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time // Для листа ожидания определяет очередь
+
+	User *UserProfile `gorm:"-"`
+	Mark *Mark        `gorm:"-"`
+}
+
+// RSVPSummary сводка по ответам на метку для детального просмотра
+type RSVPSummary struct {
+	Capacity   *int
+	Going      int64
+	Interested int64
+	NotGoing   int64
+	Waitlist   int64
+}
+
+// SpotsLeft количество свободных мест, nil если вместимость не ограничена
+func (s RSVPSummary) SpotsLeft() *int64 {
+	if s.Capacity == nil {
+		return nil
+	}
+	left := int64(*s.Capacity) - s.Going
+	if left < 0 {
+		left = 0
+	}
+	return &left
+}
@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// MarkShareOpen переход по ссылке, которой поделился пользователь.
+// Каждый посетитель учитывается на метке один раз, засчитывается первая открытая им ссылка
+type MarkShareOpen struct {
+	ID        uint   `gorm:"primaryKey"`
+	MarkID    int    `gorm:"uniqueIndex:idx_share_open_mark_visitor;not null"`
+	VisitorID string `gorm:"uniqueIndex:idx_share_open_mark_visitor;size:64;not null"` // Пользователь или хэш анонимного посетителя
+	SharerID  uint   `gorm:"index;not null"`
+
+	CreatedAt time.Time
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/types"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/model"
+)
+
+type SavedAreaRepository interface {
+	Create(ctx context.Context, area *model.SavedArea) error
+	// GetByUser области пользователя: Новые -> Старые
+	GetByUser(ctx context.Context, userID int) ([]*model.SavedArea, error)
+	GetByID(ctx context.Context, id uint) (*model.SavedArea, error)
+	Delete(ctx context.Context, id uint) error
+	CountByUser(ctx context.Context, userID int) (int64, error)
+	// MatchPoint области, в которые попадает точка и фильтр категорий которых допускает categoryID
+	MatchPoint(ctx context.Context, point types.Point, categoryID int) ([]*model.SavedArea, error)
+}
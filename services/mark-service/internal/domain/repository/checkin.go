@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/pagination"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/types"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/model"
+)
+
+type CheckInRepository interface {
+	// Create сохраняет отметку и увеличивает счетчик на метке
+	Create(ctx context.Context, checkIn *model.MarkCheckIn) (*model.MarkCheckIn, int64, error)
+	// DistanceTo расстояние в метрах от точки до метки (PostGIS geography)
+	DistanceTo(ctx context.Context, markID int, point types.Point) (float64, error)
+	// GetByMark список отметок на метке: Новые -> Старые
+	GetByMark(ctx context.Context, markID int, params pagination.Params) ([]*model.MarkCheckIn, int64, error)
+}
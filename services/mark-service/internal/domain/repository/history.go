@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/pagination"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/model"
+)
+
+type MarkHistoryRepository interface {
+	// Create сохраняет запись, присваивая ей следующий номер версии метки
+	Create(ctx context.Context, entry *model.MarkHistory) error
+	// GetByMark история метки: Новые версии -> Старые
+	GetByMark(ctx context.Context, markID int, params pagination.Params) ([]*model.MarkHistory, int64, error)
+	// GetVersion конкретная версия метки
+	GetVersion(ctx context.Context, markID, version int) (*model.MarkHistory, error)
+}
@@ -7,10 +7,12 @@ import (
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/pagination"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/model"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/valueobject"
+	"github.com/paulmach/orb"
 )
 
 type Filter struct {
 	BoundingBox valueobject.BoundingBox
+	Area        orb.MultiPolygon // Если задана, используется вместо BoundingBox
 	ZoomLevel   float64
 	StartAt     time.Time
 	EndAt       time.Time
@@ -18,20 +20,83 @@ type Filter struct {
 	Duration    int
 }
 
+// Статусы меток для фильтрации в админ панели
+const (
+	AdminStatusActive   = "active"
+	AdminStatusUpcoming = "upcoming"
+	AdminStatusEnded    = "ended"
+	AdminStatusHidden   = "hidden"
+	AdminStatusDeleted  = "deleted"
+)
+
+// AdminFilter параметры фильтрации и сортировки меток в админ панели. Пустые поля не учитываются
+type AdminFilter struct {
+	OwnerID     *int
+	CategoryID  *int
+	Status      string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	MinReports  *int64
+
+	SortBy   string // id | created_at | start_at | end_at | reports_count
+	SortDesc bool
+}
+
+// TimelineFilter параметры гистограммы активности меток в области карты
+type TimelineFilter struct {
+	BoundingBox valueobject.BoundingBox
+	Area        orb.MultiPolygon // Если задана, используется вместо BoundingBox
+	From        time.Time
+	To          time.Time
+	Step        time.Duration // Размер интервала гистограммы
+}
+
+// RouteFilter параметры поиска меток в коридоре вдоль маршрута
+type RouteFilter struct {
+	Route  orb.LineString
+	Buffer float64 // Половина ширины коридора в метрах
+	At     time.Time
+	Limit  int
+}
+
 type MarkRepository interface {
 	Create(ctx context.Context, data *model.Mark) (*model.Mark, error)
 	TodayCreated(ctx context.Context, userID int) (int64, error)
 	GetMarksInArea(ctx context.Context, filter Filter) ([]*model.Mark, error)
+	// GetMarkIDsInArea только id меток области, без загрузки самих меток
+	GetMarkIDsInArea(ctx context.Context, filter Filter) ([]int, error)
 	GetUserMarks(ctx context.Context, userID uint, params pagination.Params) ([]*model.Mark, int64, error)
 	GetMarksInCluster(ctx context.Context, filter Filter) ([]*model.Cluster, error)
+	// GetTimeline количество активных меток области по интервалам [From, To) с шагом Step
+	GetTimeline(ctx context.Context, filter TimelineFilter) ([]model.TimelineBucket, error)
+	// GetMarksAlongRoute активные метки не дальше Buffer от маршрута в порядке следования по нему
+	GetMarksAlongRoute(ctx context.Context, filter RouteFilter) ([]*model.RouteMark, error)
 	Exist(ctx context.Context, id int) (bool, error)
 	Delete(ctx context.Context, id int) error
 	GetByID(ctx context.Context, id int) (*model.Mark, error)
+	// GetByIDs неудаленные метки из списка, включая скрытые. Отсутствующие id пропускаются
+	GetByIDs(ctx context.Context, ids []int) ([]*model.Mark, error)
 	Update(ctx context.Context, id int, mark *model.Mark) (*model.Mark, error)
 
+	// Корзина (мягко удаленные метки)
+
+	// GetDeleted удаленные метки пользователя, удаленные не раньше since: Новые -> Старые
+	GetDeleted(ctx context.Context, userID int, since time.Time, params pagination.Params) ([]*model.Mark, int64, error)
+	// GetDeletedByID мягко удаленная метка
+	GetDeletedByID(ctx context.Context, id int) (*model.Mark, error)
+	// Restore снимает отметку об удалении
+	Restore(ctx context.Context, id int) error
+	// GetExpiredDeleted метки, удаленные раньше before
+	GetExpiredDeleted(ctx context.Context, before time.Time, limit int) ([]*model.Mark, error)
+	// HardDelete окончательно удаляет метки вместе с лайками, отметками, RSVP, жалобами и переходами по ссылкам
+	HardDelete(ctx context.Context, ids []int) error
+
 	// Специфические для админ панели запросы
 
-	GetAll(ctx context.Context, params pagination.Params) ([]*model.Mark, int64, error)
+	// GetAll метки с фильтрацией и сортировкой, включая скрытые (и удаленные при Status = deleted)
+	GetAll(ctx context.Context, filter AdminFilter, params pagination.Params) ([]*model.Mark, int64, error)
+	// SetModerationState обновляет только флаги скрытия и завершения и время окончания, не затрагивая счетчики метки
+	SetModerationState(ctx context.Context, mark *model.Mark) error
 }
 
 type MarkStatsRepository interface {
@@ -43,4 +108,10 @@ type MarkStatsRepository interface {
 	GetCountPerPeriod(ctx context.Context, userID uint, start, end time.Time) ([]model.DayActivity, error)
 	// GetPopularCategories Получение популярных категорий пользователя на основе меток
 	GetPopularCategories(ctx context.Context, userID uint) ([]model.CategoryStat, error)
+	// GetReceivedLikes лайки других пользователей на метках пользователя за период и предыдущий период
+	GetReceivedLikes(ctx context.Context, userID uint, period model.StatPeriod) (model.PeriodCount, error)
+	// GetReceivedShares уникальные переходы по ссылкам на метки пользователя за период и предыдущий период
+	GetReceivedShares(ctx context.Context, userID uint, period model.StatPeriod) (model.PeriodCount, error)
+	// GetCategoryMonthly количество меток пользователя по категориям и месяцам года: Популярные -> Редкие
+	GetCategoryMonthly(ctx context.Context, userID uint, year int) ([]model.CategoryMonthlySeries, error)
 }
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/pagination"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/model"
+)
+
+type ReportRepository interface {
+	// Create сохраняет жалобу. Повторная жалоба пользователя на метку - ErrAlreadyReported
+	Create(ctx context.Context, report *model.MarkReport) error
+	// PendingScore сумма весов нерассмотренных жалоб на метку
+	PendingScore(ctx context.Context, markID int) (float64, error)
+	// GetByMark жалобы на метку, status пустой - все: Новые -> Старые
+	GetByMark(ctx context.Context, markID int, status model.ReportStatus, params pagination.Params) ([]*model.MarkReport, int64, error)
+	// GetQueue метки с нерассмотренными жалобами: Больший вес -> Меньший
+	GetQueue(ctx context.Context, params pagination.Params) ([]*model.ReportQueueItem, int64, error)
+	// Resolve переводит нерассмотренные жалобы на метку в status и возвращает их авторов
+	Resolve(ctx context.Context, markID int, status model.ReportStatus, reviewerID int) ([]int, error)
+
+	// IncrementReports увеличивает счетчик нерассмотренных жалоб метки
+	IncrementReports(ctx context.Context, markID int) error
+	// ResetReports обнуляет счетчик нерассмотренных жалоб метки
+	ResetReports(ctx context.Context, markID int) error
+	// HideMark скрывает метку. Возвращает false, если метка уже была скрыта
+	HideMark(ctx context.Context, markID int) (bool, error)
+
+	// GetReputation репутация автора жалоб (нулевая, если жалоб еще не рассматривали)
+	GetReputation(ctx context.Context, userID int) (*model.ReporterReputation, error)
+	// AddReputation учитывает рассмотренные жалобы пользователей
+	AddReputation(ctx context.Context, userIDs []int, accepted bool) error
+}
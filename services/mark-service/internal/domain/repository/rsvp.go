@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/pagination"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/model"
+)
+
+type RSVPRepository interface {
+	// LockMark блокирует строку метки до конца транзакции, чтобы решения по вместимости принимались последовательно
+	LockMark(ctx context.Context, markID int) (*model.Mark, error)
+	// SetCapacity обновление вместимости метки
+	SetCapacity(ctx context.Context, markID int, capacity *int) error
+	// Get ответ пользователя на метку (nil если ответа нет)
+	Get(ctx context.Context, markID int, userID uint) (*model.MarkRSVP, error)
+	// Save создает или обновляет ответ пользователя
+	Save(ctx context.Context, rsvp *model.MarkRSVP) error
+	// Delete удаляет ответ пользователя
+	Delete(ctx context.Context, markID int, userID uint) error
+	// CountGoing количество подтвердивших участие
+	CountGoing(ctx context.Context, markID int) (int64, error)
+	// PromoteWaitlisted переводит первых limit пользователей из листа ожидания в going (limit < 0 - всех)
+	PromoteWaitlisted(ctx context.Context, markID int, limit int) ([]*model.MarkRSVP, error)
+	// GetSummary сводка по статусам
+	GetSummary(ctx context.Context, markID int) (*model.RSVPSummary, error)
+	// GetByMark список ответов на метку с фильтром по статусу (пустой - все)
+	GetByMark(ctx context.Context, markID int, status model.RSVPStatus, params pagination.Params) ([]*model.MarkRSVP, int64, error)
+	// GetUpcoming предстоящие и идущие метки, на которые пользователь ответил going/interested/waitlist
+	GetUpcoming(ctx context.Context, userID uint, params pagination.Params) ([]*model.MarkRSVP, int64, error)
+}
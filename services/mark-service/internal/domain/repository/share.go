@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/model"
+)
+
+type ShareRepository interface {
+	// RecordOpen сохраняет переход и увеличивает счетчик на метке.
+	// Возвращает false, если посетитель уже открывал ссылку на эту метку
+	RecordOpen(ctx context.Context, open *model.MarkShareOpen) (bool, error)
+	// CountBySharer количество уникальных переходов по ссылкам пользователя на метку
+	CountBySharer(ctx context.Context, markID int, sharerID uint) (int64, error)
+}
@@ -23,20 +23,6 @@ func NewService(markRepo repository.MarkRepository, accrualRepo repository.Accru
 	}
 }
 
-func (s *Service) IncreaseShare(ctx context.Context, markID uint) (int64, error) {
-	s.logger.Info("IncreaseShare", zap.Uint("markID", markID))
-	if err := s.checkMarkExist(ctx, markID); err != nil {
-		return 0, err
-	}
-
-	count, err := s.accrualRepo.IncShare(ctx, markID)
-	if err != nil {
-		s.logger.Error("IncreaseShare. Accrual error", zap.Uint("markID", markID))
-		return 0, err
-	}
-	return count, nil
-}
-
 func (s *Service) SetLike(ctx context.Context, markID, userID uint) error {
 	if err := s.checkMarkExist(ctx, markID); err != nil {
 		return err
@@ -51,11 +37,11 @@ func (s *Service) SetLike(ctx context.Context, markID, userID uint) error {
 func (s *Service) checkMarkExist(ctx context.Context, markID uint) error {
 	exist, err := s.markRepo.Exist(ctx, int(markID))
 	if err != nil {
-		s.logger.Error("IncreaseShare. Mark error", zap.Uint("markID", markID))
+		s.logger.Error("checkMarkExist. Mark error", zap.Uint("markID", markID))
 		return err
 	}
 	if !exist {
-		s.logger.Warn("IncreaseShare. Mark not exist", zap.Uint("markID", markID))
+		s.logger.Warn("checkMarkExist. Mark not exist", zap.Uint("markID", markID))
 		return domainerrors.ErrMarkNotFound(int(markID))
 	}
 	return nil
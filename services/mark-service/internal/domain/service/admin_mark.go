@@ -2,15 +2,22 @@ package service
 
 import (
 	"context"
+	"time"
 
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/database/txmanager"
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/mediavalidator"
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/pagination"
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/storage"
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/transport/kafka/producer"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/domainerrors"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/model"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/repository"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/service/input"
 )
 
+// maxBulkModeration максимальное количество меток в одном массовом действии
+const maxBulkModeration = 100
+
 type AdminMarkService struct {
 	markRepo       repository.MarkRepository
 	categoryRepo   repository.CategoryRepository
@@ -22,22 +29,129 @@ func NewAdminMarkService(markRepo repository.MarkRepository,
 	categoryRepo repository.CategoryRepository,
 	store storage.Storage,
 	producer *producer.Producer,
-	validator *mediavalidator.PhotoValidator) *AdminMarkService {
+	validator *mediavalidator.PhotoValidator,
+	historyRepo repository.MarkHistoryRepository,
+	tx txmanager.TxManager) *AdminMarkService {
 	return &AdminMarkService{
 		markRepo:       markRepo,
 		categoryRepo:   categoryRepo,
 		mediaValidator: validator,
-		shared:         newMarkShared(store, producer),
+		shared:         newMarkShared(store, producer, historyRepo, tx),
 	}
 }
 
+// BulkFailure метка, к которой не удалось применить действие
+type BulkFailure struct {
+	MarkID int
+	Err    error
+}
+
+// BulkResult результат массового действия
+type BulkResult struct {
+	Succeeded []int
+	Failed    []BulkFailure
+}
+
 // Основные методы
 
-// GetAll получение всех записей
-func (s *AdminMarkService) GetAll(ctx context.Context, params pagination.Params) ([]*model.Mark, int64, error) {
-	marks, count, err := s.markRepo.GetAll(ctx, params)
+// GetAll получение всех записей с фильтрацией и сортировкой
+func (s *AdminMarkService) GetAll(ctx context.Context, filter repository.AdminFilter, params pagination.Params) ([]*model.Mark, int64, error) {
+	params.Defaults()
+	marks, count, err := s.markRepo.GetAll(ctx, filter, params)
 	if err != nil {
 		return nil, 0, err
 	}
 	return marks, count, nil
 }
+
+// Moderate применяет действие модератора к метке: скрытие, возврат, принудительное завершение или удаление.
+// Для всех действий, кроме возврата скрытой метки, причина обязательна
+func (s *AdminMarkService) Moderate(ctx context.Context, input input.ModerationInput) (*model.Mark, error) {
+	if err := validateModeration(input); err != nil {
+		return nil, err
+	}
+
+	mark, err := s.markRepo.GetByID(ctx, input.MarkID)
+	if err != nil {
+		return nil, err
+	}
+
+	prev := model.NewMarkSnapshot(mark)
+	switch input.Action {
+	case model.MarkActionHidden:
+		if mark.IsHidden {
+			return nil, domainerrors.ErrModerationNoop(mark.ID, "mark is already hidden")
+		}
+		mark.IsHidden = true
+	case model.MarkActionUnhidden:
+		if !mark.IsHidden {
+			return nil, domainerrors.ErrModerationNoop(mark.ID, "mark is not hidden")
+		}
+		mark.IsHidden = false
+	case model.MarkActionEnded:
+		if mark.HasEnded() {
+			return nil, domainerrors.ErrMarkEnded(mark.ID)
+		}
+		// Запросы карты отбирают метки по времени, поэтому окончание переносится на момент завершения
+		mark.IsEnded = true
+		mark.EndAt = time.Now()
+	}
+
+	err = s.shared.tx.WithTx(ctx, func(ctx context.Context) error {
+		if input.Action == model.MarkActionDeleted {
+			if err := s.markRepo.Delete(ctx, mark.ID); err != nil {
+				return err
+			}
+		} else if err := s.markRepo.SetModerationState(ctx, mark); err != nil {
+			return err
+		}
+		return s.shared.recordHistory(ctx, input.Action, input.UserInput, input.Reason, &prev, mark)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	go s.shared.sendModerationEvent(context.Background(), input.Action, input.UserID, input.Reason, mark)
+	return mark, nil
+}
+
+// BulkModerate применяет одно действие к списку меток (MarkID во входных данных игнорируется).
+// Ошибка по одной метке не прерывает обработку остальных
+func (s *AdminMarkService) BulkModerate(ctx context.Context, ids []int, moderator input.ModerationInput) (*BulkResult, error) {
+	if len(ids) > maxBulkModeration {
+		return nil, domainerrors.ErrTooManyMarkIDs(len(ids), maxBulkModeration)
+	}
+	if err := validateModeration(moderator); err != nil {
+		return nil, err
+	}
+
+	result := &BulkResult{Succeeded: make([]int, 0, len(ids))}
+	seen := make(map[int]struct{}, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+
+		moderator.MarkID = id
+		if _, err := s.Moderate(ctx, moderator); err != nil {
+			result.Failed = append(result.Failed, BulkFailure{MarkID: id, Err: err})
+			continue
+		}
+		result.Succeeded = append(result.Succeeded, id)
+	}
+	return result, nil
+}
+
+func validateModeration(input input.ModerationInput) error {
+	switch input.Action {
+	case model.MarkActionHidden, model.MarkActionEnded, model.MarkActionDeleted:
+		if input.Reason == "" {
+			return domainerrors.ErrModerationReasonRequired()
+		}
+	case model.MarkActionUnhidden:
+	default:
+		return domainerrors.ErrInvalidModerationAction(string(input.Action))
+	}
+	return nil
+}
@@ -0,0 +1,11 @@
+package area
+
+// Input создание сохраненной области: полигон или центр с радиусом
+type Input struct {
+	UserID      int
+	Name        string
+	Polygon     [][2]float64 // Вершины [lon, lat]
+	Center      *[2]float64  // [lon, lat]
+	Radius      *float64     // Метры
+	CategoryIDs []int
+}
@@ -0,0 +1,189 @@
+package area
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/transport/kafka/events"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/transport/kafka/producer"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/types"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/domainerrors"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/model"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/repository"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/valueobject"
+	"github.com/paulmach/orb"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultMaxPerUser = 10
+
+	minRadius = 50.0    // 50 м
+	maxRadius = 50000.0 // 50 км
+)
+
+// polygonLimits ограничения на полигон сохраненной области
+var polygonLimits = valueobject.PolygonLimits{
+	MaxVertices: 100,
+	MaxArea:     2500 * 1e6, // 2500 км²
+}
+
+// Service сохраненные области пользователей и уведомления о новых метках в них
+type Service struct {
+	areaRepo     repository.SavedAreaRepository
+	categoryRepo repository.CategoryRepository
+	producer     *producer.Producer
+
+	maxPerUser int
+
+	logger *zap.Logger
+}
+
+func NewService(
+	areaRepo repository.SavedAreaRepository,
+	categoryRepo repository.CategoryRepository,
+	producer *producer.Producer,
+	maxPerUser int,
+	logger *zap.Logger,
+) *Service {
+	if maxPerUser <= 0 {
+		maxPerUser = defaultMaxPerUser
+	}
+	return &Service{
+		areaRepo:     areaRepo,
+		categoryRepo: categoryRepo,
+		producer:     producer,
+		maxPerUser:   maxPerUser,
+		logger:       logger,
+	}
+}
+
+// Create сохраняет область пользователя с учетом лимита на количество
+func (s *Service) Create(ctx context.Context, input Input) (*model.SavedArea, error) {
+	area := &model.SavedArea{
+		UserID: input.UserID,
+		Name:   input.Name,
+	}
+
+	switch {
+	case len(input.Polygon) > 0:
+		polygon, err := valueobject.NewPolygon(input.Polygon, polygonLimits)
+		if err != nil {
+			return nil, err
+		}
+		area.Kind = model.AreaKindPolygon
+		area.Geom = types.Polygon{Polygon: polygon}
+	case input.Center != nil && input.Radius != nil:
+		if *input.Radius < minRadius || *input.Radius > maxRadius {
+			return nil, domainerrors.ErrAreaRadius(minRadius, maxRadius)
+		}
+		center := orb.Point{input.Center[0], input.Center[1]}
+		area.Kind = model.AreaKindCircle
+		area.Geom = types.Polygon{Polygon: valueobject.NewCircle(center, *input.Radius)}
+		area.Center = &types.Point{Point: center}
+		area.Radius = input.Radius
+	default:
+		return nil, domainerrors.ErrAreaShapeRequired()
+	}
+
+	categoryIDs, err := s.validateCategories(ctx, input.CategoryIDs)
+	if err != nil {
+		return nil, err
+	}
+	area.CategoryIDs = categoryIDs
+
+	count, err := s.areaRepo.CountByUser(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= int64(s.maxPerUser) {
+		return nil, domainerrors.ErrAreaLimitReached(s.maxPerUser)
+	}
+
+	if err := s.areaRepo.Create(ctx, area); err != nil {
+		return nil, err
+	}
+	return area, nil
+}
+
+// GetUserAreas области пользователя
+func (s *Service) GetUserAreas(ctx context.Context, userID int) ([]*model.SavedArea, error) {
+	return s.areaRepo.GetByUser(ctx, userID)
+}
+
+// Delete удаляет область владельца
+func (s *Service) Delete(ctx context.Context, id uint, userID int) error {
+	area, err := s.areaRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if area.UserID != userID {
+		return domainerrors.ErrPermissionDenied()
+	}
+	return s.areaRepo.Delete(ctx, id)
+}
+
+// NotifyMarkCreated находит области, в которые попала новая метка, и отправляет
+// каждому подписанному пользователю одно событие со всеми его совпавшими областями.
+// Автор метки о своей же метке не уведомляется
+func (s *Service) NotifyMarkCreated(ctx context.Context, mark *model.Mark) {
+	if s.producer == nil {
+		return
+	}
+
+	areas, err := s.areaRepo.MatchPoint(ctx, mark.Geom, mark.CategoryID)
+	if err != nil {
+		s.logger.Warn("failed to match saved areas", zap.Int("mark_id", mark.ID), zap.Error(err))
+		return
+	}
+
+	// Области отсортированы по пользователю, собираем их в группы
+	for i := 0; i < len(areas); {
+		userID := areas[i].UserID
+		var refs []events.AreaRef
+		for ; i < len(areas) && areas[i].UserID == userID; i++ {
+			refs = append(refs, events.AreaRef{ID: areas[i].ID, Name: areas[i].Name})
+		}
+		if userID == mark.UserID {
+			continue
+		}
+
+		event := events.NewAreaMarkCreated(events.AreaMarkCreatedPayload{
+			UserID:     userID,
+			Areas:      refs,
+			MarkID:     mark.ID,
+			MarkName:   mark.MarkName,
+			CategoryID: mark.CategoryID,
+			OwnerID:    mark.UserID,
+			Longitude:  mark.Geom.Lon(),
+			Latitude:   mark.Geom.Lat(),
+		})
+		err := s.producer.PublishWithMeta(ctx, producer.EventMeta{
+			EventType: events.AreaMarkCreated,
+			UserID:    strconv.Itoa(userID),
+			SourceID:  strconv.Itoa(mark.ID),
+			Timestamp: time.Now().Format(time.RFC3339)}, event)
+		if err != nil {
+			s.logger.Warn("failed to publish area mark created event", zap.Int("mark_id", mark.ID), zap.Int("user_id", userID), zap.Error(err))
+		}
+	}
+}
+
+// validateCategories проверяет существование категорий и убирает повторы
+func (s *Service) validateCategories(ctx context.Context, ids []int) (model.CategoryIDs, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	unique := slices.Clone(ids)
+	slices.Sort(unique)
+	unique = slices.Compact(unique)
+
+	for _, id := range unique {
+		if _, err := s.categoryRepo.GetByID(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+	return unique, nil
+}
@@ -0,0 +1,10 @@
+package checkin
+
+import "github.com/RealTimeMap/RealTimeMap-backend/pkg/types"
+
+// Input данные для отметки на метке
+type Input struct {
+	MarkID int
+	UserID uint
+	Geom   types.Point // Координаты клиента в момент отметки
+}
@@ -0,0 +1,150 @@
+package checkin
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/pagination"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/transport/kafka/events"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/transport/kafka/producer"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/utils"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/domainerrors"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/model"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/repository"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/infrastructure/grpc/profile"
+	"go.uber.org/zap"
+)
+
+// Service отметки присутствия пользователей на активных метках
+type Service struct {
+	markRepo       repository.MarkRepository
+	checkInRepo    repository.CheckInRepository
+	producer       *producer.Producer
+	profileAdapter *profile.Adapter
+
+	radius float64 // Допустимое расстояние до метки в метрах
+
+	logger *zap.Logger
+}
+
+func NewService(
+	markRepo repository.MarkRepository,
+	checkInRepo repository.CheckInRepository,
+	producer *producer.Producer,
+	profileAdapter *profile.Adapter,
+	radius float64,
+	logger *zap.Logger,
+) *Service {
+	return &Service{
+		markRepo:       markRepo,
+		checkInRepo:    checkInRepo,
+		producer:       producer,
+		profileAdapter: profileAdapter,
+		radius:         radius,
+		logger:         logger,
+	}
+}
+
+// CheckIn отмечает пользователя на метке, если он находится в пределах радиуса.
+// Возвращает отметку и новое количество отметок на метке.
+func (s *Service) CheckIn(ctx context.Context, input Input) (*model.MarkCheckIn, int64, error) {
+	mark, err := s.markRepo.GetByID(ctx, input.MarkID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !mark.IsActive() {
+		return nil, 0, domainerrors.ErrMarkNotActive(input.MarkID)
+	}
+
+	distance, err := s.checkInRepo.DistanceTo(ctx, input.MarkID, input.Geom)
+	if err != nil {
+		return nil, 0, err
+	}
+	if distance > s.radius {
+		return nil, 0, domainerrors.ErrCheckInTooFar(distance, s.radius)
+	}
+
+	checkIn, count, err := s.checkInRepo.Create(ctx, &model.MarkCheckIn{
+		MarkID:   input.MarkID,
+		UserID:   input.UserID,
+		Geom:     input.Geom,
+		Distance: distance,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Асинхронная отправка события в Kafka (не блокируем ответ клиенту)
+	go s.sendCheckInEvent(context.Background(), mark, checkIn)
+
+	return checkIn, count, nil
+}
+
+// GetCheckIns список пользователей, отметившихся на метке
+func (s *Service) GetCheckIns(ctx context.Context, markID int, params pagination.Params) ([]*model.MarkCheckIn, int64, error) {
+	params.Defaults()
+	if _, err := s.markRepo.GetByID(ctx, markID); err != nil {
+		return nil, 0, err
+	}
+
+	checkIns, count, err := s.checkInRepo.GetByMark(ctx, markID, params)
+	if err != nil {
+		return nil, 0, err
+	}
+	s.attachUsers(ctx, checkIns)
+	return checkIns, count, nil
+}
+
+// sendCheckInEvent отсылает ивент в kafka после отметки
+func (s *Service) sendCheckInEvent(ctx context.Context, mark *model.Mark, checkIn *model.MarkCheckIn) {
+	// Пропускаем если Kafka выключен (producer == nil)
+	if s.producer == nil {
+		return
+	}
+
+	event := events.NewMarkCheckIn(events.MarkCheckInPayload{
+		MarkID:     mark.ID,
+		CategoryID: mark.CategoryID,
+		OwnerID:    mark.UserID,
+		UserID:     checkIn.UserID,
+		Distance:   checkIn.Distance,
+	})
+	err := s.producer.PublishWithMeta(ctx, producer.EventMeta{
+		EventType: events.MarkCheckIn,
+		UserID:    strconv.FormatUint(uint64(checkIn.UserID), 10),
+		SourceID:  strconv.Itoa(mark.ID),
+		Timestamp: time.Now().Format(time.RFC3339)}, event)
+	if err != nil {
+		s.logger.Warn("failed to publish check-in event", zap.Int("mark_id", mark.ID), zap.Error(err))
+	}
+}
+
+func (s *Service) attachUsers(ctx context.Context, checkIns []*model.MarkCheckIn) {
+	if len(checkIns) == 0 {
+		return
+	}
+
+	ids := make([]uint, len(checkIns))
+	for i, c := range checkIns {
+		ids[i] = c.UserID
+	}
+
+	byID := make(map[uint]*model.UserProfile, len(ids))
+	profiles, err := s.profileAdapter.GetUserProfileByIDs(ctx, utils.UniqueValues(ids))
+	if err != nil {
+		s.logger.Warn("failed to load check-in profiles", zap.Error(err))
+	} else {
+		for _, p := range profiles {
+			byID[p.ID] = p
+		}
+	}
+
+	for _, c := range checkIns {
+		if p, ok := byID[c.UserID]; ok {
+			c.User = p
+		} else {
+			c.User = &model.UserProfile{ID: c.UserID}
+		}
+	}
+}
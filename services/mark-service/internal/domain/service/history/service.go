@@ -0,0 +1,64 @@
+package history
+
+import (
+	"context"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/pagination"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/domainerrors"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/model"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/repository"
+	"go.uber.org/zap"
+)
+
+// Service просмотр журнала изменений меток.
+// Записи в журнал создаются при изменении метки (см. markShared.recordHistory)
+type Service struct {
+	markRepo    repository.MarkRepository
+	historyRepo repository.MarkHistoryRepository
+
+	logger *zap.Logger
+}
+
+func NewService(
+	markRepo repository.MarkRepository,
+	historyRepo repository.MarkHistoryRepository,
+	logger *zap.Logger,
+) *Service {
+	return &Service{
+		markRepo:    markRepo,
+		historyRepo: historyRepo,
+		logger:      logger,
+	}
+}
+
+// GetHistory история метки для модераторов, доступна в том числе для удаленных меток
+func (s *Service) GetHistory(ctx context.Context, markID int, params pagination.Params) ([]*model.MarkHistory, int64, error) {
+	return s.historyRepo.GetByMark(ctx, markID, params)
+}
+
+// GetOwnerHistory история метки для ее владельца
+func (s *Service) GetOwnerHistory(ctx context.Context, markID, userID int, params pagination.Params) ([]*model.MarkHistory, int64, error) {
+	if err := s.checkOwner(ctx, markID, userID); err != nil {
+		return nil, 0, err
+	}
+	return s.historyRepo.GetByMark(ctx, markID, params)
+}
+
+// GetOwnerVersion конкретная версия метки для ее владельца
+func (s *Service) GetOwnerVersion(ctx context.Context, markID, version, userID int) (*model.MarkHistory, error) {
+	if err := s.checkOwner(ctx, markID, userID); err != nil {
+		return nil, err
+	}
+	return s.historyRepo.GetVersion(ctx, markID, version)
+}
+
+func (s *Service) checkOwner(ctx context.Context, markID, userID int) error {
+	mark, err := s.markRepo.GetByID(ctx, markID)
+	if err != nil {
+		return err
+	}
+	if mark.UserID != userID {
+		return domainerrors.ErrPermissionDenied()
+	}
+	return nil
+}
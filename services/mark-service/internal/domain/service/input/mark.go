@@ -6,6 +6,7 @@ import (
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/helpers/context"
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/mediavalidator"
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/types"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/model"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/valueobject"
 )
 
@@ -35,3 +36,27 @@ type MarkUpdateInput struct {
 
 	context.UserInput // TODO Что это вообще за хуйня?!
 }
+
+// ModerationInput - действие модератора над меткой
+type ModerationInput struct {
+	MarkID int
+	Action model.MarkAction // hidden | unhidden | ended | deleted
+	Reason string
+	context.UserInput
+}
+
+// ReportInput - жалоба пользователя на метку
+type ReportInput struct {
+	MarkID  int
+	Reason  model.ReportReason
+	Comment *string
+	context.UserInput
+}
+
+// ReportResolveInput - решение модератора по жалобам на метку
+type ReportResolveInput struct {
+	MarkID   int
+	Accepted bool   // true - жалобы подтверждены, false - признаны ложными
+	Reason   string // Причина скрытия при подтверждении
+	context.UserInput
+}
@@ -6,6 +6,8 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/database/txmanager"
+	helper "github.com/RealTimeMap/RealTimeMap-backend/pkg/helpers/context"
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/mediavalidator"
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/storage"
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/transport/kafka/events"
@@ -13,24 +15,31 @@ import (
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/types"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/domainerrors"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/model"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/repository"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/service/input"
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/geo"
 )
 
 // markShared содержит общие функции для работы с метками, используемые как UserMarkService, так и AdminMarkService
 type markShared struct {
-	store    storage.Storage
-	producer *producer.Producer
+	store       storage.Storage
+	producer    *producer.Producer
+	historyRepo repository.MarkHistoryRepository
+	tx          txmanager.TxManager
 }
 
-func newMarkShared(store storage.Storage, producer *producer.Producer) *markShared {
+func newMarkShared(store storage.Storage, producer *producer.Producer, historyRepo repository.MarkHistoryRepository, tx txmanager.TxManager) *markShared {
 	return &markShared{
-		store:    store,
-		producer: producer,
+		store:       store,
+		producer:    producer,
+		historyRepo: historyRepo,
+		tx:          tx,
 	}
 }
 
-// uploadPhotos загружает все фото в storage
-func (s *markShared) uploadPhotos(ctx context.Context, photos []mediavalidator.PhotoInput) (types.Photos, error) {
+// uploadPhotos загружает все фото в storage и помечает снятые далеко от метки
+func (s *markShared) uploadPhotos(ctx context.Context, photos []mediavalidator.PhotoInput, geom types.Point) (types.Photos, error) {
 	// Подготовка файлов для загрузки
 	fileUploads := make([]storage.FileUpload, 0, len(photos))
 
@@ -43,6 +52,7 @@ func (s *markShared) uploadPhotos(ctx context.Context, photos []mediavalidator.P
 				MaxSize:       5 * 1024 * 1024, // 5MB
 				GenerateThumb: false,
 				Optimize:      false, // Отключаем оптимизацию для ускорения
+				KeepMetadata:  true,  // Нужны координаты съемки для проверки расстояния до метки
 			},
 		})
 	}
@@ -52,15 +62,30 @@ func (s *markShared) uploadPhotos(ctx context.Context, photos []mediavalidator.P
 	if err != nil {
 		return nil, err
 	}
+	flagDistantPhotos(uploadedPhotos, geom)
 
 	return uploadedPhotos, nil
 }
 
+// flagDistantPhotos вычисляет расстояние от места съемки до метки
+// и помечает фото, сделанные дальше maxPhotoDistance
+func flagDistantPhotos(photos types.Photos, geom types.Point) {
+	for i := range photos {
+		meta := photos[i].Metadata
+		if !meta.HasLocation() {
+			continue
+		}
+		distance := geo.Distance(orb.Point{*meta.Longitude, *meta.Latitude}, geom.Point)
+		meta.Distance = &distance
+		meta.LocationMismatch = distance > maxPhotoDistance
+	}
+}
+
 // updatePhotos обрабатывает обновление фотографий:
 // 1. Удаляет старые фото из storage и массива
 // 2. Загружает новые фото в storage
 // 3. Возвращает обновленный массив фотографий
-func (s *markShared) updatePhotos(ctx context.Context, currentPhotos types.Photos, newPhotos []mediavalidator.PhotoInput, photosToDelete []string, maxPhotos int) (types.Photos, error) {
+func (s *markShared) updatePhotos(ctx context.Context, geom types.Point, currentPhotos types.Photos, newPhotos []mediavalidator.PhotoInput, photosToDelete []string, maxPhotos int) (types.Photos, error) {
 	// 1. Создаем map для быстрого поиска удаляемых фото (по URL)
 	deleteMap := make(map[string]bool, len(photosToDelete))
 	for _, url := range photosToDelete {
@@ -83,7 +108,7 @@ func (s *markShared) updatePhotos(ctx context.Context, currentPhotos types.Photo
 	var uploadedPhotos types.Photos
 	if len(newPhotos) > 0 {
 		var err error
-		uploadedPhotos, err = s.uploadPhotos(ctx, newPhotos)
+		uploadedPhotos, err = s.uploadPhotos(ctx, newPhotos, geom)
 		if err != nil {
 			return nil, domainerrors.ErrStorageOperation("upload photos", err)
 		}
@@ -100,6 +125,35 @@ func (s *markShared) updatePhotos(ctx context.Context, currentPhotos types.Photo
 	return resultPhotos, nil
 }
 
+// recordHistory записывает новую версию метки в журнал изменений.
+// prev - состояние до изменения (nil при создании), reason - причина действия (может быть пустой).
+// Обновление без изменений не записывается. Вызывать в той же транзакции, что и изменение метки
+func (s *markShared) recordHistory(ctx context.Context, action model.MarkAction, actor helper.UserInput, reason string, prev *model.MarkSnapshot, mark *model.Mark) error {
+	snapshot := model.NewMarkSnapshot(mark)
+
+	var changes model.FieldChanges
+	if prev != nil {
+		changes = snapshot.Diff(*prev)
+		if action == model.MarkActionUpdated && len(changes) == 0 {
+			return nil
+		}
+	}
+
+	entry := &model.MarkHistory{
+		MarkID:       mark.ID,
+		Action:       action,
+		ActorID:      actor.UserID,
+		ActorName:    actor.UserName,
+		ActorIsAdmin: actor.IsAdmin,
+		Changes:      changes,
+		Snapshot:     snapshot,
+	}
+	if reason != "" {
+		entry.Reason = &reason
+	}
+	return s.historyRepo.Create(ctx, entry)
+}
+
 // sendCreateEvent отсылает ивент в kafka при создании метки
 func (s *markShared) sendCreateEvent(ctx context.Context, mark *model.Mark) {
 	// Пропускаем если Kafka выключен (producer == nil)
@@ -116,6 +170,38 @@ func (s *markShared) sendCreateEvent(ctx context.Context, mark *model.Mark) {
 		Timestamp: time.Now().Format(time.RFC3339)}, event)
 }
 
+// moderationEvents соответствие действия модератора событию и его типу в kafka
+var moderationEvents = map[model.MarkAction]struct{ event, eventType string }{
+	model.MarkActionHidden:   {events.MarkHidden, "mark.hidden"},
+	model.MarkActionUnhidden: {events.MarkUnhidden, "mark.unhidden"},
+	model.MarkActionEnded:    {events.MarkUpdated, "mark.updated"},
+	model.MarkActionDeleted:  {events.MarkDeleted, "mark.deleted"},
+}
+
+// sendModerationEvent отсылает ивент в kafka о действии модератора над меткой
+func (s *markShared) sendModerationEvent(ctx context.Context, action model.MarkAction, moderatorID int, reason string, mark *model.Mark) {
+	if s.producer == nil {
+		return
+	}
+	meta, ok := moderationEvents[action]
+	if !ok {
+		return
+	}
+
+	payload := events.NewMarkPayload(mark.ID, mark.CategoryID, mark.UserID, mark.MarkName, mark.AdditionalInfo)
+	payload.IsEnded = mark.IsEnded
+	event := events.NewMarkModeration(meta.event, events.MarkModerationPayload{
+		MarkPayload: payload,
+		ModeratorID: moderatorID,
+		Reason:      reason,
+	})
+	_ = s.producer.PublishWithMeta(ctx, producer.EventMeta{
+		EventType: meta.eventType,
+		UserID:    strconv.Itoa(mark.UserID),
+		SourceID:  strconv.Itoa(mark.ID),
+		Timestamp: time.Now().Format(time.RFC3339)}, event)
+}
+
 // applyUpdates вспомогательная функция для обновления полей метки
 func applyUpdates(mark *model.Mark, input input.MarkUpdateInput) {
 	if input.MarkName != nil {
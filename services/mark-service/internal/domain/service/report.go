@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/database/txmanager"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/pagination"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/storage"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/transport/kafka/producer"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/domainerrors"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/model"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/repository"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/service/input"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultAutoHideThreshold = 5.0
+	autoHideReason           = "reports threshold reached"
+	rejectedReportsReason    = "reports rejected"
+)
+
+// ReportService жалобы пользователей на метки: подача, автоматическое скрытие и рассмотрение модераторами
+type ReportService struct {
+	markRepo   repository.MarkRepository
+	reportRepo repository.ReportRepository
+	shared     *markShared
+
+	autoHideThreshold float64 // Сумма весов жалоб, при которой метка скрывается до рассмотрения
+
+	logger *zap.Logger
+}
+
+func NewReportService(markRepo repository.MarkRepository,
+	reportRepo repository.ReportRepository,
+	store storage.Storage,
+	producer *producer.Producer,
+	historyRepo repository.MarkHistoryRepository,
+	tx txmanager.TxManager,
+	autoHideThreshold float64,
+	logger *zap.Logger) *ReportService {
+	if autoHideThreshold <= 0 {
+		autoHideThreshold = defaultAutoHideThreshold
+	}
+	return &ReportService{
+		markRepo:          markRepo,
+		reportRepo:        reportRepo,
+		shared:            newMarkShared(store, producer, historyRepo, tx),
+		autoHideThreshold: autoHideThreshold,
+		logger:            logger,
+	}
+}
+
+// Report сохраняет жалобу с весом по репутации автора. Когда сумма весов нерассмотренных жалоб
+// достигает порога, метка скрывается до решения модератора
+func (s *ReportService) Report(ctx context.Context, input input.ReportInput) (*model.MarkReport, error) {
+	if !input.Reason.IsValid() {
+		return nil, domainerrors.ErrInvalidReportReason(string(input.Reason))
+	}
+
+	mark, err := s.markRepo.GetByID(ctx, input.MarkID)
+	if err != nil {
+		return nil, err
+	}
+	if mark.IsHidden {
+		return nil, domainerrors.ErrMarkNotFound(input.MarkID)
+	}
+	if mark.UserID == input.UserID {
+		return nil, domainerrors.ErrReportOwnMark(input.MarkID)
+	}
+
+	reputation, err := s.reportRepo.GetReputation(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	report := &model.MarkReport{
+		MarkID:  input.MarkID,
+		UserID:  input.UserID,
+		Reason:  input.Reason,
+		Comment: input.Comment,
+		Status:  model.ReportPending,
+		Weight:  reputation.Weight(),
+	}
+
+	var hidden bool
+	err = s.shared.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.reportRepo.Create(ctx, report); err != nil {
+			return err
+		}
+		if err := s.reportRepo.IncrementReports(ctx, input.MarkID); err != nil {
+			return err
+		}
+
+		score, err := s.reportRepo.PendingScore(ctx, input.MarkID)
+		if err != nil || score < s.autoHideThreshold {
+			return err
+		}
+		// Метку могли скрыть параллельной жалобой, повторно в историю не пишем
+		hidden, err = s.reportRepo.HideMark(ctx, input.MarkID)
+		if err != nil || !hidden {
+			return err
+		}
+		prev := model.NewMarkSnapshot(mark)
+		mark.IsHidden = true
+		return s.shared.recordHistory(ctx, model.MarkActionHidden, systemActor, autoHideReason, &prev, mark)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if hidden {
+		s.logger.Info("mark auto-hidden by reports", zap.Int("markID", mark.ID))
+		go s.shared.sendModerationEvent(context.Background(), model.MarkActionHidden, systemActor.UserID, autoHideReason, mark)
+	}
+	return report, nil
+}
+
+// GetQueue очередь меток с нерассмотренными жалобами
+func (s *ReportService) GetQueue(ctx context.Context, params pagination.Params) ([]*model.ReportQueueItem, int64, error) {
+	params.Defaults()
+	items, count, err := s.reportRepo.GetQueue(ctx, params)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ids := make([]int, len(items))
+	for i, item := range items {
+		ids[i] = item.MarkID
+	}
+	marks, err := s.markRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, 0, err
+	}
+	byID := make(map[int]*model.Mark, len(marks))
+	for _, mark := range marks {
+		byID[mark.ID] = mark
+	}
+	for _, item := range items {
+		item.Mark = byID[item.MarkID]
+	}
+	return items, count, nil
+}
+
+// GetMarkReports жалобы на метку для модератора
+func (s *ReportService) GetMarkReports(ctx context.Context, markID int, status model.ReportStatus, params pagination.Params) ([]*model.MarkReport, int64, error) {
+	params.Defaults()
+	return s.reportRepo.GetByMark(ctx, markID, status, params)
+}
+
+// Resolve закрывает нерассмотренные жалобы на метку и обновляет репутацию их авторов.
+// Подтвержденные жалобы скрывают метку, ложные - возвращают метку, скрытую автоматически
+func (s *ReportService) Resolve(ctx context.Context, input input.ReportResolveInput) (*model.Mark, error) {
+	if input.Accepted && input.Reason == "" {
+		return nil, domainerrors.ErrModerationReasonRequired()
+	}
+
+	mark, err := s.markRepo.GetByID(ctx, input.MarkID)
+	if err != nil {
+		return nil, err
+	}
+	autoHidden, err := s.isAutoHidden(ctx, mark)
+	if err != nil {
+		return nil, err
+	}
+
+	status := model.ReportRejected
+	if input.Accepted {
+		status = model.ReportAccepted
+	}
+
+	var action model.MarkAction
+	prev := model.NewMarkSnapshot(mark)
+	switch {
+	case input.Accepted && !mark.IsHidden:
+		action = model.MarkActionHidden
+		mark.IsHidden = true
+	case !input.Accepted && autoHidden:
+		action = model.MarkActionUnhidden
+		input.Reason = rejectedReportsReason
+		mark.IsHidden = false
+	}
+
+	err = s.shared.tx.WithTx(ctx, func(ctx context.Context) error {
+		reporters, err := s.reportRepo.Resolve(ctx, input.MarkID, status, input.UserID)
+		if err != nil {
+			return err
+		}
+		if len(reporters) == 0 {
+			return domainerrors.ErrNoPendingReports(input.MarkID)
+		}
+		if err := s.reportRepo.AddReputation(ctx, reporters, input.Accepted); err != nil {
+			return err
+		}
+		if err := s.reportRepo.ResetReports(ctx, input.MarkID); err != nil {
+			return err
+		}
+		mark.ReportsCount = 0
+
+		if action == "" {
+			return nil
+		}
+		if err := s.markRepo.SetModerationState(ctx, mark); err != nil {
+			return err
+		}
+		return s.shared.recordHistory(ctx, action, input.UserInput, input.Reason, &prev, mark)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if action != "" {
+		go s.shared.sendModerationEvent(context.Background(), action, input.UserID, input.Reason, mark)
+	}
+	return mark, nil
+}
+
+// GetReputation статистика рассмотренных жалоб пользователя
+func (s *ReportService) GetReputation(ctx context.Context, userID int) (*model.ReporterReputation, error) {
+	return s.reportRepo.GetReputation(ctx, userID)
+}
+
+// isAutoHidden проверяет, что метка скрыта системой по жалобам, а не модератором
+func (s *ReportService) isAutoHidden(ctx context.Context, mark *model.Mark) (bool, error) {
+	if !mark.IsHidden {
+		return false, nil
+	}
+	last, _, err := s.shared.historyRepo.GetByMark(ctx, mark.ID, pagination.Params{Page: 1, PageSize: 1})
+	if err != nil || len(last) == 0 {
+		return false, err
+	}
+	return last[0].Action == model.MarkActionHidden && last[0].ActorID == systemActor.UserID, nil
+}
@@ -0,0 +1,28 @@
+package rsvp
+
+import (
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/pagination"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/model"
+)
+
+// Input ответ пользователя на метку
+type Input struct {
+	MarkID int
+	UserID uint
+	Status model.RSVPStatus
+}
+
+// CapacityInput изменение вместимости владельцем метки
+type CapacityInput struct {
+	MarkID   int
+	UserID   uint
+	Capacity *int // nil - снять ограничение
+}
+
+// AttendeesParams параметры списка участников для владельца
+type AttendeesParams struct {
+	MarkID     int
+	UserID     uint
+	Status     model.RSVPStatus // Пустой - все статусы
+	Pagination pagination.Params
+}
@@ -0,0 +1,321 @@
+package rsvp
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/database/txmanager"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/pagination"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/transport/kafka/events"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/transport/kafka/producer"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/utils"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/domainerrors"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/model"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/repository"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/infrastructure/grpc/profile"
+	"go.uber.org/zap"
+)
+
+const maxCapacity = 100000
+
+// Service ответы пользователей на события меток с учетом вместимости и листа ожидания
+type Service struct {
+	markRepo       repository.MarkRepository
+	rsvpRepo       repository.RSVPRepository
+	tx             txmanager.TxManager
+	producer       *producer.Producer
+	profileAdapter *profile.Adapter
+
+	logger *zap.Logger
+}
+
+func NewService(
+	markRepo repository.MarkRepository,
+	rsvpRepo repository.RSVPRepository,
+	tx txmanager.TxManager,
+	producer *producer.Producer,
+	profileAdapter *profile.Adapter,
+	logger *zap.Logger,
+) *Service {
+	return &Service{
+		markRepo:       markRepo,
+		rsvpRepo:       rsvpRepo,
+		tx:             tx,
+		producer:       producer,
+		profileAdapter: profileAdapter,
+		logger:         logger,
+	}
+}
+
+// Respond сохраняет ответ пользователя. При заполненной вместимости going превращается в waitlist,
+// а освободившееся место отдается первому в листе ожидания.
+func (s *Service) Respond(ctx context.Context, input Input) (*model.MarkRSVP, error) {
+	if !input.Status.IsSelectable() {
+		return nil, domainerrors.ErrInvalidRSVPStatus(string(input.Status))
+	}
+
+	var (
+		mark     *model.Mark
+		result   *model.MarkRSVP
+		promoted []*model.MarkRSVP
+	)
+	err := s.tx.WithTx(ctx, func(txCtx context.Context) error {
+		var err error
+		mark, err = s.lockOpenMark(txCtx, input.MarkID)
+		if err != nil {
+			return err
+		}
+
+		current, err := s.rsvpRepo.Get(txCtx, input.MarkID, input.UserID)
+		if err != nil {
+			return err
+		}
+
+		status, err := s.resolveStatus(txCtx, mark, current, input.Status)
+		if err != nil {
+			return err
+		}
+		// Повторный ответ тем же статусом не должен сдвигать очередь
+		if current != nil && current.Status == status {
+			result = current
+			return nil
+		}
+
+		result = &model.MarkRSVP{MarkID: input.MarkID, UserID: input.UserID, Status: status}
+		if err := s.rsvpRepo.Save(txCtx, result); err != nil {
+			return err
+		}
+
+		if current != nil && current.Status == model.RSVPGoing {
+			promoted, err = s.promote(txCtx, mark)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	go s.sendPromotedEvents(context.Background(), mark, promoted)
+	return result, nil
+}
+
+// Cancel удаляет ответ пользователя
+func (s *Service) Cancel(ctx context.Context, markID int, userID uint) error {
+	var (
+		mark     *model.Mark
+		promoted []*model.MarkRSVP
+	)
+	err := s.tx.WithTx(ctx, func(txCtx context.Context) error {
+		var err error
+		mark, err = s.lockOpenMark(txCtx, markID)
+		if err != nil {
+			return err
+		}
+
+		current, err := s.rsvpRepo.Get(txCtx, markID, userID)
+		if err != nil {
+			return err
+		}
+		if current == nil {
+			return domainerrors.ErrRSVPNotFound(markID)
+		}
+		if err := s.rsvpRepo.Delete(txCtx, markID, userID); err != nil {
+			return err
+		}
+
+		if current.Status == model.RSVPGoing {
+			promoted, err = s.promote(txCtx, mark)
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	go s.sendPromotedEvents(context.Background(), mark, promoted)
+	return nil
+}
+
+// SetCapacity изменяет вместимость метки. Новые места сразу отдаются листу ожидания,
+// уже подтвердившие участие при уменьшении вместимости не вытесняются.
+func (s *Service) SetCapacity(ctx context.Context, input CapacityInput) (*model.RSVPSummary, error) {
+	if input.Capacity != nil && (*input.Capacity < 1 || *input.Capacity > maxCapacity) {
+		return nil, domainerrors.ErrInvalidCapacity(*input.Capacity)
+	}
+
+	var (
+		mark     *model.Mark
+		promoted []*model.MarkRSVP
+	)
+	err := s.tx.WithTx(ctx, func(txCtx context.Context) error {
+		var err error
+		mark, err = s.lockOpenMark(txCtx, input.MarkID)
+		if err != nil {
+			return err
+		}
+		if uint(mark.UserID) != input.UserID {
+			return domainerrors.ErrPermissionDenied()
+		}
+
+		if err := s.rsvpRepo.SetCapacity(txCtx, input.MarkID, input.Capacity); err != nil {
+			return err
+		}
+		mark.Capacity = input.Capacity
+
+		promoted, err = s.promote(txCtx, mark)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	go s.sendPromotedEvents(context.Background(), mark, promoted)
+	return s.Summary(ctx, mark)
+}
+
+// Summary сводка по ответам для детального просмотра метки
+func (s *Service) Summary(ctx context.Context, mark *model.Mark) (*model.RSVPSummary, error) {
+	summary, err := s.rsvpRepo.GetSummary(ctx, mark.ID)
+	if err != nil {
+		return nil, err
+	}
+	summary.Capacity = mark.Capacity
+	return summary, nil
+}
+
+// GetAttendees список ответивших на метку, доступен только владельцу
+func (s *Service) GetAttendees(ctx context.Context, params AttendeesParams) ([]*model.MarkRSVP, int64, error) {
+	params.Pagination.Defaults()
+	if params.Status != "" && params.Status != model.RSVPWaitlist && !params.Status.IsSelectable() {
+		return nil, 0, domainerrors.ErrInvalidRSVPStatus(string(params.Status))
+	}
+
+	mark, err := s.markRepo.GetByID(ctx, params.MarkID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if uint(mark.UserID) != params.UserID {
+		return nil, 0, domainerrors.ErrPermissionDenied()
+	}
+
+	rsvps, count, err := s.rsvpRepo.GetByMark(ctx, params.MarkID, params.Status, params.Pagination)
+	if err != nil {
+		return nil, 0, err
+	}
+	s.attachUsers(ctx, rsvps)
+	return rsvps, count, nil
+}
+
+// GetUpcoming предстоящие метки пользователя: Ближайшие -> Дальние
+func (s *Service) GetUpcoming(ctx context.Context, userID uint, params pagination.Params) ([]*model.MarkRSVP, int64, error) {
+	params.Defaults()
+	return s.rsvpRepo.GetUpcoming(ctx, userID, params)
+}
+
+// lockOpenMark блокирует метку и проверяет, что событие еще не прошло
+func (s *Service) lockOpenMark(ctx context.Context, markID int) (*model.Mark, error) {
+	mark, err := s.rsvpRepo.LockMark(ctx, markID)
+	if err != nil {
+		return nil, err
+	}
+	if mark.IsHidden {
+		return nil, domainerrors.ErrMarkNotFound(markID)
+	}
+	if mark.HasEnded() {
+		return nil, domainerrors.ErrMarkEnded(markID)
+	}
+	return mark, nil
+}
+
+// resolveStatus определяет итоговый статус с учетом вместимости
+func (s *Service) resolveStatus(ctx context.Context, mark *model.Mark, current *model.MarkRSVP, requested model.RSVPStatus) (model.RSVPStatus, error) {
+	if requested != model.RSVPGoing || mark.Capacity == nil {
+		return requested, nil
+	}
+	if current != nil && (current.Status == model.RSVPGoing || current.Status == model.RSVPWaitlist) {
+		// Уже занятое место или позиция в очереди сохраняются
+		return current.Status, nil
+	}
+
+	going, err := s.rsvpRepo.CountGoing(ctx, mark.ID)
+	if err != nil {
+		return "", err
+	}
+	if going >= int64(*mark.Capacity) {
+		return model.RSVPWaitlist, nil
+	}
+	return model.RSVPGoing, nil
+}
+
+// promote переводит пользователей из листа ожидания на свободные места
+func (s *Service) promote(ctx context.Context, mark *model.Mark) ([]*model.MarkRSVP, error) {
+	if mark.Capacity == nil {
+		return s.rsvpRepo.PromoteWaitlisted(ctx, mark.ID, -1)
+	}
+
+	going, err := s.rsvpRepo.CountGoing(ctx, mark.ID)
+	if err != nil {
+		return nil, err
+	}
+	free := int64(*mark.Capacity) - going
+	if free <= 0 {
+		return nil, nil
+	}
+	return s.rsvpRepo.PromoteWaitlisted(ctx, mark.ID, int(free))
+}
+
+// sendPromotedEvents уведомляет пользователей, получивших место из листа ожидания
+func (s *Service) sendPromotedEvents(ctx context.Context, mark *model.Mark, promoted []*model.MarkRSVP) {
+	// Пропускаем если Kafka выключен (producer == nil)
+	if s.producer == nil || len(promoted) == 0 {
+		return
+	}
+
+	for _, rsvp := range promoted {
+		event := events.NewMarkRSVPPromoted(events.MarkRSVPPayload{
+			MarkID:   mark.ID,
+			OwnerID:  mark.UserID,
+			UserID:   rsvp.UserID,
+			MarkName: mark.MarkName,
+			Status:   string(rsvp.Status),
+		})
+		err := s.producer.PublishWithMeta(ctx, producer.EventMeta{
+			EventType: events.MarkRSVPPromoted,
+			UserID:    strconv.FormatUint(uint64(rsvp.UserID), 10),
+			SourceID:  strconv.Itoa(mark.ID),
+			Timestamp: time.Now().Format(time.RFC3339)}, event)
+		if err != nil {
+			s.logger.Warn("failed to publish rsvp promoted event", zap.Int("mark_id", mark.ID), zap.Error(err))
+		}
+	}
+}
+
+func (s *Service) attachUsers(ctx context.Context, rsvps []*model.MarkRSVP) {
+	if len(rsvps) == 0 {
+		return
+	}
+
+	ids := make([]uint, len(rsvps))
+	for i, r := range rsvps {
+		ids[i] = r.UserID
+	}
+
+	byID := make(map[uint]*model.UserProfile, len(ids))
+	profiles, err := s.profileAdapter.GetUserProfileByIDs(ctx, utils.UniqueValues(ids))
+	if err != nil {
+		s.logger.Warn("failed to load rsvp profiles", zap.Error(err))
+	} else {
+		for _, p := range profiles {
+			byID[p.ID] = p
+		}
+	}
+
+	for _, r := range rsvps {
+		if p, ok := byID[r.UserID]; ok {
+			r.User = p
+		} else {
+			r.User = &model.UserProfile{ID: r.UserID}
+		}
+	}
+}
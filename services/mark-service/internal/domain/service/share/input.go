@@ -0,0 +1,13 @@
+package share
+
+// Visitor посетитель, открывший ссылку
+type Visitor struct {
+	UserID uint   // Пользователь, подтвержденный шлюзом. 0 для анонимного посетителя
+	IP     string // Адрес анонимного посетителя
+}
+
+// Link ссылка на метку от имени пользователя
+type Link struct {
+	Token string
+	URL   string
+}
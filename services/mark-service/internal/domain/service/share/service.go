@@ -0,0 +1,143 @@
+package share
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/transport/kafka/events"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/transport/kafka/producer"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/domainerrors"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/model"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/repository"
+	"go.uber.org/zap"
+)
+
+// Service подписанные ссылки на метки и учет переходов по ним
+type Service struct {
+	markRepo  repository.MarkRepository
+	shareRepo repository.ShareRepository
+	producer  *producer.Producer
+	signer    signer
+
+	linkBaseURL string // Адрес публичного эндпоинта, к которому дописывается токен
+	redirectURL string // Адрес клиента, в котором открывается метка
+
+	logger *zap.Logger
+}
+
+func NewService(
+	markRepo repository.MarkRepository,
+	shareRepo repository.ShareRepository,
+	producer *producer.Producer,
+	secret string,
+	linkBaseURL string,
+	redirectURL string,
+	logger *zap.Logger,
+) *Service {
+	return &Service{
+		markRepo:    markRepo,
+		shareRepo:   shareRepo,
+		producer:    producer,
+		signer:      newSigner(secret),
+		linkBaseURL: strings.TrimRight(linkBaseURL, "/"),
+		redirectURL: strings.TrimRight(redirectURL, "/"),
+		logger:      logger,
+	}
+}
+
+// CreateLink выдает ссылку на метку от имени пользователя
+func (s *Service) CreateLink(ctx context.Context, markID int, sharerID uint) (Link, error) {
+	if _, err := s.getVisibleMark(ctx, markID); err != nil {
+		return Link{}, err
+	}
+	token := s.signer.Sign(markID, sharerID)
+	return Link{Token: token, URL: s.linkBaseURL + "/" + token}, nil
+}
+
+// Open разбирает токен и учитывает переход. Повторные переходы одного посетителя не засчитываются
+func (s *Service) Open(ctx context.Context, token string, visitor Visitor) (*model.Mark, error) {
+	markID, sharerID, err := s.signer.Parse(token)
+	if err != nil {
+		return nil, domainerrors.ErrInvalidShareToken(token)
+	}
+	mark, err := s.getVisibleMark(ctx, markID)
+	if err != nil {
+		return nil, err
+	}
+	// Переходы по собственной ссылке не засчитываются
+	if visitor.UserID != 0 && visitor.UserID == sharerID {
+		return mark, nil
+	}
+
+	created, err := s.shareRepo.RecordOpen(ctx, &model.MarkShareOpen{
+		MarkID:    markID,
+		VisitorID: s.visitorID(visitor),
+		SharerID:  sharerID,
+	})
+	if err != nil {
+		// Учет перехода не должен мешать открыть метку
+		s.logger.Warn("failed to record share open", zap.Int("mark_id", markID), zap.Error(err))
+		return mark, nil
+	}
+	if created {
+		mark.SharedCount++
+		go s.sendOpenedEvent(context.Background(), mark, sharerID)
+	}
+	return mark, nil
+}
+
+// visitorID ключ уникальности посетителя. Пользователь учитывается по id, анонимный
+// посетитель - по IP: смена User-Agent или браузера не дает нового перехода
+func (s *Service) visitorID(visitor Visitor) string {
+	if visitor.UserID != 0 {
+		return "u:" + strconv.FormatUint(uint64(visitor.UserID), 10)
+	}
+	return "a:" + s.signer.Fingerprint(visitor.IP)
+}
+
+// MarkURL адрес метки в клиенте
+func (s *Service) MarkURL(markID int) string {
+	return s.redirectURL + "/marks/" + strconv.Itoa(markID)
+}
+
+func (s *Service) getVisibleMark(ctx context.Context, markID int) (*model.Mark, error) {
+	mark, err := s.markRepo.GetByID(ctx, markID)
+	if err != nil {
+		return nil, err
+	}
+	if mark.IsHidden {
+		return nil, domainerrors.ErrMarkNotFound(markID)
+	}
+	return mark, nil
+}
+
+// sendOpenedEvent уведомляет поделившегося пользователя о новом переходе
+func (s *Service) sendOpenedEvent(ctx context.Context, mark *model.Mark, sharerID uint) {
+	// Пропускаем если Kafka выключен (producer == nil)
+	if s.producer == nil {
+		return
+	}
+
+	opens, err := s.shareRepo.CountBySharer(ctx, mark.ID, sharerID)
+	if err != nil {
+		s.logger.Warn("failed to count share opens", zap.Int("mark_id", mark.ID), zap.Error(err))
+		return
+	}
+	event := events.NewMarkShareOpened(events.MarkShareOpenedPayload{
+		MarkID:   mark.ID,
+		OwnerID:  mark.UserID,
+		SharerID: sharerID,
+		MarkName: mark.MarkName,
+		Opens:    opens,
+	})
+	err = s.producer.PublishWithMeta(ctx, producer.EventMeta{
+		EventType: events.MarkShareOpened,
+		UserID:    strconv.FormatUint(uint64(sharerID), 10),
+		SourceID:  strconv.Itoa(mark.ID),
+		Timestamp: time.Now().Format(time.RFC3339)}, event)
+	if err != nil {
+		s.logger.Warn("failed to publish share opened event", zap.Int("mark_id", mark.ID), zap.Error(err))
+	}
+}
@@ -0,0 +1,65 @@
+package share
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+)
+
+// signatureSize длина подписи в байтах. 8 байт HMAC достаточно против подбора и держат токен коротким
+const signatureSize = 8
+
+var errInvalidToken = errors.New("invalid share token")
+
+// signer подписывает пары (метка, поделившийся пользователь) в короткие токены
+type signer struct {
+	secret []byte
+}
+
+func newSigner(secret string) signer {
+	return signer{secret: []byte(secret)}
+}
+
+// Sign токен вида base64url(uvarint(markID) + uvarint(sharerID) + hmac[:8])
+func (s signer) Sign(markID int, sharerID uint) string {
+	payload := binary.AppendUvarint(nil, uint64(markID))
+	payload = binary.AppendUvarint(payload, uint64(sharerID))
+	return base64.RawURLEncoding.EncodeToString(append(payload, s.sum(payload)...))
+}
+
+// Parse проверяет подпись и возвращает метку и поделившегося пользователя
+func (s signer) Parse(token string) (int, uint, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(raw) <= signatureSize {
+		return 0, 0, errInvalidToken
+	}
+	payload, signature := raw[:len(raw)-signatureSize], raw[len(raw)-signatureSize:]
+	if !hmac.Equal(signature, s.sum(payload)) {
+		return 0, 0, errInvalidToken
+	}
+
+	markID, n := binary.Uvarint(payload)
+	if n <= 0 || markID == 0 {
+		return 0, 0, errInvalidToken
+	}
+	sharerID, m := binary.Uvarint(payload[n:])
+	if m <= 0 || n+m != len(payload) {
+		return 0, 0, errInvalidToken
+	}
+	return int(markID), uint(sharerID), nil
+}
+
+// Fingerprint необратимый идентификатор анонимного посетителя
+func (s signer) Fingerprint(value string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte("visitor:" + value))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:16])
+}
+
+func (s signer) sum(payload []byte) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write(payload)
+	return mac.Sum(nil)[:signatureSize]
+}
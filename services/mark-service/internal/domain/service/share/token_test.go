@@ -0,0 +1,76 @@
+package share
+
+import (
+	"encoding/base64"
+	"testing"
+)
+
+func TestSignerSignParse(t *testing.T) {
+	s := newSigner("secret")
+
+	tests := []struct {
+		name     string
+		markID   int
+		sharerID uint
+	}{
+		{"маленькие id", 1, 2},
+		{"большие id", 1 << 40, 1<<32 + 7},
+		{"без поделившегося", 15, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			markID, sharerID, err := s.Parse(s.Sign(tt.markID, tt.sharerID))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if markID != tt.markID || sharerID != tt.sharerID {
+				t.Errorf("Parse() = (%d, %d), want (%d, %d)", markID, sharerID, tt.markID, tt.sharerID)
+			}
+		})
+	}
+}
+
+func TestSignerParseInvalid(t *testing.T) {
+	s := newSigner("secret")
+	valid := s.Sign(42, 7)
+
+	tampered, _ := base64.RawURLEncoding.DecodeString(valid)
+	tampered[0]++
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"пустой токен", ""},
+		{"не base64", "!!!"},
+		{"только подпись", base64.RawURLEncoding.EncodeToString(make([]byte, signatureSize))},
+		{"измененная метка", base64.RawURLEncoding.EncodeToString(tampered)},
+		{"обрезанная подпись", valid[:len(valid)-2]},
+		{"другой секрет", newSigner("other").Sign(42, 7)},
+		{"нулевая метка", s.Sign(0, 7)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := s.Parse(tt.token); err == nil {
+				t.Errorf("Parse(%q) error = nil, want error", tt.token)
+			}
+		})
+	}
+}
+
+func TestSignerFingerprint(t *testing.T) {
+	s := newSigner("secret")
+	visitor := "10.0.0.1"
+
+	if s.Fingerprint(visitor) != s.Fingerprint(visitor) {
+		t.Error("Fingerprint() is not stable")
+	}
+	if s.Fingerprint(visitor) == s.Fingerprint("10.0.0.2") {
+		t.Error("Fingerprint() collides for different visitors")
+	}
+	if s.Fingerprint(visitor) == newSigner("other").Fingerprint(visitor) {
+		t.Error("Fingerprint() does not depend on secret")
+	}
+}
@@ -57,6 +57,56 @@ func (s *MarkStatsService) GetPopularUserCategories(ctx context.Context, userID
 
 }
 
+// GetEngagement лайки и переходы по ссылкам, полученные метками пользователя, в сравнении с предыдущим периодом
+func (s *MarkStatsService) GetEngagement(ctx context.Context, userID uint, period model.StatPeriod) (model.PeriodCount, model.PeriodCount, error) {
+	s.logger.Info("start MarkStatService.GetEngagement")
+
+	likes, err := s.statsRepo.GetReceivedLikes(ctx, userID, period)
+	if err != nil {
+		s.logger.Error("GetEngagement likes", zap.Error(err))
+		return model.PeriodCount{}, model.PeriodCount{}, err
+	}
+	shares, err := s.statsRepo.GetReceivedShares(ctx, userID, period)
+	if err != nil {
+		s.logger.Error("GetEngagement shares", zap.Error(err))
+		return model.PeriodCount{}, model.PeriodCount{}, err
+	}
+	return likes, shares, nil
+}
+
+// GetCategoryMonthlySeries помесячные ряды topN популярных категорий года, остальные объединяются в Other
+func (s *MarkStatsService) GetCategoryMonthlySeries(ctx context.Context, userID uint, year, topN int) ([]model.CategoryMonthlySeries, error) {
+	s.logger.Info("start MarkStatService.GetCategoryMonthlySeries")
+
+	series, err := s.statsRepo.GetCategoryMonthly(ctx, userID, year)
+	if err != nil {
+		s.logger.Error("GetCategoryMonthlySeries", zap.Error(err))
+		return nil, err
+	}
+	return buildCategorySeries(series, topN), nil
+}
+
+// buildCategorySeries оставляет topN рядов и суммирует остальные по месяцам. topN <= 0 - без ограничения
+func buildCategorySeries(data []model.CategoryMonthlySeries, topN int) []model.CategoryMonthlySeries {
+	if topN <= 0 || len(data) <= topN {
+		return data
+	}
+
+	other := model.CategoryMonthlySeries{
+		CategoryName: OtherCategoryName,
+		Months:       make([]model.MonthlyActivity, len(data[topN].Months)),
+	}
+	copy(other.Months, data[topN].Months)
+	other.Total = data[topN].Total
+	for _, series := range data[topN+1:] {
+		for i, month := range series.Months {
+			other.Months[i].Count += month.Count
+		}
+		other.Total += series.Total
+	}
+	return append(data[:topN:topN], other)
+}
+
 func buildCategoryStats(data []model.CategoryStat, topN int) []model.CategoryStat {
 	var total int64
 
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/database/txmanager"
+	helper "github.com/RealTimeMap/RealTimeMap-backend/pkg/helpers/context"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/pagination"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/storage"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/transport/kafka/producer"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/domainerrors"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/model"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/repository"
+	"go.uber.org/zap"
+)
+
+const defaultPurgeBatch = 100
+
+// systemActor автор записей в истории для фоновых операций
+var systemActor = helper.NewUserInput(0, "system", true)
+
+// TrashService корзина удаленных меток: просмотр, восстановление и окончательная очистка
+type TrashService struct {
+	markRepo repository.MarkRepository
+	shared   *markShared
+
+	gracePeriod time.Duration // Сколько удаленная метка доступна для восстановления
+	purgeBatch  int
+
+	logger *zap.Logger
+}
+
+func NewTrashService(markRepo repository.MarkRepository,
+	store storage.Storage,
+	producer *producer.Producer,
+	historyRepo repository.MarkHistoryRepository,
+	tx txmanager.TxManager,
+	gracePeriod time.Duration,
+	purgeBatch int,
+	logger *zap.Logger) *TrashService {
+	if purgeBatch <= 0 {
+		purgeBatch = defaultPurgeBatch
+	}
+	return &TrashService{
+		markRepo:    markRepo,
+		shared:      newMarkShared(store, producer, historyRepo, tx),
+		gracePeriod: gracePeriod,
+		purgeBatch:  purgeBatch,
+		logger:      logger,
+	}
+}
+
+// GracePeriod срок, в течение которого метку можно восстановить
+func (s *TrashService) GracePeriod() time.Duration {
+	return s.gracePeriod
+}
+
+// GetTrash удаленные метки пользователя, которые еще можно восстановить
+func (s *TrashService) GetTrash(ctx context.Context, userID int, params pagination.Params) ([]*model.Mark, int64, error) {
+	return s.markRepo.GetDeleted(ctx, userID, time.Now().Add(-s.gracePeriod), params)
+}
+
+// Restore восстанавливает удаленную метку владельца и оповещает об ее появлении
+func (s *TrashService) Restore(ctx context.Context, id int, user helper.UserInput) (*model.Mark, error) {
+	mark, err := s.markRepo.GetDeletedByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if mark.UserID != user.UserID {
+		return nil, domainerrors.ErrPermissionDenied()
+	}
+	if time.Since(mark.DeletedAt.Time) > s.gracePeriod {
+		return nil, domainerrors.ErrRestorePeriodExpired(id)
+	}
+	// Метки, удаленные модератором, владелец восстановить не может
+	last, _, err := s.shared.historyRepo.GetByMark(ctx, id, pagination.Params{Page: 1, PageSize: 1})
+	if err != nil {
+		return nil, err
+	}
+	if len(last) > 0 && last[0].Action == model.MarkActionDeleted && last[0].ActorIsAdmin {
+		return nil, domainerrors.ErrPermissionDenied()
+	}
+
+	prev := model.NewMarkSnapshot(mark)
+	err = s.shared.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.markRepo.Restore(ctx, id); err != nil {
+			return err
+		}
+		return s.shared.recordHistory(ctx, model.MarkActionRestored, user, "", &prev, mark)
+	})
+	if err != nil {
+		return nil, err
+	}
+	mark.DeletedAt.Valid = false
+
+	// Для клиентов восстановленная метка выглядит как новая
+	go s.shared.sendCreateEvent(context.Background(), mark)
+
+	return mark, nil
+}
+
+// Purge окончательно удаляет метки, срок восстановления которых истек, вместе с их фотографиями.
+// Возвращает количество удаленных меток
+func (s *TrashService) Purge(ctx context.Context) (int, error) {
+	before := time.Now().Add(-s.gracePeriod)
+	purged := 0
+
+	for {
+		marks, err := s.markRepo.GetExpiredDeleted(ctx, before, s.purgeBatch)
+		if err != nil {
+			return purged, err
+		}
+		if len(marks) == 0 {
+			return purged, nil
+		}
+
+		ids := make([]int, len(marks))
+		var keys []string
+		for i, mark := range marks {
+			ids[i] = mark.ID
+			for _, photo := range mark.Photos {
+				if photo.StorageKey != "" {
+					keys = append(keys, photo.StorageKey)
+				}
+			}
+		}
+
+		err = s.shared.tx.WithTx(ctx, func(ctx context.Context) error {
+			if err := s.markRepo.HardDelete(ctx, ids); err != nil {
+				return err
+			}
+			for _, mark := range marks {
+				prev := model.NewMarkSnapshot(mark)
+				if err := s.shared.recordHistory(ctx, model.MarkActionPurged, systemActor, "", &prev, mark); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return purged, err
+		}
+		purged += len(marks)
+
+		// Файлы удаляем только после фиксации транзакции
+		if len(keys) > 0 {
+			if err := s.shared.store.DeleteMultiple(ctx, keys); err != nil {
+				s.logger.Warn("failed to delete purged mark photos", zap.Int("count", len(keys)), zap.Error(err))
+			}
+		}
+
+		if len(marks) < s.purgeBatch {
+			return purged, nil
+		}
+	}
+}
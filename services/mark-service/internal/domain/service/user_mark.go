@@ -8,10 +8,12 @@ import (
 	_ "image/png"
 	"time"
 
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/database/txmanager"
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/mediavalidator"
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/pagination"
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/transport/kafka/producer"
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/utils"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/service/area"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/service/input"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/infrastructure/grpc/profile"
 	_ "golang.org/x/image/webp"
@@ -26,10 +28,18 @@ import (
 )
 
 const (
-	maxPhotosPerMark     = 10  // Максимум 10 фото
-	maxStartAtPastDays   = 1   // Не более 1 дня назад
-	maxStartAtFutureDays = 30  // Не более 30 дней вперед
-	maxMarksPerDay       = 100 // Лимит на создание меток для пользователя TODO уменьшить для production версии
+	maxPhotosPerMark     = 10   // Максимум 10 фото
+	maxPhotoDistance     = 1000 // Фото, снятые дальше 1 км от метки, помечаются
+	maxStartAtPastDays   = 1    // Не более 1 дня назад
+	maxStartAtFutureDays = 30   // Не более 30 дней вперед
+	maxMarksPerDay       = 100  // Лимит на создание меток для пользователя TODO уменьшить для production версии
+
+	minTimelineStep    = time.Minute * 5 // Минимальный шаг гистограммы
+	maxTimelineBuckets = 500             // Максимум интервалов в одной гистограмме
+
+	maxRouteBuffer     = 5000 // Максимальная половина ширины коридора вдоль маршрута в метрах
+	defaultRouteLimit  = 100
+	maxRouteMarksLimit = 500
 )
 
 type UserMarkService struct {
@@ -38,6 +48,8 @@ type UserMarkService struct {
 	mediaValidator *mediavalidator.PhotoValidator
 	shared         *markShared
 	profileAdapter *profile.Adapter
+	rsvpRepo       repository.RSVPRepository
+	areaService    *area.Service
 }
 
 func NewUserMarkService(markRepo repository.MarkRepository,
@@ -45,13 +57,19 @@ func NewUserMarkService(markRepo repository.MarkRepository,
 	store storage.Storage,
 	producer *producer.Producer,
 	validator *mediavalidator.PhotoValidator,
-	profileAdapter *profile.Adapter) *UserMarkService {
+	profileAdapter *profile.Adapter,
+	rsvpRepo repository.RSVPRepository,
+	historyRepo repository.MarkHistoryRepository,
+	tx txmanager.TxManager,
+	areaService *area.Service) *UserMarkService {
 	return &UserMarkService{
 		markRepo:       markRepo,
 		categoryRepo:   categoryRepo,
 		mediaValidator: validator,
-		shared:         newMarkShared(store, producer),
+		shared:         newMarkShared(store, producer, historyRepo, tx),
 		profileAdapter: profileAdapter,
+		rsvpRepo:       rsvpRepo,
+		areaService:    areaService,
 	}
 }
 
@@ -67,7 +85,7 @@ func (s *UserMarkService) CreateMark(ctx context.Context, input input.MarkInput)
 	// 2. Загрузка фото в storage (если есть)
 	var photos types.Photos
 	if len(input.Photos) > 0 {
-		uploadedPhotos, err := s.shared.uploadPhotos(ctx, input.Photos)
+		uploadedPhotos, err := s.shared.uploadPhotos(ctx, input.Photos, input.Geom)
 		if err != nil {
 			return nil, domainerrors.ErrStorageOperation("upload photos", err)
 		}
@@ -91,14 +109,23 @@ func (s *UserMarkService) CreateMark(ctx context.Context, input input.MarkInput)
 		payload.DefaultEndAt()
 	}
 
-	// 3. Создание метки
-	mark, err := s.markRepo.Create(ctx, payload)
+	// 3. Создание метки вместе с первой версией в истории
+	var mark *model.Mark
+	err := s.shared.tx.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		mark, err = s.markRepo.Create(ctx, payload)
+		if err != nil {
+			return err
+		}
+		return s.shared.recordHistory(ctx, model.MarkActionCreated, input.UserInput, "", nil, mark)
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	// Асинхронная отправка события в Kafka (не блокируем ответ клиенту)
 	go s.shared.sendCreateEvent(context.Background(), mark)
+	go s.areaService.NotifyMarkCreated(context.Background(), mark)
 
 	return mark, nil
 }
@@ -113,6 +140,26 @@ func (s *UserMarkService) GetMarksInArea(ctx context.Context, filter repository.
 
 }
 
+// GetMarkIDsInArea id меток в области, для сравнения с уже отправленными клиенту
+func (s *UserMarkService) GetMarkIDsInArea(ctx context.Context, filter repository.Filter) ([]int, error) {
+	return s.markRepo.GetMarkIDsInArea(ctx, filter)
+}
+
+// GetMarksByIDs метки по списку id, скрытые модератором пропускаются
+func (s *UserMarkService) GetMarksByIDs(ctx context.Context, ids []int) ([]*model.Mark, error) {
+	marks, err := s.markRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	visible := marks[:0]
+	for _, mark := range marks {
+		if !mark.IsHidden {
+			visible = append(visible, mark)
+		}
+	}
+	return visible, nil
+}
+
 // GetMarksInCluster получение сгруппированных меток по кластерам для отображения при большой области карты
 func (s *UserMarkService) GetMarksInCluster(ctx context.Context, filter repository.Filter) ([]*model.Cluster, error) {
 	clusters, err := s.markRepo.GetMarksInCluster(ctx, filter)
@@ -122,6 +169,39 @@ func (s *UserMarkService) GetMarksInCluster(ctx context.Context, filter reposito
 	return clusters, nil
 }
 
+// GetTimeline гистограмма количества активных меток в области по интервалам времени
+func (s *UserMarkService) GetTimeline(ctx context.Context, filter repository.TimelineFilter) ([]model.TimelineBucket, error) {
+	if !filter.To.After(filter.From) {
+		return nil, domainerrors.ErrTimelineRange()
+	}
+	if filter.Step < minTimelineStep {
+		return nil, domainerrors.ErrTimelineStep(int(minTimelineStep.Minutes()))
+	}
+	// Последний неполный интервал дополняется до целого шага
+	buckets := int((filter.To.Sub(filter.From) + filter.Step - 1) / filter.Step)
+	if buckets > maxTimelineBuckets {
+		return nil, domainerrors.ErrTimelineTooManyBuckets(buckets, maxTimelineBuckets)
+	}
+	filter.To = filter.From.Add(time.Duration(buckets) * filter.Step)
+
+	return s.markRepo.GetTimeline(ctx, filter)
+}
+
+// GetMarksAlongRoute активные метки в коридоре вдоль маршрута в порядке следования по нему
+func (s *UserMarkService) GetMarksAlongRoute(ctx context.Context, filter repository.RouteFilter) ([]*model.RouteMark, error) {
+	if filter.Buffer < 1 || filter.Buffer > maxRouteBuffer {
+		return nil, domainerrors.ErrInvalidRouteBuffer(maxRouteBuffer)
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultRouteLimit
+	}
+	filter.Limit = min(filter.Limit, maxRouteMarksLimit)
+	if filter.At.IsZero() {
+		filter.At = time.Now()
+	}
+	return s.markRepo.GetMarksAlongRoute(ctx, filter)
+}
+
 // DeleteMark удаление метки
 func (s *UserMarkService) DeleteMark(ctx context.Context, id int, user helper.UserInput) error {
 	mark, err := s.markRepo.GetByID(ctx, id)
@@ -132,10 +212,13 @@ func (s *UserMarkService) DeleteMark(ctx context.Context, id int, user helper.Us
 		return domainerrors.ErrPermissionDenied()
 	}
 
-	if err := s.markRepo.Delete(ctx, id); err != nil {
-		return err
-	}
-	return nil
+	prev := model.NewMarkSnapshot(mark)
+	return s.shared.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.markRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return s.shared.recordHistory(ctx, model.MarkActionDeleted, user, "", &prev, mark)
+	})
 }
 
 // UpdateMark частичное обновление метки
@@ -150,17 +233,26 @@ func (s *UserMarkService) UpdateMark(ctx context.Context, input input.MarkUpdate
 	}
 
 	// 2. Обработка фотографий (добавление новых + удаление старых)
-	updatedPhotos, err := s.shared.updatePhotos(ctx, mark.Photos, input.Photos, input.PhotosToDelete, maxPhotosPerMark)
+	updatedPhotos, err := s.shared.updatePhotos(ctx, mark.Geom, mark.Photos, input.Photos, input.PhotosToDelete, maxPhotosPerMark)
 	if err != nil {
 		return nil, err
 	}
 
 	// 3. Применение обновлений
+	prev := model.NewMarkSnapshot(mark)
 	s.applyUpdates(mark, input)
 	mark.Photos = updatedPhotos
 
-	// 4. Сохранение в БД
-	newMark, err := s.markRepo.Update(ctx, input.MarkID, mark)
+	// 4. Сохранение в БД вместе с записью в историю
+	var newMark *model.Mark
+	err = s.shared.tx.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		newMark, err = s.markRepo.Update(ctx, input.MarkID, mark)
+		if err != nil {
+			return err
+		}
+		return s.shared.recordHistory(ctx, model.MarkActionUpdated, input.UserInput, "", &prev, newMark)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -173,7 +265,12 @@ func (s *UserMarkService) DetailMark(ctx context.Context, id int) (*model.Mark,
 	if err != nil {
 		return nil, err
 	}
+	// Скрытые модератором метки недоступны для просмотра
+	if mark.IsHidden {
+		return nil, domainerrors.ErrMarkNotFound(id)
+	}
 	s.attachOwners(ctx, []*model.Mark{mark})
+	s.attachRSVP(ctx, mark)
 	return mark, nil
 }
 
@@ -296,6 +393,16 @@ func (s *UserMarkService) attachOwners(ctx context.Context, marks []*model.Mark)
 	}
 }
 
+// attachRSVP добавляет сводку ответов, при ошибке детальный просмотр отдается без нее
+func (s *UserMarkService) attachRSVP(ctx context.Context, mark *model.Mark) {
+	summary, err := s.rsvpRepo.GetSummary(ctx, mark.ID)
+	if err != nil {
+		return
+	}
+	summary.Capacity = mark.Capacity
+	mark.RSVP = summary
+}
+
 func localFallback(mark *model.Mark) *model.UserProfile {
 	return &model.UserProfile{
 		ID:       uint(mark.UserID),
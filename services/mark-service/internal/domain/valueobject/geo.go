@@ -1,6 +1,11 @@
 package valueobject
 
-import "github.com/mmcloughlin/geohash"
+import (
+	"math"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/domainerrors"
+	"github.com/mmcloughlin/geohash"
+)
 
 const GeohashPersistence = 5
 
@@ -19,23 +24,83 @@ type BoundingBox struct {
 	RightBottom Point
 }
 
+// Envelope прямоугольник, не пересекающий 180-й меридиан
+type Envelope struct {
+	MinLon, MinLat, MaxLon, MaxLat float64
+}
+
+// CrossesAntimeridian экран пересекает 180-й меридиан: левый край восточнее правого
+func (b BoundingBox) CrossesAntimeridian() bool {
+	return b.LeftTop.Lon > b.RightBottom.Lon
+}
+
+// Envelopes прямоугольники для запроса. При пересечении 180-го меридиана
+// экран делится на две части: до 180 и от -180
+func (b BoundingBox) Envelopes() []Envelope {
+	minLat, maxLat := b.RightBottom.Lat, b.LeftTop.Lat
+	if b.CrossesAntimeridian() {
+		return []Envelope{
+			{MinLon: b.LeftTop.Lon, MinLat: minLat, MaxLon: 180, MaxLat: maxLat},
+			{MinLon: -180, MinLat: minLat, MaxLon: b.RightBottom.Lon, MaxLat: maxLat},
+		}
+	}
+	return []Envelope{{MinLon: b.LeftTop.Lon, MinLat: minLat, MaxLon: b.RightBottom.Lon, MaxLat: maxLat}}
+}
+
+// LonSpan ширина экрана в градусах с учетом пересечения 180-го меридиана
+func (b BoundingBox) LonSpan() float64 {
+	if b.CrossesAntimeridian() {
+		return 360 - b.LeftTop.Lon + b.RightBottom.Lon
+	}
+	return b.RightBottom.Lon - b.LeftTop.Lon
+}
+
+// LatSpan высота экрана в градусах
+func (b BoundingBox) LatSpan() float64 {
+	return b.LeftTop.Lat - b.RightBottom.Lat
+}
+
+// BoundingBoxLimits ограничения на размер экрана в градусах. Нулевое значение - без ограничения
+type BoundingBoxLimits struct {
+	MaxLonSpan float64
+	MaxLatSpan float64
+}
+
+// Validate проверяет, что верхний край экрана не ниже нижнего и размер экрана не превышает limits
+func (b BoundingBox) Validate(limits BoundingBoxLimits) error {
+	if b.LeftTop.Lat < b.RightBottom.Lat {
+		return domainerrors.ErrInvalidBoundingBox()
+	}
+	if limits.MaxLonSpan > 0 && b.LonSpan() > limits.MaxLonSpan ||
+		limits.MaxLatSpan > 0 && b.LatSpan() > limits.MaxLatSpan {
+		return domainerrors.ErrBoundingBoxTooLarge(limits.MaxLonSpan, limits.MaxLatSpan)
+	}
+	return nil
+}
+
+// GeoHashes геохеши ячеек, покрывающих экран. Экран через 180-й меридиан покрывается по частям
 func (b BoundingBox) GeoHashes() []string {
-	minLat := b.RightBottom.Lat
-	maxLat := b.LeftTop.Lat
-	minLon := b.LeftTop.Lon
-	maxLon := b.RightBottom.Lon
+	seen := make(map[string]struct{})
+	for _, e := range b.Envelopes() {
+		e.collectGeoHashes(seen)
+	}
 
-	latCells := int((maxLat-minLat)/geoHashLatStep) + 2
-	lonCells := int((maxLon-minLon)/geoHashLonStep) + 2
-	size := latCells * lonCells
+	result := make([]string, 0, len(seen))
+	for hash := range seen {
+		result = append(result, hash)
+	}
+	return result
+}
 
-	seen := make(map[string]struct{}, size)
+func (e Envelope) collectGeoHashes(seen map[string]struct{}) {
+	minLat, maxLat := e.MinLat, e.MaxLat
+	minLon, maxLon := e.MinLon, e.MaxLon
 
 	latStep := geoHashLatStep * 0.95
 	lonStep := geoHashLonStep * 0.95
 
-	maxLat += geoHashLatStep * 0.1
-	maxLon += geoHashLonStep * 0.1
+	maxLat = math.Min(maxLat+geoHashLatStep*0.1, 90)
+	maxLon = math.Min(maxLon+geoHashLonStep*0.1, 180)
 
 	for lat := minLat; lat <= maxLat; lat += latStep {
 		for lon := minLon; lon <= maxLon; lon += lonStep {
@@ -43,10 +108,4 @@ func (b BoundingBox) GeoHashes() []string {
 			seen[hash] = struct{}{}
 		}
 	}
-
-	result := make([]string, 0, len(seen))
-	for hash := range seen {
-		result = append(result, hash)
-	}
-	return result
 }
@@ -0,0 +1,58 @@
+package valueobject
+
+import (
+	"testing"
+
+	"github.com/mmcloughlin/geohash"
+)
+
+func TestBoundingBoxValidate(t *testing.T) {
+	limits := BoundingBoxLimits{MaxLonSpan: 5, MaxLatSpan: 5}
+
+	tests := []struct {
+		name    string
+		bbox    BoundingBox
+		limits  BoundingBoxLimits
+		wantErr bool
+	}{
+		{"обычный экран", BoundingBox{Point{37, 56}, Point{38, 55}}, limits, false},
+		{"верх ниже низа", BoundingBox{Point{37, 55}, Point{38, 56}}, limits, true},
+		{"слишком широкий", BoundingBox{Point{30, 56}, Point{38, 55}}, limits, true},
+		{"слишком высокий", BoundingBox{Point{37, 60}, Point{38, 50}}, limits, true},
+		{"через 180-й меридиан", BoundingBox{Point{178, 1}, Point{-178, -1}}, limits, false},
+		{"через 180-й меридиан слишком широкий", BoundingBox{Point{170, 1}, Point{-170, -1}}, limits, true},
+		{"весь мир без ограничения", BoundingBox{Point{-180, 85}, Point{180, -85}}, BoundingBoxLimits{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.bbox.Validate(tt.limits); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBoundingBoxGeoHashesAntimeridian(t *testing.T) {
+	bbox := BoundingBox{Point{179.9, 0.1}, Point{-179.9, -0.1}}
+	hashes := bbox.GeoHashes()
+
+	// Ячейки по обе стороны меридиана, без обхода всего земного шара
+	want := map[string]bool{
+		geohash.EncodeWithPrecision(0, 179.95, GeohashPersistence):  false,
+		geohash.EncodeWithPrecision(0, -179.95, GeohashPersistence): false,
+	}
+	for _, hash := range hashes {
+		if _, ok := want[hash]; ok {
+			want[hash] = true
+		}
+	}
+	for hash, found := range want {
+		if !found {
+			t.Errorf("GeoHashes() = %v, missing %s", hashes, hash)
+		}
+	}
+	if len(hashes) > 100 {
+		t.Errorf("len(GeoHashes()) = %d, want cells near the antimeridian only", len(hashes))
+	}
+}
@@ -0,0 +1,217 @@
+package valueobject
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/domainerrors"
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/geo"
+	"github.com/paulmach/orb/planar"
+)
+
+// circleSegments количество вершин многоугольника, аппроксимирующего окружность
+const circleSegments = 32
+
+// PolygonLimits ограничения на полигон, переданный клиентом
+type PolygonLimits struct {
+	MaxVertices int
+	MaxArea     float64 // Максимальная площадь в квадратных метрах
+}
+
+// NewPolygon проверяет вершины [lon, lat] и строит замкнутый полигон.
+// Кольцо замыкается автоматически, если первая и последняя вершины не совпадают
+func NewPolygon(coords [][2]float64, limits PolygonLimits) (orb.Polygon, error) {
+	ring := make(orb.Ring, 0, len(coords)+1)
+	for _, c := range coords {
+		ring = append(ring, orb.Point{c[0], c[1]})
+	}
+	ring, err := normalizeRing(ring, orb.CCW)
+	if err != nil {
+		return nil, domainerrors.ErrInvalidPolygon(err.Error())
+	}
+	if limits.MaxVertices > 0 && len(ring)-1 > limits.MaxVertices {
+		return nil, domainerrors.ErrInvalidPolygon(fmt.Sprintf("must have at most %d vertices", limits.MaxVertices))
+	}
+
+	polygon := orb.Polygon{ring}
+	if limits.MaxArea > 0 && geo.Area(polygon) > limits.MaxArea {
+		return nil, domainerrors.ErrInvalidPolygon(fmt.Sprintf("area must not exceed %.0f km²", limits.MaxArea/1e6))
+	}
+	return polygon, nil
+}
+
+// geoJSONGeometry минимальное представление GeoJSON геометрии или Feature
+type geoJSONGeometry struct {
+	Type        string           `json:"type"`
+	Coordinates json.RawMessage  `json:"coordinates"`
+	Geometry    *geoJSONGeometry `json:"geometry"`
+}
+
+// NewArea разбирает GeoJSON Polygon или MultiPolygon (геометрию или Feature) области запроса.
+// Внешние кольца приводятся к обходу против часовой стрелки, дыры - по часовой
+func NewArea(data []byte, limits PolygonLimits) (orb.MultiPolygon, error) {
+	var geometry geoJSONGeometry
+	if err := json.Unmarshal(data, &geometry); err != nil {
+		return nil, domainerrors.ErrInvalidArea("must be a GeoJSON Polygon or MultiPolygon")
+	}
+	if geometry.Type == "Feature" && geometry.Geometry != nil {
+		geometry = *geometry.Geometry
+	}
+
+	var area orb.MultiPolygon
+	switch geometry.Type {
+	case "Polygon":
+		var polygon orb.Polygon
+		if err := json.Unmarshal(geometry.Coordinates, &polygon); err != nil {
+			return nil, domainerrors.ErrInvalidArea("invalid polygon coordinates")
+		}
+		area = orb.MultiPolygon{polygon}
+	case "MultiPolygon":
+		if err := json.Unmarshal(geometry.Coordinates, &area); err != nil {
+			return nil, domainerrors.ErrInvalidArea("invalid multipolygon coordinates")
+		}
+	default:
+		return nil, domainerrors.ErrInvalidArea("must be a GeoJSON Polygon or MultiPolygon")
+	}
+	if len(area) == 0 {
+		return nil, domainerrors.ErrInvalidArea("must not be empty")
+	}
+
+	vertices := 0
+	for i, polygon := range area {
+		if len(polygon) == 0 {
+			return nil, domainerrors.ErrInvalidArea("polygon must not be empty")
+		}
+		for j, ring := range polygon {
+			orientation := orb.CCW
+			if j > 0 {
+				orientation = orb.CW
+			}
+			normalized, err := normalizeRing(ring, orientation)
+			if err != nil {
+				return nil, domainerrors.ErrInvalidArea(err.Error())
+			}
+			area[i][j] = normalized
+			vertices += len(normalized) - 1
+		}
+		for _, hole := range polygon[1:] {
+			for _, p := range hole {
+				if !planar.RingContains(polygon[0], p) {
+					return nil, domainerrors.ErrInvalidArea("hole must lie inside the outer ring")
+				}
+			}
+		}
+	}
+	if limits.MaxVertices > 0 && vertices > limits.MaxVertices {
+		return nil, domainerrors.ErrInvalidArea(fmt.Sprintf("must have at most %d vertices", limits.MaxVertices))
+	}
+	if limits.MaxArea > 0 && geo.Area(area) > limits.MaxArea {
+		return nil, domainerrors.ErrInvalidArea(fmt.Sprintf("area must not exceed %.0f km²", limits.MaxArea/1e6))
+	}
+	return area, nil
+}
+
+// normalizeRing проверяет координаты кольца, замыкает его и задает направление обхода
+func normalizeRing(ring orb.Ring, orientation orb.Orientation) (orb.Ring, error) {
+	for _, p := range ring {
+		if p.Lon() < -180 || p.Lon() > 180 || p.Lat() < -90 || p.Lat() > 90 {
+			return nil, fmt.Errorf("vertex %v is out of range", [2]float64(p))
+		}
+	}
+	ring = removeRepeatedPoints(ring)
+	if len(ring) > 0 && !ring.Closed() {
+		ring = append(ring, ring[0])
+	}
+	if len(ring)-1 < 3 {
+		return nil, fmt.Errorf("ring must have at least 3 vertices")
+	}
+	if planar.Area(ring) == 0 {
+		return nil, fmt.Errorf("ring must not be degenerate")
+	}
+	if selfIntersects(ring) {
+		return nil, fmt.Errorf("ring must not self-intersect")
+	}
+	if ring.Orientation() != orientation {
+		ring.Reverse()
+	}
+	return ring, nil
+}
+
+// removeRepeatedPoints убирает подряд идущие одинаковые вершины, они дают отрезки нулевой длины
+func removeRepeatedPoints(ring orb.Ring) orb.Ring {
+	result := make(orb.Ring, 0, len(ring))
+	for i, p := range ring {
+		if i > 0 && p == ring[i-1] {
+			continue
+		}
+		result = append(result, p)
+	}
+	return result
+}
+
+// selfIntersects замкнутое кольцо пересекает или касается само себя (как невалидное кольцо в ST_IsValid).
+// Соседние ребра могут иметь только общую вершину, несоседние не должны иметь общих точек
+func selfIntersects(ring orb.Ring) bool {
+	n := len(ring) - 1 // количество ребер
+	for i := 0; i < n; i++ {
+		a, b := ring[i], ring[i+1]
+		for j := i + 1; j < n; j++ {
+			c, d := ring[j], ring[j+1]
+			if j == i+1 || i == 0 && j == n-1 {
+				// Соседние ребра на одной прямой перекрываются, если идут навстречу друг другу
+				collinear := cross(a, b, c) == 0 && cross(a, b, d) == 0
+				if collinear && (b[0]-a[0])*(d[0]-c[0])+(b[1]-a[1])*(d[1]-c[1]) < 0 {
+					return true
+				}
+				continue
+			}
+			if segmentsIntersect(a, b, c, d) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// segmentsIntersect отрезки ab и cd имеют хотя бы одну общую точку
+func segmentsIntersect(a, b, c, d orb.Point) bool {
+	d1, d2 := cross(c, d, a), cross(c, d, b)
+	d3, d4 := cross(a, b, c), cross(a, b, d)
+	if (d1 > 0 && d2 < 0 || d1 < 0 && d2 > 0) && (d3 > 0 && d4 < 0 || d3 < 0 && d4 > 0) {
+		return true
+	}
+	return d1 == 0 && onSegment(c, d, a) ||
+		d2 == 0 && onSegment(c, d, b) ||
+		d3 == 0 && onSegment(a, b, c) ||
+		d4 == 0 && onSegment(a, b, d)
+}
+
+// cross знак показывает, с какой стороны от прямой ab лежит точка p
+func cross(a, b, p orb.Point) float64 {
+	return (b[0]-a[0])*(p[1]-a[1]) - (b[1]-a[1])*(p[0]-a[0])
+}
+
+// onSegment точка p, лежащая на прямой ab, попадает на отрезок ab
+func onSegment(a, b, p orb.Point) bool {
+	return math.Min(a[0], b[0]) <= p[0] && p[0] <= math.Max(a[0], b[0]) &&
+		math.Min(a[1], b[1]) <= p[1] && p[1] <= math.Max(a[1], b[1])
+}
+
+// NewCircle многоугольник, описанный вокруг окружности с центром center и радиусом radius (в метрах)
+func NewCircle(center orb.Point, radius float64) orb.Polygon {
+	// Описанный многоугольник гарантирует, что вся окружность попадает внутрь
+	outer := radius / math.Cos(math.Pi/circleSegments)
+
+	ring := make(orb.Ring, 0, circleSegments+1)
+	for i := 0; i < circleSegments; i++ {
+		bearing := 360.0 * float64(i) / circleSegments
+		ring = append(ring, geo.PointAtBearingAndDistance(center, bearing, outer))
+	}
+	ring = append(ring, ring[0])
+	if ring.Orientation() == orb.CW {
+		ring.Reverse()
+	}
+	return orb.Polygon{ring}
+}
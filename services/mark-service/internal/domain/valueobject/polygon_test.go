@@ -0,0 +1,38 @@
+package valueobject
+
+import "testing"
+
+func TestNewArea(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{"квадрат", `{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,1],[0,0]]]}`, false},
+		{"незамкнутое кольцо", `{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,1]]]}`, false},
+		{"повторная вершина", `{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,0],[1,1],[0,1],[0,0]]]}`, false},
+		{"вершина на стороне", `{"type":"Polygon","coordinates":[[[0,0],[0.5,0],[1,0],[1,1],[0,1],[0,0]]]}`, false},
+		{"бабочка", `{"type":"Polygon","coordinates":[[[0,0],[1,1],[1,0],[0,1],[0,0]]]}`, true},
+		{"касание самого себя", `{"type":"Polygon","coordinates":[[[0,0],[2,0],[1,1],[2,2],[0,2],[1,1],[0,0]]]}`, true},
+		{"шип назад", `{"type":"Polygon","coordinates":[[[0,0],[2,0],[1,0],[1,1],[0,1],[0,0]]]}`, true},
+		{"дыра внутри", `{"type":"Polygon","coordinates":[
+			[[0,0],[4,0],[4,4],[0,4],[0,0]],
+			[[1,1],[2,1],[2,2],[1,2],[1,1]]
+		]}`, false},
+		{"дыра снаружи", `{"type":"Polygon","coordinates":[
+			[[0,0],[4,0],[4,4],[0,4],[0,0]],
+			[[5,5],[6,5],[6,6],[5,6],[5,5]]
+		]}`, true},
+		{"Feature", `{"type":"Feature","geometry":{"type":"MultiPolygon","coordinates":[[[[0,0],[1,0],[1,1],[0,0]]]]}}`, false},
+		{"точка", `{"type":"Point","coordinates":[0,0]}`, true},
+		{"вершина вне диапазона", `{"type":"Polygon","coordinates":[[[0,0],[181,0],[1,1],[0,0]]]}`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewArea([]byte(tt.data), PolygonLimits{}); (err != nil) != tt.wantErr {
+				t.Errorf("NewArea() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package valueobject
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/domainerrors"
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/geo"
+)
+
+// polylinePrecision множитель координат в encoded polyline (5 знаков после запятой)
+const polylinePrecision = 1e5
+
+// RouteLimits ограничения на маршрут, переданный клиентом
+type RouteLimits struct {
+	MaxVertices int
+	MaxLength   float64 // Максимальная длина в метрах
+}
+
+// NewRoute разбирает GeoJSON LineString (геометрию или Feature)
+func NewRoute(data []byte, limits RouteLimits) (orb.LineString, error) {
+	var geometry geoJSONGeometry
+	if err := json.Unmarshal(data, &geometry); err != nil {
+		return nil, domainerrors.ErrInvalidRoute("must be a GeoJSON LineString")
+	}
+	if geometry.Type == "Feature" && geometry.Geometry != nil {
+		geometry = *geometry.Geometry
+	}
+	if geometry.Type != "LineString" {
+		return nil, domainerrors.ErrInvalidRoute("must be a GeoJSON LineString")
+	}
+
+	var line orb.LineString
+	if err := json.Unmarshal(geometry.Coordinates, &line); err != nil {
+		return nil, domainerrors.ErrInvalidRoute("invalid linestring coordinates")
+	}
+	return validateRoute(line, limits)
+}
+
+// DecodePolyline разбирает маршрут в формате Google encoded polyline с точностью 5 знаков
+func DecodePolyline(encoded string, limits RouteLimits) (orb.LineString, error) {
+	var (
+		line     orb.LineString
+		lat, lon int
+	)
+	for i := 0; i < len(encoded); {
+		var deltas [2]int
+		for j := range deltas {
+			value, next, ok := decodePolylineValue(encoded, i)
+			if !ok {
+				return nil, domainerrors.ErrInvalidRoute("malformed encoded polyline")
+			}
+			deltas[j], i = value, next
+		}
+		lat += deltas[0]
+		lon += deltas[1]
+		line = append(line, orb.Point{float64(lon) / polylinePrecision, float64(lat) / polylinePrecision})
+	}
+	return validateRoute(line, limits)
+}
+
+// decodePolylineValue читает одно число начиная с позиции i и возвращает позицию следующего
+func decodePolylineValue(encoded string, i int) (int, int, bool) {
+	var result, shift int
+	for {
+		if i >= len(encoded) || shift > 30 {
+			return 0, 0, false
+		}
+		b := int(encoded[i]) - 63
+		i++
+		if b < 0 || b > 63 {
+			return 0, 0, false
+		}
+		result |= (b & 0x1f) << shift
+		shift += 5
+		if b < 0x20 {
+			break
+		}
+	}
+	if result&1 != 0 {
+		return ^(result >> 1), i, true
+	}
+	return result >> 1, i, true
+}
+
+// validateRoute проверяет координаты, количество вершин и длину маршрута.
+// Подряд идущие одинаковые точки схлопываются
+func validateRoute(line orb.LineString, limits RouteLimits) (orb.LineString, error) {
+	route := make(orb.LineString, 0, len(line))
+	for _, p := range line {
+		if p.Lon() < -180 || p.Lon() > 180 || p.Lat() < -90 || p.Lat() > 90 {
+			return nil, domainerrors.ErrInvalidRoute(fmt.Sprintf("vertex %v is out of range", [2]float64(p)))
+		}
+		if len(route) > 0 && route[len(route)-1].Equal(p) {
+			continue
+		}
+		route = append(route, p)
+	}
+	if len(route) < 2 {
+		return nil, domainerrors.ErrInvalidRoute("must have at least 2 distinct vertices")
+	}
+	if limits.MaxVertices > 0 && len(route) > limits.MaxVertices {
+		return nil, domainerrors.ErrInvalidRoute(fmt.Sprintf("must have at most %d vertices", limits.MaxVertices))
+	}
+	if limits.MaxLength > 0 && geo.Length(route) > limits.MaxLength {
+		return nil, domainerrors.ErrInvalidRoute(fmt.Sprintf("length must not exceed %.0f km", limits.MaxLength/1e3))
+	}
+	return route, nil
+}
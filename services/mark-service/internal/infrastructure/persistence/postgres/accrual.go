@@ -25,21 +25,6 @@ func NewPgAccrualRepository(db *gorm.DB, logger *zap.Logger) repository.AccrualR
 	}
 }
 
-func (r *PgAccrualRepository) IncShare(ctx context.Context, markID uint) (int64, error) {
-	var mark model.Mark
-
-	err := r.db.WithContext(ctx).
-		Model(&mark).
-		Clauses(clause.Returning{Columns: []clause.Column{{Name: "shared_count"}}}).
-		Where("id = ?", markID).
-		Update("shared_count", gorm.Expr("shared_count + 1")).Error
-	if err != nil {
-		return 0, err
-	}
-
-	return mark.SharedCount, nil
-}
-
 func (r *PgAccrualRepository) UnLike(ctx context.Context, markID, userID uint) error {
 	//TODO implement me
 	panic("implement me")
@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/logger/sl"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/types"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/domainerrors"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/model"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/repository"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type SavedAreaRepository struct {
+	db    *gorm.DB
+	log   *zap.Logger
+	layer string
+}
+
+func NewSavedAreaRepository(db *gorm.DB, logger *zap.Logger) repository.SavedAreaRepository {
+	return &SavedAreaRepository{
+		db:    db,
+		log:   logger,
+		layer: "saved_area_repository",
+	}
+}
+
+func (r *SavedAreaRepository) Create(ctx context.Context, area *model.SavedArea) error {
+	if err := r.db.WithContext(ctx).Create(area).Error; err != nil {
+		r.log.Error("create_saved_area err: ", sl.String("layer", r.layer), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *SavedAreaRepository) GetByUser(ctx context.Context, userID int) ([]*model.SavedArea, error) {
+	var areas []*model.SavedArea
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&areas).Error
+	if err != nil {
+		r.log.Error("get_saved_areas err: ", sl.String("layer", r.layer), zap.Error(err))
+		return nil, err
+	}
+	return areas, nil
+}
+
+func (r *SavedAreaRepository) GetByID(ctx context.Context, id uint) (*model.SavedArea, error) {
+	var area model.SavedArea
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&area).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainerrors.ErrAreaNotFound(id)
+		}
+		r.log.Error("get_saved_area err: ", sl.String("layer", r.layer), zap.Error(err))
+		return nil, err
+	}
+	return &area, nil
+}
+
+func (r *SavedAreaRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&model.SavedArea{}).Error
+}
+
+func (r *SavedAreaRepository) CountByUser(ctx context.Context, userID int) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&model.SavedArea{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+func (r *SavedAreaRepository) MatchPoint(ctx context.Context, point types.Point, categoryID int) ([]*model.SavedArea, error) {
+	var areas []*model.SavedArea
+	// geom && point отбирает кандидатов по GiST индексу, окружности затем проверяются точно по радиусу
+	err := r.db.WithContext(ctx).
+		Where("geom && ?::geometry", point).
+		Where(`(kind = ? AND ST_Intersects(geom, ?::geometry))
+            OR (kind = ? AND ST_DWithin(center::geography, ?::geography, radius))`,
+			model.AreaKindPolygon, point, model.AreaKindCircle, point).
+		Where("category_ids IS NULL OR category_ids @> jsonb_build_array(?::int)", categoryID).
+		Order("user_id, id").
+		Find(&areas).Error
+	if err != nil {
+		r.log.Error("match_saved_areas err: ", sl.String("layer", r.layer), zap.Error(err))
+		return nil, err
+	}
+	return areas, nil
+}
@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/logger/sl"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/pagination"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/types"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/domainerrors"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/model"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/repository"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CheckInRepository struct {
+	db    *gorm.DB
+	log   *zap.Logger
+	layer string
+}
+
+func NewCheckInRepository(db *gorm.DB, logger *zap.Logger) repository.CheckInRepository {
+	return &CheckInRepository{
+		db:    db,
+		log:   logger,
+		layer: "checkin_repository",
+	}
+}
+
+func (r *CheckInRepository) Create(ctx context.Context, checkIn *model.MarkCheckIn) (*model.MarkCheckIn, int64, error) {
+	r.log.Info("create_checkin in: ", sl.String("layer", r.layer), sl.Int("mark_id", checkIn.MarkID))
+
+	var mark model.Mark
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(checkIn).Error; err != nil {
+			return err
+		}
+		return tx.Model(&mark).
+			Clauses(clause.Returning{Columns: []clause.Column{{Name: "check_in_count"}}}).
+			Where("id = ?", checkIn.MarkID).
+			Update("check_in_count", gorm.Expr("check_in_count + 1")).Error
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, 0, domainerrors.ErrCheckInAlreadyExists(checkIn.MarkID)
+		}
+		r.log.Error("create_checkin err: ", sl.String("layer", r.layer), zap.Error(err))
+		return nil, 0, err
+	}
+	return checkIn, mark.CheckInCount, nil
+}
+
+func (r *CheckInRepository) DistanceTo(ctx context.Context, markID int, point types.Point) (float64, error) {
+	var distance float64
+	err := r.db.WithContext(ctx).Model(&model.Mark{}).
+		Select("ST_Distance(geom::geography, ST_SetSRID(ST_MakePoint(?, ?), 4326)::geography)", point.Lon(), point.Lat()).
+		Where("id = ? AND deleted_at IS NULL", markID).
+		Take(&distance).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, domainerrors.ErrMarkNotFound(markID)
+		}
+		r.log.Error("checkin_distance err: ", sl.String("layer", r.layer), zap.Error(err))
+		return 0, err
+	}
+	return distance, nil
+}
+
+func (r *CheckInRepository) GetByMark(ctx context.Context, markID int, params pagination.Params) ([]*model.MarkCheckIn, int64, error) {
+	var checkIns []*model.MarkCheckIn
+	var count int64
+
+	query := r.db.WithContext(ctx).Model(&model.MarkCheckIn{}).Where("mark_id = ?", markID).Session(&gorm.Session{})
+	if err := query.Count(&count).Error; err != nil {
+		r.log.Error("get_checkins_count err: ", sl.String("layer", r.layer), zap.Error(err))
+		return nil, 0, err
+	}
+
+	err := query.
+		Order("created_at DESC").
+		Limit(params.Limit()).
+		Offset(params.Offset()).
+		Find(&checkIns).Error
+	if err != nil {
+		r.log.Error("get_checkins err: ", sl.String("layer", r.layer), zap.Error(err))
+		return nil, 0, err
+	}
+	return checkIns, count, nil
+}
@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/database/txmanager"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/logger/sl"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/pagination"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/domainerrors"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/model"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/repository"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MarkHistoryRepository struct {
+	db    *gorm.DB
+	log   *zap.Logger
+	layer string
+}
+
+func NewMarkHistoryRepository(db *gorm.DB, logger *zap.Logger) repository.MarkHistoryRepository {
+	return &MarkHistoryRepository{
+		db:    db,
+		log:   logger,
+		layer: "mark_history_repository",
+	}
+}
+
+func (r *MarkHistoryRepository) Create(ctx context.Context, entry *model.MarkHistory) error {
+	db := txmanager.DBFromCtx(ctx, r.db)
+
+	// Блокируем строку метки до конца транзакции, чтобы параллельные изменения
+	// одной метки не получили одинаковый номер версии
+	var lockedID int
+	err := db.Unscoped().Model(&model.Mark{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		Where("id = ?", entry.MarkID).
+		Scan(&lockedID).Error
+	if err != nil {
+		r.log.Error("lock_mark_history err: ", sl.String("layer", r.layer), zap.Error(err))
+		return err
+	}
+
+	var version int
+	err = db.Model(&model.MarkHistory{}).
+		Select("COALESCE(MAX(version), 0) + 1").
+		Where("mark_id = ?", entry.MarkID).
+		Scan(&version).Error
+	if err != nil {
+		r.log.Error("next_history_version err: ", sl.String("layer", r.layer), zap.Error(err))
+		return err
+	}
+
+	entry.Version = version
+	if err := db.Create(entry).Error; err != nil {
+		r.log.Error("create_history err: ", sl.String("layer", r.layer), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *MarkHistoryRepository) GetByMark(ctx context.Context, markID int, params pagination.Params) ([]*model.MarkHistory, int64, error) {
+	var entries []*model.MarkHistory
+	var count int64
+
+	query := r.db.WithContext(ctx).Model(&model.MarkHistory{}).Where("mark_id = ?", markID).Session(&gorm.Session{})
+	if err := query.Count(&count).Error; err != nil {
+		r.log.Error("get_history_count err: ", sl.String("layer", r.layer), zap.Error(err))
+		return nil, 0, err
+	}
+
+	err := query.
+		Order("version DESC").
+		Limit(params.Limit()).
+		Offset(params.Offset()).
+		Find(&entries).Error
+	if err != nil {
+		r.log.Error("get_history err: ", sl.String("layer", r.layer), zap.Error(err))
+		return nil, 0, err
+	}
+	return entries, count, nil
+}
+
+func (r *MarkHistoryRepository) GetVersion(ctx context.Context, markID, version int) (*model.MarkHistory, error) {
+	var entry model.MarkHistory
+	err := r.db.WithContext(ctx).
+		Where("mark_id = ? AND version = ?", markID, version).
+		First(&entry).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainerrors.ErrMarkVersionNotFound(version)
+		}
+		r.log.Error("get_history_version err: ", sl.String("layer", r.layer), zap.Error(err))
+		return nil, err
+	}
+	return &entry, nil
+}
@@ -4,16 +4,23 @@ import (
 	"context"
 	"errors"
 	"math"
+	"strings"
+	"time"
 
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/database/txmanager"
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/logger/sl"
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/pagination"
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/types"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/domainerrors"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/model"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/repository"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/valueobject"
 	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/encoding/wkt"
+	"github.com/paulmach/orb/geo"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 const clusterPixelThreshold = 60.0
@@ -35,15 +42,17 @@ func NewMarkRepository(db *gorm.DB, logger *zap.Logger) repository.MarkRepositor
 func (r *MarkRepository) Create(ctx context.Context, data *model.Mark) (*model.Mark, error) {
 	r.log.Info("create mark in: ", sl.String("layer", r.layer))
 
+	db := txmanager.DBFromCtx(ctx, r.db)
+
 	// Создаем запись
-	err := r.db.WithContext(ctx).Create(data).Error
+	err := db.Create(data).Error
 	if err != nil {
 		r.log.Error("create mark err: ", sl.String("layer", r.layer), zap.Error(err))
 		return nil, err
 	}
 
 	// Загружаем связанную Category для возврата полного объекта
-	err = r.db.WithContext(ctx).Preload("Category").First(data, data.ID).Error
+	err = db.Preload("Category").First(data, data.ID).Error
 	if err != nil {
 		r.log.Error("failed to preload category: ", sl.String("layer", r.layer), zap.Error(err))
 		return nil, err
@@ -55,7 +64,7 @@ func (r *MarkRepository) Create(ctx context.Context, data *model.Mark) (*model.M
 func (r *MarkRepository) Update(ctx context.Context, id int, mark *model.Mark) (*model.Mark, error) {
 	r.log.Info("MarkRepository.Update", zap.Int("id", id))
 
-	err := r.db.WithContext(ctx).Model(&model.Mark{}).Where("id = ?", id).Save(mark).Error
+	err := txmanager.DBFromCtx(ctx, r.db).Model(&model.Mark{}).Where("id = ?", id).Save(mark).Error
 	if err != nil {
 		r.log.Error("update_mark_by_id err: ", sl.String("layer", r.layer), zap.Error(err))
 		return nil, err
@@ -77,10 +86,23 @@ func (r *MarkRepository) GetByID(ctx context.Context, id int) (*model.Mark, erro
 	return mark, nil
 }
 
+func (r *MarkRepository) GetByIDs(ctx context.Context, ids []int) ([]*model.Mark, error) {
+	var marks []*model.Mark
+	if len(ids) == 0 {
+		return marks, nil
+	}
+	err := r.db.WithContext(ctx).Model(&model.Mark{}).Preload("Category").Where("id IN ?", ids).Find(&marks).Error
+	if err != nil {
+		r.log.Error("get_marks_by_ids err: ", sl.String("layer", r.layer), zap.Error(err))
+		return nil, err
+	}
+	return marks, nil
+}
+
 func (r *MarkRepository) Delete(ctx context.Context, id int) error {
 	r.log.Info("delete_mark_by_id", sl.String("layer", r.layer))
 
-	result := r.db.WithContext(ctx).Delete(&model.Mark{}, id)
+	result := txmanager.DBFromCtx(ctx, r.db).Delete(&model.Mark{}, id)
 	if result.Error != nil {
 		r.log.Error("delete_mark_by_id err: ", sl.String("layer", r.layer), zap.Error(result.Error))
 		return result.Error
@@ -107,12 +129,12 @@ func (r *MarkRepository) TodayCreated(ctx context.Context, userID int) (int64, e
 
 func (r *MarkRepository) GetMarksInArea(ctx context.Context, filter repository.Filter) ([]*model.Mark, error) {
 	var marks []*model.Mark
-	bbox := filter.BoundingBox
+	spatial, spatialArgs := spatialCondition("marks.geom", filter.BoundingBox, filter.Area)
 	err := r.db.WithContext(ctx).Model(&model.Mark{}).
 		Joins("Category").
-		Where("geom && ST_MakeEnvelope(?, ?, ?, ?, 4326)", bbox.LeftTop.Lon, bbox.RightBottom.Lat, bbox.RightBottom.Lon, bbox.LeftTop.Lat).
+		Where(spatial, spatialArgs...).
 		Where("start_at <= ? AND end_at >= ?", filter.EndAt, filter.StartAt).
-		Where("deleted_at IS NULL").
+		Where("deleted_at IS NULL AND is_hidden = false").
 		Find(&marks).Error
 	if err != nil {
 		r.log.Error("error MarkRepository.GetMarksInArea", zap.Error(err))
@@ -122,6 +144,21 @@ func (r *MarkRepository) GetMarksInArea(ctx context.Context, filter repository.F
 	return marks, nil
 }
 
+func (r *MarkRepository) GetMarkIDsInArea(ctx context.Context, filter repository.Filter) ([]int, error) {
+	var ids []int
+	spatial, spatialArgs := spatialCondition("geom", filter.BoundingBox, filter.Area)
+	err := r.db.WithContext(ctx).Model(&model.Mark{}).
+		Where(spatial, spatialArgs...).
+		Where("start_at <= ? AND end_at >= ?", filter.EndAt, filter.StartAt).
+		Where("is_hidden = false").
+		Pluck("id", &ids).Error
+	if err != nil {
+		r.log.Error("error MarkRepository.GetMarkIDsInArea", zap.Error(err))
+		return nil, err
+	}
+	return ids, nil
+}
+
 func (r *MarkRepository) GetMarksInCluster(ctx context.Context, filter repository.Filter) ([]*model.Cluster, error) {
 	type clusterResult struct {
 		ClusterID int     `gorm:"column:cluster_id"`
@@ -131,20 +168,30 @@ func (r *MarkRepository) GetMarksInCluster(ctx context.Context, filter repositor
 	}
 
 	var results []clusterResult
-	bbox := filter.BoundingBox
+	spatial, spatialArgs := spatialCondition("geom", filter.BoundingBox, filter.Area)
+	// Экран через 180-й меридиан кластеризуется в долготах 0..360, иначе соседние
+	// по разные стороны меридиана метки окажутся на расстоянии 360 градусов
+	shift := filter.Area == nil && filter.BoundingBox.CrossesAntimeridian()
 	query := `
-        WITH clustered_marks AS (
+        WITH area_marks AS (
             SELECT
                 id,
-                geom,
-                ST_ClusterDBSCAN(geom, eps := ?, minpoints := ?) OVER (
-                    ORDER BY id
-                ) AS cluster_id
+                CASE WHEN ? THEN ST_ShiftLongitude(geom) ELSE geom END AS geom
             FROM marks
-            WHERE geom && ST_MakeEnvelope(?, ?, ?, ?, 4326)
+            WHERE ` + spatial + `
               AND start_at <= ?
               AND end_at >= ?
               AND deleted_at IS NULL
+              AND is_hidden = false
+        ),
+        clustered_marks AS (
+            SELECT
+                id,
+                geom,
+                ST_ClusterDBSCAN(geom, eps := ?, minpoints := ?) OVER (
+                    ORDER BY id
+                ) AS cluster_id
+            FROM area_marks
         )
         SELECT
             cluster_id,
@@ -158,13 +205,18 @@ func (r *MarkRepository) GetMarksInCluster(ctx context.Context, filter repositor
 
 	eps := clusterPixelThreshold * 360.0 / (256.0 * math.Pow(2, filter.ZoomLevel))
 
-	err := r.db.WithContext(ctx).Raw(query, eps, 1, bbox.LeftTop.Lon, bbox.RightBottom.Lat, bbox.RightBottom.Lon, bbox.LeftTop.Lat, filter.EndAt, filter.StartAt).Scan(&results).Error
+	args := append([]any{shift}, spatialArgs...)
+	args = append(args, filter.EndAt, filter.StartAt, eps, 1)
+	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&results).Error
 	if err != nil {
 		r.log.Error("failed to get marks in cluster", zap.Error(err))
 		return nil, err
 	}
 	clusters := make([]*model.Cluster, len(results))
 	for i, result := range results {
+		if result.CenterLon > 180 {
+			result.CenterLon -= 360
+		}
 		clusters[i] = &model.Cluster{
 			Center: types.Point{
 				Point: orb.Point{result.CenterLon, result.CenterLat},
@@ -175,17 +227,163 @@ func (r *MarkRepository) GetMarksInCluster(ctx context.Context, filter repositor
 	return clusters, nil
 }
 
+// spatialCondition условие попадания column в область запроса: в полигон, если он задан,
+// иначе в прямоугольник экрана (разделенный по 180-му меридиану при необходимости)
+func spatialCondition(column string, bbox valueobject.BoundingBox, area orb.MultiPolygon) (string, []any) {
+	if area != nil {
+		return "ST_Intersects(" + column + ", ST_GeomFromText(?, 4326))", []any{wkt.MarshalString(area)}
+	}
+
+	envelopes := bbox.Envelopes()
+	conditions := make([]string, len(envelopes))
+	args := make([]any, 0, len(envelopes)*4)
+	for i, e := range envelopes {
+		conditions[i] = column + " && ST_MakeEnvelope(?, ?, ?, ?, 4326)"
+		args = append(args, e.MinLon, e.MinLat, e.MaxLon, e.MaxLat)
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")", args
+}
+
+func (r *MarkRepository) GetTimeline(ctx context.Context, filter repository.TimelineFilter) ([]model.TimelineBucket, error) {
+	type bucketResult struct {
+		BucketStart time.Time `gorm:"column:bucket_start"`
+		Count       int64     `gorm:"column:count"`
+	}
+
+	var results []bucketResult
+	spatial, spatialArgs := spatialCondition("m.geom", filter.BoundingBox, filter.Area)
+	// Метка попадает в интервал, если была активна хотя бы его часть
+	query := `
+        SELECT
+            b.bucket_start,
+            COUNT(m.id) AS count
+        FROM generate_series(
+            ?::timestamptz,
+            ?::timestamptz - make_interval(secs => ?),
+            make_interval(secs => ?)
+        ) AS b(bucket_start)
+        LEFT JOIN marks m
+            ON m.start_at < b.bucket_start + make_interval(secs => ?)
+           AND m.end_at > b.bucket_start
+           AND ` + spatial + `
+           AND m.deleted_at IS NULL
+           AND m.is_hidden = false
+        GROUP BY b.bucket_start
+        ORDER BY b.bucket_start
+    `
+
+	step := filter.Step.Seconds()
+	args := append([]any{filter.From, filter.To, step, step, step}, spatialArgs...)
+	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&results).Error
+	if err != nil {
+		r.log.Error("failed to get marks timeline", zap.Error(err))
+		return nil, err
+	}
+
+	buckets := make([]model.TimelineBucket, len(results))
+	for i, result := range results {
+		buckets[i] = model.TimelineBucket{
+			Start: result.BucketStart,
+			End:   result.BucketStart.Add(filter.Step),
+			Count: result.Count,
+		}
+	}
+	return buckets, nil
+}
+
+func (r *MarkRepository) GetMarksAlongRoute(ctx context.Context, filter repository.RouteFilter) ([]*model.RouteMark, error) {
+	type routeResult struct {
+		ID       int     `gorm:"column:id"`
+		Position float64 `gorm:"column:position"`
+		Along    float64 `gorm:"column:along"`
+		Distance float64 `gorm:"column:distance"`
+	}
+
+	var results []routeResult
+	// Прямоугольник маршрута, расширенный на ширину коридора, отсекает метки по индексу
+	// до точной проверки расстояния на сфере
+	bound := geo.BoundPad(filter.Route.Bound(), filter.Buffer)
+	query := `
+        WITH route AS (
+            SELECT
+                ST_GeomFromText(?, 4326) AS line,
+                ST_GeomFromText(?, 4326)::geography AS geog
+        )
+        SELECT
+            m.id,
+            ST_LineLocatePoint(route.line, m.geom) AS position,
+            ST_LineLocatePoint(route.line, m.geom) * ST_Length(route.geog) AS along,
+            ST_Distance(m.geom::geography, route.geog) AS distance
+        FROM marks m, route
+        WHERE m.geom && ST_MakeEnvelope(?, ?, ?, ?, 4326)
+          AND ST_DWithin(m.geom::geography, route.geog, ?)
+          AND m.start_at <= ?
+          AND m.end_at >= ?
+          AND m.deleted_at IS NULL
+          AND m.is_hidden = false
+        ORDER BY position, distance, m.id
+        LIMIT ?
+    `
+
+	line := wkt.MarshalString(filter.Route)
+	err := r.db.WithContext(ctx).Raw(query,
+		line, line,
+		bound.Min.Lon(), bound.Min.Lat(), bound.Max.Lon(), bound.Max.Lat(),
+		filter.Buffer, filter.At, filter.At, filter.Limit,
+	).Scan(&results).Error
+	if err != nil {
+		r.log.Error("failed to get marks along route", zap.Error(err))
+		return nil, err
+	}
+	if len(results) == 0 {
+		return []*model.RouteMark{}, nil
+	}
+
+	ids := make([]int, len(results))
+	for i, result := range results {
+		ids[i] = result.ID
+	}
+	var marks []*model.Mark
+	if err := r.db.WithContext(ctx).Joins("Category").Where("marks.id IN ?", ids).Find(&marks).Error; err != nil {
+		r.log.Error("failed to load marks along route", zap.Error(err))
+		return nil, err
+	}
+	byID := make(map[int]*model.Mark, len(marks))
+	for _, mark := range marks {
+		byID[mark.ID] = mark
+	}
+
+	routeMarks := make([]*model.RouteMark, 0, len(results))
+	for _, result := range results {
+		mark, ok := byID[result.ID]
+		if !ok {
+			continue
+		}
+		routeMarks = append(routeMarks, &model.RouteMark{
+			Mark:     mark,
+			Position: result.Position,
+			Along:    result.Along,
+			Distance: result.Distance,
+		})
+	}
+	return routeMarks, nil
+}
+
 func (r *MarkRepository) GetUserMarks(ctx context.Context, userID uint, params pagination.Params) ([]*model.Mark, int64, error) {
 	r.log.Info("GetUserMarks", zap.Uint("user_id", userID))
 	var marks []*model.Mark
 	var count int64
-	err := r.db.WithContext(ctx).Model(&model.Mark{}).
+	query := r.db.WithContext(ctx).Model(&model.Mark{}).
 		Where("user_id = ?", userID).
+		Session(&gorm.Session{})
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+	err := query.
 		Order("created_at DESC").
 		Limit(params.Limit()).
 		Offset(params.Offset()).
-		Find(&marks).
-		Count(&count).Error
+		Find(&marks).Error
 	return marks, count, err
 }
 
@@ -209,13 +407,174 @@ func (r *MarkRepository) Exist(ctx context.Context, id int) (bool, error) {
 	return exists, nil
 }
 
-func (r *MarkRepository) GetAll(ctx context.Context, params pagination.Params) ([]*model.Mark, int64, error) {
+func (r *MarkRepository) GetDeleted(ctx context.Context, userID int, since time.Time, params pagination.Params) ([]*model.Mark, int64, error) {
 	var marks []*model.Mark
 	var count int64
-	err := r.db.WithContext(ctx).Model(&model.Mark{}).Offset(params.Offset()).Limit(params.Limit()).Find(&marks).Count(&count).Error
+
+	query := r.db.WithContext(ctx).Unscoped().Model(&model.Mark{}).
+		Where("user_id = ? AND deleted_at IS NOT NULL AND deleted_at >= ?", userID, since).
+		Session(&gorm.Session{})
+	if err := query.Count(&count).Error; err != nil {
+		r.log.Error("get_deleted_marks_count err: ", sl.String("layer", r.layer), zap.Error(err))
+		return nil, 0, err
+	}
+
+	err := query.
+		Preload("Category").
+		Order("deleted_at DESC").
+		Limit(params.Limit()).
+		Offset(params.Offset()).
+		Find(&marks).Error
+	if err != nil {
+		r.log.Error("get_deleted_marks err: ", sl.String("layer", r.layer), zap.Error(err))
+		return nil, 0, err
+	}
+	return marks, count, nil
+}
+
+func (r *MarkRepository) GetDeletedByID(ctx context.Context, id int) (*model.Mark, error) {
+	var mark model.Mark
+	err := txmanager.DBFromCtx(ctx, r.db).Unscoped().
+		Preload("Category").
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		First(&mark).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainerrors.ErrMarkNotFound(id)
+		}
+		r.log.Error("get_deleted_mark err: ", sl.String("layer", r.layer), zap.Error(err))
+		return nil, err
+	}
+	return &mark, nil
+}
+
+func (r *MarkRepository) Restore(ctx context.Context, id int) error {
+	result := txmanager.DBFromCtx(ctx, r.db).Unscoped().Model(&model.Mark{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		r.log.Error("restore_mark err: ", sl.String("layer", r.layer), zap.Error(result.Error))
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.ErrMarkNotFound(id)
+	}
+	return nil
+}
+
+func (r *MarkRepository) GetExpiredDeleted(ctx context.Context, before time.Time, limit int) ([]*model.Mark, error) {
+	var marks []*model.Mark
+	err := r.db.WithContext(ctx).Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Order("deleted_at ASC").
+		Limit(limit).
+		Find(&marks).Error
 	if err != nil {
+		r.log.Error("get_expired_marks err: ", sl.String("layer", r.layer), zap.Error(err))
+		return nil, err
+	}
+	return marks, nil
+}
+
+func (r *MarkRepository) HardDelete(ctx context.Context, ids []int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	db := txmanager.DBFromCtx(ctx, r.db)
+
+	// Зависимые записи удаляем до самих меток
+	dependents := []any{&model.MarkReaction{}, &model.MarkCheckIn{}, &model.MarkRSVP{}, &model.MarkReport{}, &model.MarkShareOpen{}}
+	for _, dependent := range dependents {
+		if err := db.Where("mark_id IN ?", ids).Delete(dependent).Error; err != nil {
+			r.log.Error("hard_delete_dependents err: ", sl.String("layer", r.layer), zap.Error(err))
+			return err
+		}
+	}
+
+	if err := db.Unscoped().Where("id IN ?", ids).Delete(&model.Mark{}).Error; err != nil {
+		r.log.Error("hard_delete_marks err: ", sl.String("layer", r.layer), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *MarkRepository) SetModerationState(ctx context.Context, mark *model.Mark) error {
+	result := txmanager.DBFromCtx(ctx, r.db).Model(&model.Mark{}).
+		Where("id = ?", mark.ID).
+		Updates(map[string]any{"is_hidden": mark.IsHidden, "is_ended": mark.IsEnded, "end_at": mark.EndAt})
+	if result.Error != nil {
+		r.log.Error("set_moderation_state err: ", sl.String("layer", r.layer), zap.Error(result.Error))
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.ErrMarkNotFound(mark.ID)
+	}
+	return nil
+}
+
+// adminSortColumns колонки, по которым разрешена сортировка в админ панели
+var adminSortColumns = map[string]struct{}{
+	"id":            {},
+	"created_at":    {},
+	"start_at":      {},
+	"end_at":        {},
+	"reports_count": {},
+}
+
+func (r *MarkRepository) GetAll(ctx context.Context, filter repository.AdminFilter, params pagination.Params) ([]*model.Mark, int64, error) {
+	var marks []*model.Mark
+	var count int64
+
+	db := r.db.WithContext(ctx).Model(&model.Mark{})
+	now := time.Now()
+	switch filter.Status {
+	case repository.AdminStatusDeleted:
+		db = db.Unscoped().Where("deleted_at IS NOT NULL")
+	case repository.AdminStatusHidden:
+		db = db.Where("is_hidden = true")
+	case repository.AdminStatusEnded:
+		db = db.Where("is_ended = true OR end_at < ?", now)
+	case repository.AdminStatusActive:
+		db = db.Where("is_ended = false AND is_hidden = false AND start_at <= ? AND end_at >= ?", now, now)
+	case repository.AdminStatusUpcoming:
+		db = db.Where("is_ended = false AND start_at > ?", now)
+	}
+	if filter.OwnerID != nil {
+		db = db.Where("user_id = ?", *filter.OwnerID)
+	}
+	if filter.CategoryID != nil {
+		db = db.Where("category_id = ?", *filter.CategoryID)
+	}
+	if filter.CreatedFrom != nil {
+		db = db.Where("created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		db = db.Where("created_at < ?", *filter.CreatedTo)
+	}
+	if filter.MinReports != nil {
+		db = db.Where("reports_count >= ?", *filter.MinReports)
+	}
+	query := db.Session(&gorm.Session{})
+
+	if err := query.Count(&count).Error; err != nil {
 		r.log.Error("failed to get marks count", zap.Error(err))
 		return nil, 0, err
 	}
+
+	sortBy := "created_at"
+	if _, ok := adminSortColumns[filter.SortBy]; ok {
+		sortBy = filter.SortBy
+	}
+	err := query.
+		Preload("Category").
+		Order(clause.OrderByColumn{Column: clause.Column{Name: sortBy}, Desc: filter.SortDesc}).
+		Order("id DESC").
+		Offset(params.Offset()).
+		Limit(params.Limit()).
+		Find(&marks).Error
+	if err != nil {
+		r.log.Error("failed to get marks", zap.Error(err))
+		return nil, 0, err
+	}
 	return marks, count, nil
 }
@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"sort"
 	"time"
 
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/date"
@@ -129,3 +130,103 @@ func (r *MarkStatRepository) GetPopularCategories(ctx context.Context, userID ui
 	}
 	return rows, nil
 }
+
+func (r *MarkStatRepository) GetReceivedLikes(ctx context.Context, userID uint, period model.StatPeriod) (model.PeriodCount, error) {
+	r.log.Info("start MarkStatRepository.GetReceivedLikes", zap.Uint("user_id", userID))
+
+	// Лайки собственных меток не учитываются
+	query := r.db.WithContext(ctx).
+		Table("mark_reactions AS r").
+		Joins("JOIN marks m ON m.id = r.mark_id").
+		Where("m.user_id = ? AND m.deleted_at IS NULL AND r.user_id <> m.user_id", userID)
+	return r.countByPeriod(query, "r.created_at", period)
+}
+
+func (r *MarkStatRepository) GetReceivedShares(ctx context.Context, userID uint, period model.StatPeriod) (model.PeriodCount, error) {
+	r.log.Info("start MarkStatRepository.GetReceivedShares", zap.Uint("user_id", userID))
+
+	query := r.db.WithContext(ctx).
+		Table("mark_share_opens AS s").
+		Joins("JOIN marks m ON m.id = s.mark_id").
+		Where("m.user_id = ? AND m.deleted_at IS NULL", userID)
+	return r.countByPeriod(query, "s.created_at", period)
+}
+
+// countByPeriod считает строки запроса в текущем и предыдущем периоде одним проходом
+func (r *MarkStatRepository) countByPeriod(query *gorm.DB, column string, period model.StatPeriod) (model.PeriodCount, error) {
+	var row struct {
+		CurrentCount int64
+		PrevCount    int64
+	}
+
+	if period.IsAllTime() {
+		query = query.Select("COUNT(*) AS current_count, 0 AS prev_count")
+	} else {
+		query = query.
+			Select(
+				"COUNT(*) FILTER (WHERE "+column+" >= ? AND "+column+" < ?) AS current_count, "+
+					"COUNT(*) FILTER (WHERE "+column+" >= ? AND "+column+" < ?) AS prev_count",
+				period.Start, period.End, period.PrevStart, period.PrevEnd,
+			).
+			Where(column+" >= LEAST(?::timestamptz, ?::timestamptz) AND "+column+" < GREATEST(?::timestamptz, ?::timestamptz)",
+				period.Start, period.PrevStart, period.End, period.PrevEnd)
+	}
+	if err := query.Scan(&row).Error; err != nil {
+		r.log.Error("MarkStatRepository.countByPeriod error", zap.Error(err))
+		return model.PeriodCount{}, err
+	}
+	return model.PeriodCount{Count: row.CurrentCount, PrevCount: row.PrevCount}, nil
+}
+
+func (r *MarkStatRepository) GetCategoryMonthly(ctx context.Context, userID uint, year int) ([]model.CategoryMonthlySeries, error) {
+	r.log.Info("start MarkStatRepository.GetCategoryMonthly", zap.Uint("user_id", userID))
+
+	type result struct {
+		CategoryID   int
+		CategoryName string
+		Month        int
+		Count        int64
+	}
+
+	var rows []result
+	err := r.db.WithContext(ctx).
+		Table("marks AS m").
+		Select("c.id AS category_id, c.category_name, EXTRACT(MONTH FROM m.created_at)::int AS month, COUNT(*) AS count").
+		Joins("JOIN categories c ON c.id = m.category_id").
+		Where("m.user_id = ? AND m.deleted_at IS NULL", userID).
+		Where("EXTRACT(YEAR FROM m.created_at) = ?", year).
+		Group("c.id, c.category_name, month").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	months := date.GetMonthsName()
+	byCategory := make(map[int]*model.CategoryMonthlySeries)
+	var series []*model.CategoryMonthlySeries
+	for _, row := range rows {
+		s, ok := byCategory[row.CategoryID]
+		if !ok {
+			s = &model.CategoryMonthlySeries{CategoryName: row.CategoryName, Months: make([]model.MonthlyActivity, 12)}
+			for i := range s.Months {
+				s.Months[i].Month = months[i]
+			}
+			byCategory[row.CategoryID] = s
+			series = append(series, s)
+		}
+		s.Months[row.Month-1].Count = row.Count
+		s.Total += row.Count
+	}
+
+	sort.SliceStable(series, func(i, j int) bool {
+		if series[i].Total != series[j].Total {
+			return series[i].Total > series[j].Total
+		}
+		return series[i].CategoryName < series[j].CategoryName
+	})
+	monthly := make([]model.CategoryMonthlySeries, len(series))
+	for i, s := range series {
+		monthly[i] = *s
+	}
+	return monthly, nil
+}
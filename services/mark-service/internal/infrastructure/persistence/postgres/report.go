@@ -0,0 +1,188 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/database/txmanager"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/logger/sl"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/pagination"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/domainerrors"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/model"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/repository"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReportRepository struct {
+	db    *gorm.DB
+	log   *zap.Logger
+	layer string
+}
+
+func NewReportRepository(db *gorm.DB, logger *zap.Logger) repository.ReportRepository {
+	return &ReportRepository{
+		db:    db,
+		log:   logger,
+		layer: "report_repository",
+	}
+}
+
+func (r *ReportRepository) Create(ctx context.Context, report *model.MarkReport) error {
+	err := txmanager.DBFromCtx(ctx, r.db).Create(report).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return domainerrors.ErrAlreadyReported(report.MarkID)
+		}
+		r.log.Error("create_report err: ", sl.String("layer", r.layer), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *ReportRepository) PendingScore(ctx context.Context, markID int) (float64, error) {
+	var score float64
+	err := txmanager.DBFromCtx(ctx, r.db).Model(&model.MarkReport{}).
+		Select("COALESCE(SUM(weight), 0)").
+		Where("mark_id = ? AND status = ?", markID, model.ReportPending).
+		Scan(&score).Error
+	return score, err
+}
+
+func (r *ReportRepository) GetByMark(ctx context.Context, markID int, status model.ReportStatus, params pagination.Params) ([]*model.MarkReport, int64, error) {
+	var reports []*model.MarkReport
+	var count int64
+
+	db := r.db.WithContext(ctx).Model(&model.MarkReport{}).Where("mark_id = ?", markID)
+	if status != "" {
+		db = db.Where("status = ?", status)
+	}
+	query := db.Session(&gorm.Session{})
+	if err := query.Count(&count).Error; err != nil {
+		r.log.Error("get_reports_count err: ", sl.String("layer", r.layer), zap.Error(err))
+		return nil, 0, err
+	}
+
+	err := query.
+		Order("created_at DESC").
+		Limit(params.Limit()).
+		Offset(params.Offset()).
+		Find(&reports).Error
+	if err != nil {
+		r.log.Error("get_reports err: ", sl.String("layer", r.layer), zap.Error(err))
+		return nil, 0, err
+	}
+	return reports, count, nil
+}
+
+func (r *ReportRepository) GetQueue(ctx context.Context, params pagination.Params) ([]*model.ReportQueueItem, int64, error) {
+	var items []*model.ReportQueueItem
+	var count int64
+
+	// Жалобы на удаленные метки в очередь не попадают
+	base := r.db.WithContext(ctx).Model(&model.MarkReport{}).
+		Joins("JOIN marks ON marks.id = mark_reports.mark_id AND marks.deleted_at IS NULL").
+		Where("mark_reports.status = ?", model.ReportPending)
+
+	if err := base.Session(&gorm.Session{}).Distinct("mark_reports.mark_id").Count(&count).Error; err != nil {
+		r.log.Error("get_report_queue_count err: ", sl.String("layer", r.layer), zap.Error(err))
+		return nil, 0, err
+	}
+
+	err := base.Session(&gorm.Session{}).
+		Select("mark_reports.mark_id AS mark_id, COUNT(*) AS pending_count, SUM(mark_reports.weight) AS score, MAX(mark_reports.created_at) AS last_report_at").
+		Group("mark_reports.mark_id").
+		Order("score DESC, last_report_at DESC").
+		Limit(params.Limit()).
+		Offset(params.Offset()).
+		Scan(&items).Error
+	if err != nil {
+		r.log.Error("get_report_queue err: ", sl.String("layer", r.layer), zap.Error(err))
+		return nil, 0, err
+	}
+	return items, count, nil
+}
+
+func (r *ReportRepository) Resolve(ctx context.Context, markID int, status model.ReportStatus, reviewerID int) ([]int, error) {
+	var resolved []*model.MarkReport
+	err := txmanager.DBFromCtx(ctx, r.db).Model(&resolved).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "user_id"}}}).
+		Where("mark_id = ? AND status = ?", markID, model.ReportPending).
+		Updates(map[string]any{
+			"status":      status,
+			"reviewed_by": reviewerID,
+			"reviewed_at": time.Now(),
+		}).Error
+	if err != nil {
+		r.log.Error("resolve_reports err: ", sl.String("layer", r.layer), zap.Error(err))
+		return nil, err
+	}
+
+	userIDs := make([]int, len(resolved))
+	for i, report := range resolved {
+		userIDs[i] = report.UserID
+	}
+	return userIDs, nil
+}
+
+func (r *ReportRepository) IncrementReports(ctx context.Context, markID int) error {
+	return txmanager.DBFromCtx(ctx, r.db).Model(&model.Mark{}).
+		Where("id = ?", markID).
+		Update("reports_count", gorm.Expr("reports_count + 1")).Error
+}
+
+func (r *ReportRepository) ResetReports(ctx context.Context, markID int) error {
+	return txmanager.DBFromCtx(ctx, r.db).Model(&model.Mark{}).
+		Where("id = ?", markID).
+		Update("reports_count", 0).Error
+}
+
+func (r *ReportRepository) HideMark(ctx context.Context, markID int) (bool, error) {
+	result := txmanager.DBFromCtx(ctx, r.db).Model(&model.Mark{}).
+		Where("id = ? AND is_hidden = false", markID).
+		Update("is_hidden", true)
+	if result.Error != nil {
+		r.log.Error("hide_mark err: ", sl.String("layer", r.layer), zap.Error(result.Error))
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *ReportRepository) GetReputation(ctx context.Context, userID int) (*model.ReporterReputation, error) {
+	reputation := model.ReporterReputation{UserID: userID}
+	err := txmanager.DBFromCtx(ctx, r.db).Where("user_id = ?", userID).Take(&reputation).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		r.log.Error("get_reputation err: ", sl.String("layer", r.layer), zap.Error(err))
+		return nil, err
+	}
+	return &reputation, nil
+}
+
+func (r *ReportRepository) AddReputation(ctx context.Context, userIDs []int, accepted bool) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	column := "rejected"
+	if accepted {
+		column = "accepted"
+	}
+
+	rows := make([]model.ReporterReputation, len(userIDs))
+	for i, userID := range userIDs {
+		rows[i] = model.ReporterReputation{UserID: userID}
+		if accepted {
+			rows[i].Accepted = 1
+		} else {
+			rows[i].Rejected = 1
+		}
+	}
+	return txmanager.DBFromCtx(ctx, r.db).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.Assignments(map[string]any{
+			column:       gorm.Expr("reporter_reputations." + column + " + 1"),
+			"updated_at": time.Now(),
+		}),
+	}).Create(&rows).Error
+}
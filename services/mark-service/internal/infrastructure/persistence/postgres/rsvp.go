@@ -0,0 +1,225 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/database/txmanager"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/logger/sl"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/pagination"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/domainerrors"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/model"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/repository"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RSVPRepository struct {
+	db    *gorm.DB
+	log   *zap.Logger
+	layer string
+}
+
+func NewRSVPRepository(db *gorm.DB, logger *zap.Logger) repository.RSVPRepository {
+	return &RSVPRepository{
+		db:    db,
+		log:   logger,
+		layer: "rsvp_repository",
+	}
+}
+
+func (r *RSVPRepository) LockMark(ctx context.Context, markID int) (*model.Mark, error) {
+	var mark model.Mark
+	err := txmanager.DBFromCtx(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND deleted_at IS NULL", markID).
+		First(&mark).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainerrors.ErrMarkNotFound(markID)
+		}
+		r.log.Error("lock_mark err: ", sl.String("layer", r.layer), zap.Error(err))
+		return nil, err
+	}
+	return &mark, nil
+}
+
+func (r *RSVPRepository) SetCapacity(ctx context.Context, markID int, capacity *int) error {
+	return txmanager.DBFromCtx(ctx, r.db).Model(&model.Mark{}).
+		Where("id = ?", markID).
+		Update("capacity", capacity).Error
+}
+
+func (r *RSVPRepository) Get(ctx context.Context, markID int, userID uint) (*model.MarkRSVP, error) {
+	var rsvp model.MarkRSVP
+	err := txmanager.DBFromCtx(ctx, r.db).
+		Where("mark_id = ? AND user_id = ?", markID, userID).
+		First(&rsvp).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &rsvp, nil
+}
+
+func (r *RSVPRepository) Save(ctx context.Context, rsvp *model.MarkRSVP) error {
+	return txmanager.DBFromCtx(ctx, r.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "mark_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"status", "updated_at"}),
+	}).Create(rsvp).Error
+}
+
+func (r *RSVPRepository) Delete(ctx context.Context, markID int, userID uint) error {
+	return txmanager.DBFromCtx(ctx, r.db).
+		Where("mark_id = ? AND user_id = ?", markID, userID).
+		Delete(&model.MarkRSVP{}).Error
+}
+
+func (r *RSVPRepository) CountGoing(ctx context.Context, markID int) (int64, error) {
+	var count int64
+	err := txmanager.DBFromCtx(ctx, r.db).Model(&model.MarkRSVP{}).
+		Where("mark_id = ? AND status = ?", markID, model.RSVPGoing).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *RSVPRepository) PromoteWaitlisted(ctx context.Context, markID int, limit int) ([]*model.MarkRSVP, error) {
+	db := txmanager.DBFromCtx(ctx, r.db)
+
+	var queue []*model.MarkRSVP
+	err := db.Where("mark_id = ? AND status = ?", markID, model.RSVPWaitlist).
+		Order("updated_at ASC, id ASC").
+		Limit(limit).
+		Find(&queue).Error
+	if err != nil {
+		r.log.Error("get_waitlist err: ", sl.String("layer", r.layer), zap.Error(err))
+		return nil, err
+	}
+	if len(queue) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]uint, len(queue))
+	for i, rsvp := range queue {
+		ids[i] = rsvp.ID
+		rsvp.Status = model.RSVPGoing
+	}
+	err = db.Model(&model.MarkRSVP{}).
+		Where("id IN ?", ids).
+		Update("status", model.RSVPGoing).Error
+	if err != nil {
+		r.log.Error("promote_waitlist err: ", sl.String("layer", r.layer), zap.Error(err))
+		return nil, err
+	}
+	return queue, nil
+}
+
+func (r *RSVPRepository) GetSummary(ctx context.Context, markID int) (*model.RSVPSummary, error) {
+	type result struct {
+		Status model.RSVPStatus
+		Count  int64
+	}
+	var rows []result
+
+	err := txmanager.DBFromCtx(ctx, r.db).Model(&model.MarkRSVP{}).
+		Select("status, COUNT(*) AS count").
+		Where("mark_id = ?", markID).
+		Group("status").
+		Scan(&rows).Error
+	if err != nil {
+		r.log.Error("get_rsvp_summary err: ", sl.String("layer", r.layer), zap.Error(err))
+		return nil, err
+	}
+
+	summary := &model.RSVPSummary{}
+	for _, row := range rows {
+		switch row.Status {
+		case model.RSVPGoing:
+			summary.Going = row.Count
+		case model.RSVPInterested:
+			summary.Interested = row.Count
+		case model.RSVPNotGoing:
+			summary.NotGoing = row.Count
+		case model.RSVPWaitlist:
+			summary.Waitlist = row.Count
+		}
+	}
+	return summary, nil
+}
+
+func (r *RSVPRepository) GetByMark(ctx context.Context, markID int, status model.RSVPStatus, params pagination.Params) ([]*model.MarkRSVP, int64, error) {
+	var rsvps []*model.MarkRSVP
+	var count int64
+
+	query := txmanager.DBFromCtx(ctx, r.db).Model(&model.MarkRSVP{}).Where("mark_id = ?", markID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	query = query.Session(&gorm.Session{})
+
+	if err := query.Count(&count).Error; err != nil {
+		r.log.Error("get_rsvps_count err: ", sl.String("layer", r.layer), zap.Error(err))
+		return nil, 0, err
+	}
+	err := query.
+		Order("updated_at ASC, id ASC").
+		Limit(params.Limit()).
+		Offset(params.Offset()).
+		Find(&rsvps).Error
+	if err != nil {
+		r.log.Error("get_rsvps err: ", sl.String("layer", r.layer), zap.Error(err))
+		return nil, 0, err
+	}
+	return rsvps, count, nil
+}
+
+func (r *RSVPRepository) GetUpcoming(ctx context.Context, userID uint, params pagination.Params) ([]*model.MarkRSVP, int64, error) {
+	var rsvps []*model.MarkRSVP
+	var count int64
+
+	query := txmanager.DBFromCtx(ctx, r.db).Model(&model.MarkRSVP{}).
+		Joins("JOIN marks ON marks.id = mark_rsvps.mark_id").
+		Where("mark_rsvps.user_id = ?", userID).
+		Where("mark_rsvps.status IN ?", []model.RSVPStatus{model.RSVPGoing, model.RSVPInterested, model.RSVPWaitlist}).
+		Where("marks.end_at >= NOW() AND NOT marks.is_ended AND marks.deleted_at IS NULL").
+		Session(&gorm.Session{})
+
+	if err := query.Count(&count).Error; err != nil {
+		r.log.Error("get_upcoming_count err: ", sl.String("layer", r.layer), zap.Error(err))
+		return nil, 0, err
+	}
+	err := query.
+		Select("mark_rsvps.*").
+		Order("marks.start_at ASC").
+		Limit(params.Limit()).
+		Offset(params.Offset()).
+		Find(&rsvps).Error
+	if err != nil {
+		r.log.Error("get_upcoming err: ", sl.String("layer", r.layer), zap.Error(err))
+		return nil, 0, err
+	}
+	if len(rsvps) == 0 {
+		return rsvps, count, nil
+	}
+
+	ids := make([]int, len(rsvps))
+	for i, rsvp := range rsvps {
+		ids[i] = rsvp.MarkID
+	}
+	var marks []*model.Mark
+	if err := txmanager.DBFromCtx(ctx, r.db).Preload("Category").Where("id IN ?", ids).Find(&marks).Error; err != nil {
+		r.log.Error("get_upcoming_marks err: ", sl.String("layer", r.layer), zap.Error(err))
+		return nil, 0, err
+	}
+	byID := make(map[int]*model.Mark, len(marks))
+	for _, m := range marks {
+		byID[m.ID] = m
+	}
+	for _, rsvp := range rsvps {
+		rsvp.Mark = byID[rsvp.MarkID]
+	}
+	return rsvps, count, nil
+}
@@ -0,0 +1,57 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/logger/sl"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/model"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/repository"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ShareRepository struct {
+	db    *gorm.DB
+	log   *zap.Logger
+	layer string
+}
+
+func NewShareRepository(db *gorm.DB, logger *zap.Logger) repository.ShareRepository {
+	return &ShareRepository{
+		db:    db,
+		log:   logger,
+		layer: "share_repository",
+	}
+}
+
+func (r *ShareRepository) RecordOpen(ctx context.Context, open *model.MarkShareOpen) (bool, error) {
+	created := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(open)
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		created = true
+		return tx.Model(&model.Mark{}).
+			Where("id = ?", open.MarkID).
+			Update("shared_count", gorm.Expr("shared_count + 1")).Error
+	})
+	if err != nil {
+		r.log.Error("record_share_open err: ", sl.String("layer", r.layer), sl.Int("mark_id", open.MarkID), zap.Error(err))
+		return false, err
+	}
+	return created, nil
+}
+
+func (r *ShareRepository) CountBySharer(ctx context.Context, markID int, sharerID uint) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&model.MarkShareOpen{}).
+		Where("mark_id = ? AND sharer_id = ?", markID, sharerID).
+		Count(&count).Error
+	if err != nil {
+		r.log.Error("count_share_opens err: ", sl.String("layer", r.layer), sl.Int("mark_id", markID), zap.Error(err))
+		return 0, err
+	}
+	return count, nil
+}
@@ -1,10 +1,13 @@
 package mark
 
 import (
+	"encoding/json"
 	"time"
 
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/domainerrors"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/repository"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/valueobject"
+	"github.com/paulmach/orb"
 )
 
 type Coords struct {
@@ -18,26 +21,79 @@ type Screen struct {
 	RightBottom Coords `json:"rightBottom" binding:"required" validate:"required"`
 }
 
+func (s Screen) BoundingBox() valueobject.BoundingBox {
+	return valueobject.BoundingBox{
+		LeftTop: valueobject.Point{
+			Lon: s.LeftTop.Longitude,
+			Lat: s.LeftTop.Latitude,
+		},
+		RightBottom: valueobject.Point{
+			Lon: s.RightBottom.Longitude,
+			Lat: s.RightBottom.Latitude,
+		},
+	}
+}
+
+// areaLimits ограничения на полигон области запроса
+var areaLimits = valueobject.PolygonLimits{
+	MaxVertices: 1000,
+	MaxArea:     5e6 * 1e6, // 5 млн км²
+}
+
+// MarksZoom масштаб, начиная с которого вместо кластеров отдаются отдельные метки
+const MarksZoom = 12
+
+// marksScreenLimits ограничения на экран при выдаче отдельных меток. Кластеры
+// считаются на стороне БД, поэтому на мелком масштабе экран может охватывать весь мир
+var marksScreenLimits = valueobject.BoundingBoxLimits{
+	MaxLonSpan: 5,
+	MaxLatSpan: 5,
+}
+
+// ScreenLimits ограничения на экран для масштаба zoom
+func ScreenLimits(zoom float64) valueobject.BoundingBoxLimits {
+	if zoom < MarksZoom {
+		return valueobject.BoundingBoxLimits{}
+	}
+	return marksScreenLimits
+}
+
+// Region область запроса: прямоугольник экрана или GeoJSON Polygon/MultiPolygon
+type Region struct {
+	Screen *Screen         `json:"screen" binding:"required_without=Area" validate:"required_without=Area"`
+	Area   json.RawMessage `json:"area,omitempty" binding:"-" validate:"-"`
+}
+
+// Resolve проверяет область запроса. Если задан полигон, экран игнорируется
+func (r Region) Resolve(limits valueobject.BoundingBoxLimits) (valueobject.BoundingBox, orb.MultiPolygon, error) {
+	if len(r.Area) > 0 {
+		area, err := valueobject.NewArea(r.Area, areaLimits)
+		return valueobject.BoundingBox{}, area, err
+	}
+	if r.Screen == nil {
+		return valueobject.BoundingBox{}, nil, domainerrors.ErrInvalidBoundingBox()
+	}
+	bbox := r.Screen.BoundingBox()
+	return bbox, nil, bbox.Validate(limits)
+}
+
 type FilterParams struct {
-	Screen    Screen    `json:"screen" binding:"required" validate:"required"`
+	Region
 	ZoomLevel float64   `json:"zoomLevel" binding:"-"`
 	StartAt   time.Time `json:"startAt" binding:"required"`
 	EndAt     time.Time `json:"endAt" binding:"-"`
 }
 
-func ToInputFilter(data FilterParams) repository.Filter {
-	return repository.Filter{BoundingBox: valueobject.BoundingBox{
-		LeftTop: valueobject.Point{
-			Lon: data.Screen.LeftTop.Longitude,
-			Lat: data.Screen.LeftTop.Latitude,
-		},
-		RightBottom: valueobject.Point{
-			Lon: data.Screen.RightBottom.Longitude,
-			Lat: data.Screen.RightBottom.Latitude,
-		},
-	},
-		ZoomLevel: data.ZoomLevel,
-		StartAt:   data.StartAt,
-		EndAt:     data.EndAt,
+func ToInputFilter(data FilterParams) (repository.Filter, error) {
+	bbox, area, err := data.Resolve(ScreenLimits(data.ZoomLevel))
+	if err != nil {
+		return repository.Filter{}, err
 	}
+	return repository.Filter{
+		BoundingBox: bbox,
+		Area:        area,
+		ZoomLevel:   data.ZoomLevel,
+		StartAt:     data.StartAt,
+		EndAt:       data.EndAt,
+	}, nil
 }
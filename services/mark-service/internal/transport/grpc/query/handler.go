@@ -0,0 +1,66 @@
+package query
+
+import (
+	"context"
+
+	markstat "github.com/RealTimeMap/RealTimeMap-backend/pkg/pb/mark"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/model"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/service"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const maxIDsPerRequest = 100
+
+// Handler отвечает другим сервисам на вопросы о существовании и состоянии меток
+type Handler struct {
+	markstat.UnimplementedMarkQueryServiceServer
+
+	service *service.UserMarkService
+	logger  *zap.Logger
+}
+
+func NewHandler(service *service.UserMarkService, logger *zap.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *Handler) GetMarksState(ctx context.Context, req *markstat.MarksStateRequest) (*markstat.MarksStateResponse, error) {
+	if len(req.GetIds()) > maxIDsPerRequest {
+		return nil, status.Errorf(codes.InvalidArgument, "too many ids, max %d", maxIDsPerRequest)
+	}
+
+	ids := make([]int, 0, len(req.GetIds()))
+	for _, id := range req.GetIds() {
+		ids = append(ids, int(id))
+	}
+	marks, err := h.service.GetMarksByIDs(ctx, ids)
+	if err != nil {
+		h.logger.Error("GetMarksState error", zap.Error(err))
+		return nil, status.Error(codes.Internal, "internal err")
+	}
+	return toStateResponse(req.GetIds(), marks), nil
+}
+
+func toStateResponse(ids []uint64, marks []*model.Mark) *markstat.MarksStateResponse {
+	byID := make(map[uint64]*model.Mark, len(marks))
+	for _, mark := range marks {
+		byID[uint64(mark.ID)] = mark
+	}
+
+	states := make([]*markstat.MarkState, 0, len(ids))
+	for _, id := range ids {
+		state := &markstat.MarkState{Id: id}
+		if mark, ok := byID[id]; ok {
+			state.Exists = true
+			state.Active = mark.IsActive()
+			state.Ended = mark.HasEnded()
+			state.OwnerId = uint64(mark.UserID)
+		}
+		states = append(states, state)
+	}
+	return &markstat.MarksStateResponse{Marks: states}
+}
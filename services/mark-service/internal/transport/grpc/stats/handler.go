@@ -4,6 +4,7 @@ import (
 	"context"
 
 	markstat "github.com/RealTimeMap/RealTimeMap-backend/pkg/pb/mark"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/utils"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/model"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/service/stats"
 	"go.uber.org/zap"
@@ -62,6 +63,59 @@ func (h *Handler) GetPopularUserCategories(ctx context.Context, req *markstat.Po
 	return toPopularCategoriesResponse(categories), nil
 }
 
+func (h *Handler) GetUserEngagementStat(ctx context.Context, req *markstat.EngagementStatRequest) (*markstat.EngagementStatResponse, error) {
+	period := model.StatPeriod{}
+	if req.GetStartDate() != nil && req.GetEndDate() != nil {
+		period = model.StatPeriod{
+			Start:     req.GetStartDate().AsTime(),
+			End:       req.GetEndDate().AsTime(),
+			PrevStart: req.GetPrevStartDate().AsTime(),
+			PrevEnd:   req.GetPrevEndDate().AsTime(),
+		}
+	}
+
+	likes, shares, err := h.service.GetEngagement(ctx, uint(req.GetUserId()), period)
+	if err != nil {
+		h.logger.Error("GetUserEngagementStat error", zap.Error(err))
+		return nil, status.Error(codes.Internal, "internal err")
+	}
+	return &markstat.EngagementStatResponse{
+		Likes:  toPeriodStat(likes),
+		Shares: toPeriodStat(shares),
+	}, nil
+}
+
+func (h *Handler) GetUserCategoryMonthlySeries(ctx context.Context, req *markstat.CategorySeriesRequest) (*markstat.CategorySeriesResponse, error) {
+	series, err := h.service.GetCategoryMonthlySeries(ctx, uint(req.GetUserId()), int(req.GetYear()), int(req.GetTopN()))
+	if err != nil {
+		h.logger.Error("GetUserCategoryMonthlySeries error", zap.Error(err))
+		return nil, status.Error(codes.Internal, "internal err")
+	}
+	return toCategorySeriesResponse(series), nil
+}
+
+func toPeriodStat(data model.PeriodCount) *markstat.PeriodStat {
+	return &markstat.PeriodStat{
+		Count:     uint64(data.Count),
+		PrevCount: uint64(data.PrevCount),
+		Direction: utils.Direction(data.Count, data.PrevCount),
+	}
+}
+
+func toCategorySeriesResponse(data []model.CategoryMonthlySeries) *markstat.CategorySeriesResponse {
+	results := make([]*markstat.CategorySeries, 0, len(data))
+	for _, item := range data {
+		results = append(results, &markstat.CategorySeries{
+			CategoryName: item.CategoryName,
+			Total:        item.Total,
+			Months:       toActivityResponse(item.Months).Activities,
+		})
+	}
+	return &markstat.CategorySeriesResponse{
+		Series: results,
+	}
+}
+
 func toDayActivity(data []model.DayActivity) *markstat.MarksHeatMapResponse {
 	result := make([]*markstat.MarkHeatMapResponse, 0, len(data))
 	for _, d := range data {
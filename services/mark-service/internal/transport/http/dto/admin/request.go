@@ -0,0 +1,59 @@
+package admin
+
+import (
+	"time"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/model"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/repository"
+)
+
+// FilterParams параметры фильтрации списка меток в админ панели
+type FilterParams struct {
+	OwnerID    *int       `form:"ownerId" binding:"omitempty,min=1"`
+	CategoryID *int       `form:"categoryId" binding:"omitempty,min=1"`
+	Status     string     `form:"status" binding:"omitempty,oneof=active upcoming ended hidden deleted"`
+	From       *time.Time `form:"from"`
+	To         *time.Time `form:"to"`
+	MinReports *int64     `form:"minReports" binding:"omitempty,min=0"`
+	Sort       string     `form:"sort" binding:"omitempty,oneof=id created_at start_at end_at reports_count"`
+	Order      string     `form:"order" binding:"omitempty,oneof=asc desc"`
+	Page       int        `form:"page"`
+	PageSize   int        `form:"pageSize"`
+}
+
+func (p FilterParams) ToFilter() repository.AdminFilter {
+	return repository.AdminFilter{
+		OwnerID:     p.OwnerID,
+		CategoryID:  p.CategoryID,
+		Status:      p.Status,
+		CreatedFrom: p.From,
+		CreatedTo:   p.To,
+		MinReports:  p.MinReports,
+		SortBy:      p.Sort,
+		SortDesc:    p.Order != "asc",
+	}
+}
+
+// RequestModeration причина действия модератора
+type RequestModeration struct {
+	Reason string `json:"reason" binding:"max=500"`
+}
+
+// RequestBulkModeration массовое действие над метками
+type RequestBulkModeration struct {
+	IDs    []int  `json:"ids" binding:"required,min=1,max=100,dive,min=1"`
+	Action string `json:"action" binding:"required,oneof=hide unhide end delete"`
+	Reason string `json:"reason" binding:"max=500"`
+}
+
+// bulkActions соответствие действия в запросе действию в истории метки
+var bulkActions = map[string]model.MarkAction{
+	"hide":   model.MarkActionHidden,
+	"unhide": model.MarkActionUnhidden,
+	"end":    model.MarkActionEnded,
+	"delete": model.MarkActionDeleted,
+}
+
+func (r RequestBulkModeration) MarkAction() model.MarkAction {
+	return bulkActions[r.Action]
+}
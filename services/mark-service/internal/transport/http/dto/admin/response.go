@@ -0,0 +1,109 @@
+package admin
+
+import (
+	"time"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/types"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/model"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/service"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/transport/http/dto/category"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/transport/http/dto/mark"
+)
+
+type ResponseOwner struct {
+	ID       int    `json:"id"`
+	Username string `json:"username"`
+}
+
+// ResponseAdminPhoto фото метки с проверкой места съемки. Координаты съемки не отдаются,
+// только расстояние до метки
+type ResponseAdminPhoto struct {
+	URL              string   `json:"url"`
+	Distance         *float64 `json:"distance,omitempty"` // Метры от места съемки до метки, nil - координат в EXIF не было
+	LocationMismatch bool     `json:"locationMismatch"`
+}
+
+func NewMultipleResponseAdminPhoto(photos types.Photos) []ResponseAdminPhoto {
+	response := make([]ResponseAdminPhoto, 0, len(photos))
+	for _, p := range photos {
+		photo := ResponseAdminPhoto{URL: p.URL}
+		if p.Metadata != nil {
+			photo.Distance = p.Metadata.Distance
+			photo.LocationMismatch = p.Metadata.LocationMismatch
+		}
+		response = append(response, photo)
+	}
+	return response
+}
+
+// ResponseAdminMark метка с данными для модерации
+type ResponseAdminMark struct {
+	ID           int                        `json:"id"`
+	MarkName     string                     `json:"markName"`
+	Owner        ResponseOwner              `json:"owner"`
+	Category     *category.ResponseCategory `json:"category"`
+	Geom         *mark.Coordinates          `json:"geom"`
+	Status       string                     `json:"status"`
+	IsHidden     bool                       `json:"isHidden"`
+	IsEnded      bool                       `json:"isEnded"`
+	ReportsCount int64                      `json:"reportsCount"`
+	Photos       []ResponseAdminPhoto       `json:"photos"`
+	StartAt      time.Time                  `json:"startAt"`
+	EndAt        time.Time                  `json:"endAt"`
+	CreatedAt    time.Time                  `json:"createdAt"`
+	DeletedAt    *time.Time                 `json:"deletedAt,omitempty"`
+}
+
+func NewResponseAdminMark(data *model.Mark) *ResponseAdminMark {
+	response := &ResponseAdminMark{
+		ID:           data.ID,
+		MarkName:     data.MarkName,
+		Owner:        ResponseOwner{ID: data.UserID, Username: data.UserName},
+		Geom:         mark.NewFromPoint(data.Geom),
+		Status:       data.Status(),
+		IsHidden:     data.IsHidden,
+		IsEnded:      data.IsEnded,
+		ReportsCount: data.ReportsCount,
+		Photos:       NewMultipleResponseAdminPhoto(data.Photos),
+		StartAt:      data.StartAt,
+		EndAt:        data.EndAt,
+		CreatedAt:    data.CreatedAt,
+	}
+	if data.Category.ID != 0 {
+		response.Category = category.NewResponseCategory(&data.Category)
+	}
+	if data.DeletedAt.Valid {
+		response.DeletedAt = &data.DeletedAt.Time
+	}
+	return response
+}
+
+func NewMultipleResponseAdminMark(data []*model.Mark) []*ResponseAdminMark {
+	response := make([]*ResponseAdminMark, len(data))
+	for i := range response {
+		response[i] = NewResponseAdminMark(data[i])
+	}
+	return response
+}
+
+type ResponseBulkFailure struct {
+	ID    int    `json:"id"`
+	Error string `json:"error"`
+}
+
+// ResponseBulkModeration результат массового действия
+type ResponseBulkModeration struct {
+	Succeeded []int                 `json:"succeeded"`
+	Failed    []ResponseBulkFailure `json:"failed"`
+}
+
+func NewResponseBulkModeration(data *service.BulkResult) ResponseBulkModeration {
+	response := ResponseBulkModeration{
+		Succeeded: data.Succeeded,
+		Failed:    make([]ResponseBulkFailure, 0, len(data.Failed)),
+	}
+	for _, f := range data.Failed {
+		response.Failed = append(response.Failed, ResponseBulkFailure{ID: f.MarkID, Error: f.Err.Error()})
+	}
+	return response
+}
@@ -0,0 +1,21 @@
+package area
+
+import (
+	subdto "github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/transport/dto/mark"
+)
+
+// RequestArea сохраненная область: polygon или center + radius
+type RequestArea struct {
+	Name        string         `json:"name" binding:"required,min=1,max=100"`
+	Polygon     [][2]float64   `json:"polygon" binding:"omitempty,min=3"` // Вершины [lon, lat]
+	Center      *subdto.Coords `json:"center" binding:"required_with=Radius"`
+	Radius      *float64       `json:"radius" binding:"required_with=Center"` // Метры
+	CategoryIDs []int          `json:"categoryIds" binding:"omitempty,max=50,dive,min=1"`
+}
+
+func (r RequestArea) CenterPoint() *[2]float64 {
+	if r.Center == nil {
+		return nil
+	}
+	return &[2]float64{r.Center.Longitude, r.Center.Latitude}
+}
@@ -0,0 +1,49 @@
+package area
+
+import (
+	"time"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/model"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/transport/http/dto/mark"
+)
+
+type ResponseArea struct {
+	ID          uint              `json:"id"`
+	Name        string            `json:"name"`
+	Kind        model.AreaKind    `json:"kind"`
+	Polygon     [][2]float64      `json:"polygon,omitempty"`
+	Center      *mark.Coordinates `json:"center,omitempty"`
+	Radius      *float64          `json:"radius,omitempty"`
+	CategoryIDs []int             `json:"categoryIds"`
+	CreatedAt   time.Time         `json:"createdAt"`
+}
+
+func NewResponseArea(data *model.SavedArea) ResponseArea {
+	response := ResponseArea{
+		ID:          data.ID,
+		Name:        data.Name,
+		Kind:        data.Kind,
+		Radius:      data.Radius,
+		CategoryIDs: data.CategoryIDs,
+		CreatedAt:   data.CreatedAt,
+	}
+	if response.CategoryIDs == nil {
+		response.CategoryIDs = []int{}
+	}
+	if data.Kind == model.AreaKindCircle && data.Center != nil {
+		response.Center = mark.NewFromPoint(*data.Center)
+	} else if len(data.Geom.Polygon) > 0 {
+		for _, p := range data.Geom.Polygon[0] {
+			response.Polygon = append(response.Polygon, [2]float64{p.Lon(), p.Lat()})
+		}
+	}
+	return response
+}
+
+func NewMultipleResponseArea(data []*model.SavedArea) []ResponseArea {
+	response := make([]ResponseArea, len(data))
+	for i := range response {
+		response[i] = NewResponseArea(data[i])
+	}
+	return response
+}
@@ -0,0 +1,7 @@
+package checkin
+
+// RequestCheckIn координаты пользователя. Указатели, чтобы нулевые широта и долгота были допустимы
+type RequestCheckIn struct {
+	Longitude *float64 `json:"lon" binding:"required,longitude"`
+	Latitude  *float64 `json:"lat" binding:"required,latitude"`
+}
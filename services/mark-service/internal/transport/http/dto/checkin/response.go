@@ -0,0 +1,40 @@
+package checkin
+
+import (
+	"time"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/model"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/transport/http/dto/mark"
+)
+
+type ResponseCheckIn struct {
+	MarkID       int       `json:"markId"`
+	Distance     float64   `json:"distance"`
+	CheckInCount int64     `json:"checkInCount"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+func NewResponseCheckIn(data *model.MarkCheckIn, count int64) ResponseCheckIn {
+	return ResponseCheckIn{
+		MarkID:       data.MarkID,
+		Distance:     data.Distance,
+		CheckInCount: count,
+		CreatedAt:    data.CreatedAt,
+	}
+}
+
+type ResponseCheckInUser struct {
+	User      mark.OwnerResponse `json:"user"`
+	CreatedAt time.Time          `json:"createdAt"`
+}
+
+func NewMultipleResponseCheckInUser(data []*model.MarkCheckIn) []ResponseCheckInUser {
+	response := make([]ResponseCheckInUser, len(data))
+	for i, c := range data {
+		response[i] = ResponseCheckInUser{
+			User:      mark.NewOwnerResponse(c.User),
+			CreatedAt: c.CreatedAt,
+		}
+	}
+	return response
+}
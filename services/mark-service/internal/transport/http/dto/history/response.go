@@ -0,0 +1,51 @@
+package history
+
+import (
+	"time"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/model"
+)
+
+type ResponseActor struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	IsAdmin bool   `json:"isAdmin"`
+}
+
+type ResponseHistory struct {
+	Version   int                `json:"version"`
+	Action    model.MarkAction   `json:"action"`
+	Actor     ResponseActor      `json:"actor"`
+	Reason    *string            `json:"reason,omitempty"`
+	Changes   model.FieldChanges `json:"changes"`
+	Snapshot  model.MarkSnapshot `json:"snapshot"`
+	CreatedAt time.Time          `json:"createdAt"`
+}
+
+func NewResponseHistory(data *model.MarkHistory) ResponseHistory {
+	changes := data.Changes
+	if changes == nil {
+		changes = model.FieldChanges{}
+	}
+	return ResponseHistory{
+		Version: data.Version,
+		Action:  data.Action,
+		Actor: ResponseActor{
+			ID:      data.ActorID,
+			Name:    data.ActorName,
+			IsAdmin: data.ActorIsAdmin,
+		},
+		Reason:    data.Reason,
+		Changes:   changes,
+		Snapshot:  data.Snapshot,
+		CreatedAt: data.CreatedAt,
+	}
+}
+
+func NewMultipleResponseHistory(data []*model.MarkHistory) []ResponseHistory {
+	response := make([]ResponseHistory, len(data))
+	for i, entry := range data {
+		response[i] = NewResponseHistory(entry)
+	}
+	return response
+}
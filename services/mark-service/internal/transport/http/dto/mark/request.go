@@ -1,8 +1,13 @@
 package mark
 
 import (
+	"encoding/json"
 	"mime/multipart"
 	"time"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/valueobject"
+	subdto "github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/transport/dto/mark"
+	"github.com/paulmach/orb"
 )
 
 type RequestMark struct {
@@ -25,3 +30,41 @@ type RequestUpdateMark struct {
 	PhotosToDelete []string                `form:"photosToDelete" binding:"-"`
 	Photos         []*multipart.FileHeader `form:"photos" binding:"-"`
 }
+
+// RequestTimeline параметры гистограммы активности меток
+type RequestTimeline struct {
+	subdto.Region
+	From time.Time `json:"from" binding:"required"`
+	To   time.Time `json:"to" binding:"required"`
+	Step int       `json:"step" binding:"required,min=1"` // Шаг в минутах
+}
+
+// RequestMarksAt метки, активные в момент At
+type RequestMarksAt struct {
+	subdto.Region
+	ZoomLevel float64   `json:"zoomLevel" binding:"-"`
+	At        time.Time `json:"at" binding:"required"`
+}
+
+// routeLimits ограничения на маршрут для поиска меток вдоль него
+var routeLimits = valueobject.RouteLimits{
+	MaxVertices: 5000,
+	MaxLength:   1000 * 1e3, // 1000 км
+}
+
+// RequestRoute маршрут в виде GeoJSON LineString или encoded polyline и ширина коридора вокруг него
+type RequestRoute struct {
+	Route    json.RawMessage `json:"route" binding:"required_without=Polyline"`
+	Polyline string          `json:"polyline" binding:"required_without=Route"`
+	Buffer   float64         `json:"buffer" binding:"required"` // Расстояние от маршрута в метрах
+	At       time.Time       `json:"at" binding:"-"`            // По умолчанию текущий момент
+	Limit    int             `json:"limit" binding:"omitempty,min=1"`
+}
+
+// Line разбирает маршрут. Если задан GeoJSON, polyline игнорируется
+func (r RequestRoute) Line() (orb.LineString, error) {
+	if len(r.Route) > 0 {
+		return valueobject.NewRoute(r.Route, routeLimits)
+	}
+	return valueobject.DecodePolyline(r.Polyline, routeLimits)
+}
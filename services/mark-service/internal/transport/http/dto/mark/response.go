@@ -95,6 +95,52 @@ func NewMultipleResponseCluster(data []*model.Cluster) []*ResponseCluster {
 	return response
 }
 
+// ResponseRouteMark метка вдоль маршрута
+type ResponseRouteMark struct {
+	*ResponseMark
+	Position float64 `json:"position"` // Доля маршрута до метки, 0..1
+	Along    float64 `json:"along"`    // Расстояние от начала маршрута в метрах
+	Distance float64 `json:"distance"` // Расстояние от маршрута в метрах
+}
+
+func NewMultipleResponseRouteMark(data []*model.RouteMark) []ResponseRouteMark {
+	response := make([]ResponseRouteMark, len(data))
+	for i, item := range data {
+		response[i] = ResponseRouteMark{
+			ResponseMark: NewResponseMark(item.Mark),
+			Position:     item.Position,
+			Along:        item.Along,
+			Distance:     item.Distance,
+		}
+	}
+	return response
+}
+
+type ResponseTimelineBucket struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	Count int64     `json:"count"`
+}
+
+// ResponseTimeline гистограмма активности меток для шкалы времени
+type ResponseTimeline struct {
+	Step    int                      `json:"step"` // Шаг в минутах
+	Max     int64                    `json:"max"`  // Максимум по интервалам для масштабирования шкалы
+	Buckets []ResponseTimelineBucket `json:"buckets"`
+}
+
+func NewResponseTimeline(data []model.TimelineBucket, step time.Duration) ResponseTimeline {
+	response := ResponseTimeline{
+		Step:    int(step.Minutes()),
+		Buckets: make([]ResponseTimelineBucket, len(data)),
+	}
+	for i, bucket := range data {
+		response.Buckets[i] = ResponseTimelineBucket{Start: bucket.Start, End: bucket.End, Count: bucket.Count}
+		response.Max = max(response.Max, bucket.Count)
+	}
+	return response
+}
+
 type Date struct {
 	StartAt         time.Time `json:"startAt"`
 	EndAt           time.Time `json:"endAt"`
@@ -125,6 +171,29 @@ func NewMeta(m *model.Mark) Meta {
 	}
 }
 
+type RSVPSummary struct {
+	Capacity   *int   `json:"capacity"`
+	SpotsLeft  *int64 `json:"spotsLeft"`
+	Going      int64  `json:"going"`
+	Interested int64  `json:"interested"`
+	NotGoing   int64  `json:"notGoing"`
+	Waitlist   int64  `json:"waitlist"`
+}
+
+func NewRSVPSummary(s *model.RSVPSummary) *RSVPSummary {
+	if s == nil {
+		return nil
+	}
+	return &RSVPSummary{
+		Capacity:   s.Capacity,
+		SpotsLeft:  s.SpotsLeft(),
+		Going:      s.Going,
+		Interested: s.Interested,
+		NotGoing:   s.NotGoing,
+		Waitlist:   s.Waitlist,
+	}
+}
+
 type DetailMarkResponse struct {
 	ID             int                        `json:"id"`
 	MarKName       string                     `json:"markName"`
@@ -135,6 +204,8 @@ type DetailMarkResponse struct {
 	Photos         []string                   `json:"photos"`
 	Date           Date                       `json:"date"`
 	Meta           Meta                       `json:"meta"`
+	CheckInCount   int64                      `json:"checkInCount"`
+	RSVP           *RSVPSummary               `json:"rsvp,omitempty"`
 }
 
 func NewDetailMarkResponse(data *model.Mark) DetailMarkResponse {
@@ -147,6 +218,8 @@ func NewDetailMarkResponse(data *model.Mark) DetailMarkResponse {
 		User:           NewOwnerResponse(data.Owner),
 		Date:           date,
 		Meta:           NewMeta(data),
+		CheckInCount:   data.CheckInCount,
+		RSVP:           NewRSVPSummary(data.RSVP),
 	}
 	if data.Category.ID != 0 {
 		response.Category = category.NewResponseCategory(&data.Category)
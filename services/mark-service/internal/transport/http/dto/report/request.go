@@ -0,0 +1,17 @@
+package report
+
+type RequestReport struct {
+	Reason  string  `json:"reason" binding:"required,oneof=spam dangerous offensive fake other"`
+	Comment *string `json:"comment" binding:"omitempty,max=500"`
+}
+
+type RequestResolve struct {
+	Decision string `json:"decision" binding:"required,oneof=accept reject"`
+	Reason   string `json:"reason" binding:"max=500"`
+}
+
+type ReportsParams struct {
+	Status   string `form:"status" binding:"omitempty,oneof=pending accepted rejected"`
+	Page     int    `form:"page"`
+	PageSize int    `form:"pageSize"`
+}
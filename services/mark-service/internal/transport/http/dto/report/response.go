@@ -0,0 +1,100 @@
+package report
+
+import (
+	"time"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/model"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/transport/http/dto/admin"
+)
+
+type ResponseReport struct {
+	ID         uint               `json:"id"`
+	MarkID     int                `json:"markId"`
+	UserID     int                `json:"userId"`
+	Reason     model.ReportReason `json:"reason"`
+	Comment    *string            `json:"comment,omitempty"`
+	Status     model.ReportStatus `json:"status"`
+	Weight     float64            `json:"weight"`
+	ReviewedBy *int               `json:"reviewedBy,omitempty"`
+	ReviewedAt *time.Time         `json:"reviewedAt,omitempty"`
+	CreatedAt  time.Time          `json:"createdAt"`
+}
+
+func NewResponseReport(data *model.MarkReport) ResponseReport {
+	return ResponseReport{
+		ID:         data.ID,
+		MarkID:     data.MarkID,
+		UserID:     data.UserID,
+		Reason:     data.Reason,
+		Comment:    data.Comment,
+		Status:     data.Status,
+		Weight:     data.Weight,
+		ReviewedBy: data.ReviewedBy,
+		ReviewedAt: data.ReviewedAt,
+		CreatedAt:  data.CreatedAt,
+	}
+}
+
+func NewMultipleResponseReport(data []*model.MarkReport) []ResponseReport {
+	response := make([]ResponseReport, len(data))
+	for i := range response {
+		response[i] = NewResponseReport(data[i])
+	}
+	return response
+}
+
+// ResponseCreatedReport ответ автору жалобы без служебных полей
+type ResponseCreatedReport struct {
+	MarkID    int                `json:"markId"`
+	Reason    model.ReportReason `json:"reason"`
+	Status    model.ReportStatus `json:"status"`
+	CreatedAt time.Time          `json:"createdAt"`
+}
+
+func NewResponseCreatedReport(data *model.MarkReport) ResponseCreatedReport {
+	return ResponseCreatedReport{
+		MarkID:    data.MarkID,
+		Reason:    data.Reason,
+		Status:    data.Status,
+		CreatedAt: data.CreatedAt,
+	}
+}
+
+type ResponseQueueItem struct {
+	Mark         *admin.ResponseAdminMark `json:"mark"`
+	PendingCount int64                    `json:"pendingCount"`
+	Score        float64                  `json:"score"`
+	LastReportAt time.Time                `json:"lastReportAt"`
+}
+
+func NewMultipleResponseQueueItem(data []*model.ReportQueueItem) []ResponseQueueItem {
+	response := make([]ResponseQueueItem, 0, len(data))
+	for _, item := range data {
+		if item.Mark == nil {
+			continue
+		}
+		response = append(response, ResponseQueueItem{
+			Mark:         admin.NewResponseAdminMark(item.Mark),
+			PendingCount: item.PendingCount,
+			Score:        item.Score,
+			LastReportAt: item.LastReportAt,
+		})
+	}
+	return response
+}
+
+type ResponseReputation struct {
+	UserID   int     `json:"userId"`
+	Accepted int64   `json:"accepted"`
+	Rejected int64   `json:"rejected"`
+	Weight   float64 `json:"weight"`
+}
+
+func NewResponseReputation(data *model.ReporterReputation) ResponseReputation {
+	return ResponseReputation{
+		UserID:   data.UserID,
+		Accepted: data.Accepted,
+		Rejected: data.Rejected,
+		Weight:   data.Weight(),
+	}
+}
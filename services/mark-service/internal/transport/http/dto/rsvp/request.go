@@ -0,0 +1,15 @@
+package rsvp
+
+type RequestRSVP struct {
+	Status string `json:"status" binding:"required,oneof=going interested not_going"`
+}
+
+type RequestCapacity struct {
+	Capacity *int `json:"capacity" binding:"omitempty,min=1"`
+}
+
+type AttendeesParams struct {
+	Status   string `form:"status" binding:"omitempty,oneof=going interested not_going waitlist"`
+	Page     int    `form:"page"`
+	PageSize int    `form:"pageSize"`
+}
@@ -0,0 +1,61 @@
+package rsvp
+
+import (
+	"time"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/model"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/transport/http/dto/mark"
+)
+
+type ResponseRSVP struct {
+	MarkID    int       `json:"markId"`
+	Status    string    `json:"status"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+func NewResponseRSVP(data *model.MarkRSVP) ResponseRSVP {
+	return ResponseRSVP{
+		MarkID:    data.MarkID,
+		Status:    string(data.Status),
+		UpdatedAt: data.UpdatedAt,
+	}
+}
+
+type ResponseAttendee struct {
+	User      mark.OwnerResponse `json:"user"`
+	Status    string             `json:"status"`
+	UpdatedAt time.Time          `json:"updatedAt"`
+}
+
+func NewMultipleResponseAttendee(data []*model.MarkRSVP) []ResponseAttendee {
+	response := make([]ResponseAttendee, len(data))
+	for i, r := range data {
+		response[i] = ResponseAttendee{
+			User:      mark.NewOwnerResponse(r.User),
+			Status:    string(r.Status),
+			UpdatedAt: r.UpdatedAt,
+		}
+	}
+	return response
+}
+
+type ResponseUpcoming struct {
+	Mark   *mark.ResponseMark `json:"mark"`
+	Date   mark.Date          `json:"date"`
+	Status string             `json:"status"`
+}
+
+func NewMultipleResponseUpcoming(data []*model.MarkRSVP) []ResponseUpcoming {
+	response := make([]ResponseUpcoming, 0, len(data))
+	for _, r := range data {
+		if r.Mark == nil {
+			continue
+		}
+		response = append(response, ResponseUpcoming{
+			Mark:   mark.NewResponseMark(r.Mark),
+			Date:   mark.NewDate(r.Mark),
+			Status: string(r.Status),
+		})
+	}
+	return response
+}
@@ -0,0 +1,12 @@
+package share
+
+import "github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/service/share"
+
+type ResponseLink struct {
+	Token string `json:"token"`
+	URL   string `json:"url"`
+}
+
+func NewResponseLink(data share.Link) ResponseLink {
+	return ResponseLink{Token: data.Token, URL: data.URL}
+}
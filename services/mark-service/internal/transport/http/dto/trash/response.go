@@ -0,0 +1,26 @@
+package trash
+
+import (
+	"time"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/model"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/transport/http/dto/mark"
+)
+
+type ResponseTrashMark struct {
+	Mark         *mark.ResponseMark `json:"mark"`
+	DeletedAt    time.Time          `json:"deletedAt"`
+	RestoreUntil time.Time          `json:"restoreUntil"`
+}
+
+func NewMultipleResponseTrashMark(data []*model.Mark, gracePeriod time.Duration) []ResponseTrashMark {
+	response := make([]ResponseTrashMark, len(data))
+	for i, m := range data {
+		response[i] = ResponseTrashMark{
+			Mark:         mark.NewResponseMark(m),
+			DeletedAt:    m.DeletedAt.Time,
+			RestoreUntil: m.DeletedAt.Time.Add(gracePeriod),
+		}
+	}
+	return response
+}
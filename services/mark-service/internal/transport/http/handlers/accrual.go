@@ -27,27 +27,10 @@ func RegisterAccrualHandler(g *gin.RouterGroup, deps AccrualDeps) {
 
 	accrualGroup := g.Group("/marks/:markID")
 	{
-		accrualGroup.POST("/share", h.ShareHandle)
 		accrualGroup.POST("/like", auth.AuthRequired(), h.LikeHandle)
 	}
 }
 
-func (h *AccrualHandler) ShareHandle(c *gin.Context) {
-	markID, err := middleware.ParsePathParams(c, "markID")
-	if err != nil {
-		middleware.HandleError(c, err, h.logger)
-		return
-	}
-	count, err := h.service.IncreaseShare(c.Request.Context(), markID)
-	if err != nil {
-		middleware.HandleError(c, err, h.logger)
-		return
-	}
-	c.JSON(http.StatusOK, gin.H{
-		"count": count,
-	})
-}
-
 func (h *AccrualHandler) LikeHandle(c *gin.Context) {
 	markID, err := middleware.ParsePathParams(c, "markID")
 	if err != nil {
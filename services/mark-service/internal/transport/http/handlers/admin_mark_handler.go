@@ -1,11 +1,17 @@
 package handlers
 
 import (
+	"net/http"
+
+	helper "github.com/RealTimeMap/RealTimeMap-backend/pkg/helpers/context"
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/middleware/auth"
 	errorhandler "github.com/RealTimeMap/RealTimeMap-backend/pkg/middleware/error"
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/pagination"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/transport/http/middleware"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/model"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/service"
-	dto "github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/transport/http/dto/mark"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/service/input"
+	dto "github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/transport/http/dto/admin"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
@@ -20,22 +26,99 @@ func InitAdminMarkHandler(g *gin.RouterGroup, service *service.AdminMarkService,
 	group := g.Group("/admin/mark")
 	{
 		group.GET("/", auth.AdminOnly(), handler.GetAll)
+		group.POST("/bulk", auth.AdminOnly(), handler.Bulk)
+		group.POST("/:markID/hide", auth.AdminOnly(), handler.moderate(model.MarkActionHidden))
+		group.POST("/:markID/unhide", auth.AdminOnly(), handler.moderate(model.MarkActionUnhidden))
+		group.POST("/:markID/end", auth.AdminOnly(), handler.moderate(model.MarkActionEnded))
+		group.DELETE("/:markID", auth.AdminOnly(), handler.moderate(model.MarkActionDeleted))
 	}
 }
 
 func (h *AdminMarkHandler) GetAll(c *gin.Context) {
-	var params pagination.Params
-	params.Defaults()
-	if err := c.ShouldBindQuery(&params); err != nil {
+	var query dto.FilterParams
+	if err := c.ShouldBindQuery(&query); err != nil {
 		errorhandler.HandleError(c, err, h.logger)
 		return
 	}
-	marks, count, err := h.service.GetAll(c.Request.Context(), params)
+	params := pagination.Params{Page: query.Page, PageSize: query.PageSize}
+	params.Defaults()
+
+	marks, count, err := h.service.GetAll(c.Request.Context(), query.ToFilter(), params)
 	if err != nil {
 		errorhandler.HandleError(c, err, h.logger)
 		return
 	}
-	marksResponse := dto.NewMultipleResponseMark(marks)
+	marksResponse := dto.NewMultipleResponseAdminMark(marks)
 	response := pagination.NewResponse(marksResponse, params, count)
 	c.JSON(200, response)
 }
+
+// moderate обработчик одиночного действия модератора над меткой
+func (h *AdminMarkHandler) moderate(action model.MarkAction) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		markID, err := middleware.ParsePathParams(c, "markID")
+		if err != nil {
+			middleware.HandleError(c, err, h.logger)
+			return
+		}
+		var request dto.RequestModeration
+		// Тело с причиной необязательно для снятия скрытия
+		if c.Request.ContentLength != 0 {
+			if err := c.ShouldBindJSON(&request); err != nil {
+				errorhandler.HandleError(c, err, h.logger)
+				return
+			}
+		}
+		moderator, err := moderatorInput(c)
+		if err != nil {
+			middleware.HandleError(c, err, h.logger)
+			return
+		}
+		moderator.MarkID = int(markID)
+		moderator.Action = action
+		moderator.Reason = request.Reason
+
+		mark, err := h.service.Moderate(c.Request.Context(), moderator)
+		if err != nil {
+			middleware.HandleError(c, err, h.logger)
+			return
+		}
+		if action == model.MarkActionDeleted {
+			c.Status(http.StatusNoContent)
+			return
+		}
+		c.JSON(http.StatusOK, dto.NewResponseAdminMark(mark))
+	}
+}
+
+func (h *AdminMarkHandler) Bulk(c *gin.Context) {
+	var request dto.RequestBulkModeration
+	if err := c.ShouldBindJSON(&request); err != nil {
+		errorhandler.HandleError(c, err, h.logger)
+		return
+	}
+	moderator, err := moderatorInput(c)
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	moderator.Action = request.MarkAction()
+	moderator.Reason = request.Reason
+
+	result, err := h.service.BulkModerate(c.Request.Context(), request.IDs, moderator)
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NewResponseBulkModeration(result))
+}
+
+// moderatorInput данные модератора из контекста. Имя может отсутствовать у админских запросов
+func moderatorInput(c *gin.Context) (input.ModerationInput, error) {
+	userID, err := helper.GetUserID(c)
+	if err != nil {
+		return input.ModerationInput{}, err
+	}
+	userName, _ := helper.GetUserName(c)
+	return input.ModerationInput{UserInput: helper.NewUserInput(userID, userName, true)}, nil
+}
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"net/http"
+
+	helper "github.com/RealTimeMap/RealTimeMap-backend/pkg/helpers/context"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/middleware/auth"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/transport/http/middleware"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/validation"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/service/area"
+	dto "github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/transport/http/dto/area"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type AreaDeps struct {
+	Service *area.Service
+
+	Logger *zap.Logger
+}
+
+type AreaHandler struct {
+	service *area.Service
+	logger  *zap.Logger
+}
+
+func RegisterAreaHandler(g *gin.RouterGroup, deps AreaDeps) {
+	h := &AreaHandler{service: deps.Service, logger: deps.Logger}
+
+	group := g.Group("/areas", auth.AuthRequired())
+	{
+		group.GET("", h.ListHandle)
+		group.POST("", h.CreateHandle)
+		group.DELETE("/:areaID", h.DeleteHandle)
+	}
+}
+
+func (h *AreaHandler) CreateHandle(c *gin.Context) {
+	userID, err := helper.GetUserID(c)
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	var req dto.RequestArea
+	if err := c.ShouldBindJSON(&req); err != nil {
+		validation.AbortWithBindingError(c, err)
+		return
+	}
+
+	result, err := h.service.Create(c.Request.Context(), area.Input{
+		UserID:      userID,
+		Name:        req.Name,
+		Polygon:     req.Polygon,
+		Center:      req.CenterPoint(),
+		Radius:      req.Radius,
+		CategoryIDs: req.CategoryIDs,
+	})
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	c.JSON(http.StatusCreated, dto.NewResponseArea(result))
+}
+
+func (h *AreaHandler) ListHandle(c *gin.Context) {
+	userID, err := helper.GetUserID(c)
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	areas, err := h.service.GetUserAreas(c.Request.Context(), userID)
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NewMultipleResponseArea(areas))
+}
+
+func (h *AreaHandler) DeleteHandle(c *gin.Context) {
+	areaID, err := middleware.ParsePathParams(c, "areaID")
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	userID, err := helper.GetUserID(c)
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	if err := h.service.Delete(c.Request.Context(), areaID, userID); err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
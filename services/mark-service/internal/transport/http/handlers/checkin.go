@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"net/http"
+
+	helper "github.com/RealTimeMap/RealTimeMap-backend/pkg/helpers/context"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/middleware/auth"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/pagination"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/transport/http/middleware"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/types"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/validation"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/service/checkin"
+	dto "github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/transport/http/dto/checkin"
+	"github.com/gin-gonic/gin"
+	"github.com/paulmach/orb"
+	"go.uber.org/zap"
+)
+
+type CheckInDeps struct {
+	Service *checkin.Service
+
+	Logger *zap.Logger
+}
+
+type CheckInHandler struct {
+	service *checkin.Service
+	logger  *zap.Logger
+}
+
+func RegisterCheckInHandler(g *gin.RouterGroup, deps CheckInDeps) {
+	h := &CheckInHandler{service: deps.Service, logger: deps.Logger}
+
+	group := g.Group("/marks/:markID")
+	{
+		group.POST("/checkin", auth.AuthRequired(), h.CheckInHandle)
+		group.GET("/checkins", h.GetCheckInsHandle)
+	}
+}
+
+func (h *CheckInHandler) CheckInHandle(c *gin.Context) {
+	markID, err := middleware.ParsePathParams(c, "markID")
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	userID, err := helper.GetUserID(c)
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+
+	var req dto.RequestCheckIn
+	if err := c.ShouldBindJSON(&req); err != nil {
+		validation.AbortWithBindingError(c, err)
+		return
+	}
+
+	checkIn, count, err := h.service.CheckIn(c.Request.Context(), checkin.Input{
+		MarkID: int(markID),
+		UserID: uint(userID),
+		Geom:   types.Point{Point: orb.Point{*req.Longitude, *req.Latitude}},
+	})
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	c.JSON(http.StatusCreated, dto.NewResponseCheckIn(checkIn, count))
+}
+
+func (h *CheckInHandler) GetCheckInsHandle(c *gin.Context) {
+	markID, err := middleware.ParsePathParams(c, "markID")
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	var params pagination.Params
+	if err := c.ShouldBindQuery(&params); err != nil {
+		validation.AbortWithBindingError(c, err)
+		return
+	}
+	params.Defaults()
+
+	checkIns, count, err := h.service.GetCheckIns(c.Request.Context(), int(markID), params)
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	c.JSON(http.StatusOK, pagination.NewResponse(dto.NewMultipleResponseCheckInUser(checkIns), params, count))
+}
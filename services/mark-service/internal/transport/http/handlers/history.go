@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"net/http"
+
+	helper "github.com/RealTimeMap/RealTimeMap-backend/pkg/helpers/context"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/middleware/auth"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/pagination"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/transport/http/middleware"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/validation"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/service/history"
+	dto "github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/transport/http/dto/history"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type HistoryDeps struct {
+	Service *history.Service
+
+	Logger *zap.Logger
+}
+
+type HistoryHandler struct {
+	service *history.Service
+	logger  *zap.Logger
+}
+
+func RegisterHistoryHandler(g *gin.RouterGroup, deps HistoryDeps) {
+	h := &HistoryHandler{service: deps.Service, logger: deps.Logger}
+
+	g.GET("/admin/mark/:markID/history", auth.AdminOnly(), h.AdminHistoryHandle)
+
+	group := g.Group("/marks/:markID/history")
+	{
+		group.GET("", auth.AuthRequired(), h.OwnerHistoryHandle)
+		group.GET("/:version", auth.AuthRequired(), h.OwnerVersionHandle)
+	}
+}
+
+func (h *HistoryHandler) AdminHistoryHandle(c *gin.Context) {
+	markID, err := middleware.ParsePathParams(c, "markID")
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	params, ok := bindPagination(c)
+	if !ok {
+		return
+	}
+
+	entries, count, err := h.service.GetHistory(c.Request.Context(), int(markID), params)
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	c.JSON(http.StatusOK, pagination.NewResponse(dto.NewMultipleResponseHistory(entries), params, count))
+}
+
+func (h *HistoryHandler) OwnerHistoryHandle(c *gin.Context) {
+	markID, err := middleware.ParsePathParams(c, "markID")
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	userID, err := helper.GetUserID(c)
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	params, ok := bindPagination(c)
+	if !ok {
+		return
+	}
+
+	entries, count, err := h.service.GetOwnerHistory(c.Request.Context(), int(markID), userID, params)
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	c.JSON(http.StatusOK, pagination.NewResponse(dto.NewMultipleResponseHistory(entries), params, count))
+}
+
+func (h *HistoryHandler) OwnerVersionHandle(c *gin.Context) {
+	markID, err := middleware.ParsePathParams(c, "markID")
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	version, err := middleware.ParsePathParams(c, "version")
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	userID, err := helper.GetUserID(c)
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+
+	entry, err := h.service.GetOwnerVersion(c.Request.Context(), int(markID), int(version), userID)
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NewResponseHistory(entry))
+}
+
+// bindPagination читает параметры пагинации из query
+func bindPagination(c *gin.Context) (pagination.Params, bool) {
+	var params pagination.Params
+	if err := c.ShouldBindQuery(&params); err != nil {
+		validation.AbortWithBindingError(c, err)
+		return params, false
+	}
+	params.Defaults()
+	return params, true
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/transport/http/middleware"
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/types"
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/validation"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/repository"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/service"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/service/input"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/valueobject"
@@ -33,6 +34,9 @@ func InitMarkHandler(g *gin.RouterGroup, service *service.UserMarkService, logge
 	markGroup := g.Group("/marks")
 	{
 		markGroup.POST("/", handler.GetMarks)
+		markGroup.POST("/timeline", handler.GetTimeline)
+		markGroup.POST("/at", handler.GetMarksAt)
+		markGroup.POST("/route", handler.GetMarksAlongRoute)
 		markGroup.GET("/:markID/list", handler.GetUserMarks) // markID потому что особенность путей, подразумевается userID
 		markGroup.GET("/create-data", handler.GetDataForCreate)
 		markGroup.POST("/create", auth.AuthRequired(), handler.CreateMark)
@@ -92,23 +96,52 @@ func (h *MarkHandler) CreateMark(c *gin.Context) {
 func (h *MarkHandler) GetMarks(c *gin.Context) {
 	var params subdto.FilterParams
 	params.ZoomLevel = 15
-	const zoomSelector = 12
 	params.EndAt = time.Now().UTC()
 
 	if err := c.ShouldBindBodyWithJSON(&params); err != nil {
 		validation.AbortWithBindingError(c, err)
 		return
 	}
-	validParams := subdto.ToInputFilter(params)
-	if validParams.ZoomLevel < zoomSelector {
-		clusters, err := h.service.GetMarksInCluster(c.Request.Context(), validParams)
+	filter, err := subdto.ToInputFilter(params)
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	h.respondMarks(c, filter)
+}
+
+// GetMarksAt метки, активные в момент времени at, для воспроизведения карты по шкале времени
+func (h *MarkHandler) GetMarksAt(c *gin.Context) {
+	params := dto.RequestMarksAt{ZoomLevel: 15}
+	if err := c.ShouldBindBodyWithJSON(&params); err != nil {
+		validation.AbortWithBindingError(c, err)
+		return
+	}
+	bbox, area, err := params.Resolve(subdto.ScreenLimits(params.ZoomLevel))
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	h.respondMarks(c, repository.Filter{
+		BoundingBox: bbox,
+		Area:        area,
+		ZoomLevel:   params.ZoomLevel,
+		StartAt:     params.At,
+		EndAt:       params.At,
+	})
+}
+
+// respondMarks отдает кластеры при мелком масштабе и отдельные метки при крупном
+func (h *MarkHandler) respondMarks(c *gin.Context, filter repository.Filter) {
+	if filter.ZoomLevel < subdto.MarksZoom {
+		clusters, err := h.service.GetMarksInCluster(c.Request.Context(), filter)
 		if err != nil {
 			errorhandler.HandleError(c, err, h.logger)
 			return
 		}
 		c.JSON(200, dto.NewMultipleResponseCluster(clusters))
 	} else {
-		marks, err := h.service.GetMarksInArea(c.Request.Context(), validParams)
+		marks, err := h.service.GetMarksInArea(c.Request.Context(), filter)
 		if err != nil {
 			errorhandler.HandleError(c, err, h.logger)
 			return
@@ -118,6 +151,58 @@ func (h *MarkHandler) GetMarks(c *gin.Context) {
 	}
 }
 
+func (h *MarkHandler) GetTimeline(c *gin.Context) {
+	var params dto.RequestTimeline
+	if err := c.ShouldBindBodyWithJSON(&params); err != nil {
+		validation.AbortWithBindingError(c, err)
+		return
+	}
+	// Гистограмма агрегируется в БД, как и кластеры, поэтому размер экрана не ограничен
+	bbox, area, err := params.Resolve(valueobject.BoundingBoxLimits{})
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	step := time.Duration(params.Step) * time.Minute
+	buckets, err := h.service.GetTimeline(c.Request.Context(), repository.TimelineFilter{
+		BoundingBox: bbox,
+		Area:        area,
+		From:        params.From,
+		To:          params.To,
+		Step:        step,
+	})
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	c.JSON(200, dto.NewResponseTimeline(buckets, step))
+}
+
+// GetMarksAlongRoute активные метки вдоль маршрута, упорядоченные по ходу движения
+func (h *MarkHandler) GetMarksAlongRoute(c *gin.Context) {
+	var params dto.RequestRoute
+	if err := c.ShouldBindBodyWithJSON(&params); err != nil {
+		validation.AbortWithBindingError(c, err)
+		return
+	}
+	route, err := params.Line()
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	marks, err := h.service.GetMarksAlongRoute(c.Request.Context(), repository.RouteFilter{
+		Route:  route,
+		Buffer: params.Buffer,
+		At:     params.At,
+		Limit:  params.Limit,
+	})
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	c.JSON(200, dto.NewMultipleResponseRouteMark(marks))
+}
+
 func (h *MarkHandler) DeleteMark(c *gin.Context) {
 	userInfo, err := helper.GetUserInfo(c)
 	if err != nil {
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"net/http"
+
+	helper "github.com/RealTimeMap/RealTimeMap-backend/pkg/helpers/context"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/middleware/auth"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/pagination"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/transport/http/middleware"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/validation"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/model"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/service"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/service/input"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/transport/http/dto/admin"
+	dto "github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/transport/http/dto/report"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type ReportDeps struct {
+	Service *service.ReportService
+
+	Logger *zap.Logger
+}
+
+type ReportHandler struct {
+	service *service.ReportService
+	logger  *zap.Logger
+}
+
+func RegisterReportHandler(g *gin.RouterGroup, deps ReportDeps) {
+	h := &ReportHandler{service: deps.Service, logger: deps.Logger}
+
+	g.POST("/marks/:markID/report", auth.AuthRequired(), h.ReportHandle)
+
+	group := g.Group("/admin/reports", auth.AdminOnly())
+	{
+		group.GET("", h.QueueHandle)
+		group.GET("/marks/:markID", h.MarkReportsHandle)
+		group.POST("/marks/:markID/resolve", h.ResolveHandle)
+		group.GET("/reporters/:userID", h.ReputationHandle)
+	}
+}
+
+func (h *ReportHandler) ReportHandle(c *gin.Context) {
+	markID, err := middleware.ParsePathParams(c, "markID")
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	userInfo, err := helper.GetUserInfo(c)
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	var req dto.RequestReport
+	if err := c.ShouldBindJSON(&req); err != nil {
+		validation.AbortWithBindingError(c, err)
+		return
+	}
+
+	report, err := h.service.Report(c.Request.Context(), input.ReportInput{
+		MarkID:    int(markID),
+		Reason:    model.ReportReason(req.Reason),
+		Comment:   req.Comment,
+		UserInput: userInfo,
+	})
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	c.JSON(http.StatusCreated, dto.NewResponseCreatedReport(report))
+}
+
+func (h *ReportHandler) QueueHandle(c *gin.Context) {
+	params, ok := bindPagination(c)
+	if !ok {
+		return
+	}
+	items, count, err := h.service.GetQueue(c.Request.Context(), params)
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	c.JSON(http.StatusOK, pagination.NewResponse(dto.NewMultipleResponseQueueItem(items), params, count))
+}
+
+func (h *ReportHandler) MarkReportsHandle(c *gin.Context) {
+	markID, err := middleware.ParsePathParams(c, "markID")
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	var query dto.ReportsParams
+	if err := c.ShouldBindQuery(&query); err != nil {
+		validation.AbortWithBindingError(c, err)
+		return
+	}
+	params := pagination.Params{Page: query.Page, PageSize: query.PageSize}
+	params.Defaults()
+
+	reports, count, err := h.service.GetMarkReports(c.Request.Context(), int(markID), model.ReportStatus(query.Status), params)
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	c.JSON(http.StatusOK, pagination.NewResponse(dto.NewMultipleResponseReport(reports), params, count))
+}
+
+func (h *ReportHandler) ResolveHandle(c *gin.Context) {
+	markID, err := middleware.ParsePathParams(c, "markID")
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	var req dto.RequestResolve
+	if err := c.ShouldBindJSON(&req); err != nil {
+		validation.AbortWithBindingError(c, err)
+		return
+	}
+	moderator, err := moderatorInput(c)
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+
+	mark, err := h.service.Resolve(c.Request.Context(), input.ReportResolveInput{
+		MarkID:    int(markID),
+		Accepted:  req.Decision == "accept",
+		Reason:    req.Reason,
+		UserInput: moderator.UserInput,
+	})
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	c.JSON(http.StatusOK, admin.NewResponseAdminMark(mark))
+}
+
+func (h *ReportHandler) ReputationHandle(c *gin.Context) {
+	userID, err := middleware.ParsePathParams(c, "userID")
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	reputation, err := h.service.GetReputation(c.Request.Context(), int(userID))
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NewResponseReputation(reputation))
+}
@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"net/http"
+
+	helper "github.com/RealTimeMap/RealTimeMap-backend/pkg/helpers/context"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/middleware/auth"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/pagination"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/transport/http/middleware"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/validation"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/model"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/service/rsvp"
+	markdto "github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/transport/http/dto/mark"
+	dto "github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/transport/http/dto/rsvp"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type RSVPDeps struct {
+	Service *rsvp.Service
+
+	Logger *zap.Logger
+}
+
+type RSVPHandler struct {
+	service *rsvp.Service
+	logger  *zap.Logger
+}
+
+func RegisterRSVPHandler(g *gin.RouterGroup, deps RSVPDeps) {
+	h := &RSVPHandler{service: deps.Service, logger: deps.Logger}
+
+	g.GET("/marks/upcoming", auth.AuthRequired(), h.UpcomingHandle)
+
+	group := g.Group("/marks/:markID")
+	{
+		group.PUT("/rsvp", auth.AuthRequired(), h.RespondHandle)
+		group.DELETE("/rsvp", auth.AuthRequired(), h.CancelHandle)
+		group.PUT("/capacity", auth.AuthRequired(), h.CapacityHandle)
+		group.GET("/attendees", auth.AuthRequired(), h.AttendeesHandle)
+	}
+}
+
+func (h *RSVPHandler) RespondHandle(c *gin.Context) {
+	markID, userID, ok := h.bindMarkAndUser(c)
+	if !ok {
+		return
+	}
+	var req dto.RequestRSVP
+	if err := c.ShouldBindJSON(&req); err != nil {
+		validation.AbortWithBindingError(c, err)
+		return
+	}
+
+	result, err := h.service.Respond(c.Request.Context(), rsvp.Input{
+		MarkID: markID,
+		UserID: userID,
+		Status: model.RSVPStatus(req.Status),
+	})
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NewResponseRSVP(result))
+}
+
+func (h *RSVPHandler) CancelHandle(c *gin.Context) {
+	markID, userID, ok := h.bindMarkAndUser(c)
+	if !ok {
+		return
+	}
+	if err := h.service.Cancel(c.Request.Context(), markID, userID); err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *RSVPHandler) CapacityHandle(c *gin.Context) {
+	markID, userID, ok := h.bindMarkAndUser(c)
+	if !ok {
+		return
+	}
+	var req dto.RequestCapacity
+	if err := c.ShouldBindJSON(&req); err != nil {
+		validation.AbortWithBindingError(c, err)
+		return
+	}
+
+	summary, err := h.service.SetCapacity(c.Request.Context(), rsvp.CapacityInput{
+		MarkID:   markID,
+		UserID:   userID,
+		Capacity: req.Capacity,
+	})
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	c.JSON(http.StatusOK, markdto.NewRSVPSummary(summary))
+}
+
+func (h *RSVPHandler) AttendeesHandle(c *gin.Context) {
+	markID, userID, ok := h.bindMarkAndUser(c)
+	if !ok {
+		return
+	}
+	var query dto.AttendeesParams
+	if err := c.ShouldBindQuery(&query); err != nil {
+		validation.AbortWithBindingError(c, err)
+		return
+	}
+	params := pagination.Params{Page: query.Page, PageSize: query.PageSize}
+	params.Defaults()
+
+	attendees, count, err := h.service.GetAttendees(c.Request.Context(), rsvp.AttendeesParams{
+		MarkID:     markID,
+		UserID:     userID,
+		Status:     model.RSVPStatus(query.Status),
+		Pagination: params,
+	})
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	c.JSON(http.StatusOK, pagination.NewResponse(dto.NewMultipleResponseAttendee(attendees), params, count))
+}
+
+func (h *RSVPHandler) UpcomingHandle(c *gin.Context) {
+	userID, err := helper.GetUserID(c)
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	var params pagination.Params
+	if err := c.ShouldBindQuery(&params); err != nil {
+		validation.AbortWithBindingError(c, err)
+		return
+	}
+	params.Defaults()
+
+	upcoming, count, err := h.service.GetUpcoming(c.Request.Context(), uint(userID), params)
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	c.JSON(http.StatusOK, pagination.NewResponse(dto.NewMultipleResponseUpcoming(upcoming), params, count))
+}
+
+func (h *RSVPHandler) bindMarkAndUser(c *gin.Context) (int, uint, bool) {
+	markID, err := middleware.ParsePathParams(c, "markID")
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return 0, 0, false
+	}
+	userID, err := helper.GetUserID(c)
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return 0, 0, false
+	}
+	return int(markID), uint(userID), true
+}
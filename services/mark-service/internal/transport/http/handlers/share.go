@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	helper "github.com/RealTimeMap/RealTimeMap-backend/pkg/helpers/context"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/middleware/auth"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/transport/http/middleware"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/service/share"
+	dtomark "github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/transport/http/dto/mark"
+	dto "github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/transport/http/dto/share"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type ShareDeps struct {
+	Service *share.Service
+
+	Logger *zap.Logger
+}
+
+type ShareHandler struct {
+	service *share.Service
+	logger  *zap.Logger
+}
+
+func RegisterShareHandler(g *gin.RouterGroup, deps ShareDeps) {
+	h := &ShareHandler{service: deps.Service, logger: deps.Logger}
+
+	g.POST("/marks/:markID/share", auth.AuthRequired(), h.CreateLinkHandle)
+	g.GET("/share/:token", h.OpenHandle)
+}
+
+func (h *ShareHandler) CreateLinkHandle(c *gin.Context) {
+	markID, err := middleware.ParsePathParams(c, "markID")
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	userID, err := helper.GetUserID(c)
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+
+	link, err := h.service.CreateLink(c.Request.Context(), int(markID), uint(userID))
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	c.JSON(http.StatusCreated, dto.NewResponseLink(link))
+}
+
+// OpenHandle публичный переход по ссылке. Браузер перенаправляется на метку в клиенте,
+// при Accept: application/json или ?format=json метка возвращается в ответе.
+// Шлюз проверяет токен, если он передан, а у анонимного запроса удаляет X-User-ID
+func (h *ShareHandler) OpenHandle(c *gin.Context) {
+	visitor := share.Visitor{IP: c.ClientIP()}
+	if userID, err := strconv.Atoi(c.GetHeader("X-User-ID")); err == nil && userID > 0 {
+		visitor.UserID = uint(userID)
+	}
+
+	mark, err := h.service.Open(c.Request.Context(), c.Param("token"), visitor)
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+
+	if c.Query("format") == "json" || c.NegotiateFormat(gin.MIMEHTML, gin.MIMEJSON) == gin.MIMEJSON {
+		c.JSON(http.StatusOK, dtomark.NewDetailMarkResponse(mark))
+		return
+	}
+	c.Redirect(http.StatusFound, h.service.MarkURL(mark.ID))
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"net/http"
+
+	helper "github.com/RealTimeMap/RealTimeMap-backend/pkg/helpers/context"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/middleware/auth"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/pagination"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/transport/http/middleware"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/service"
+	markdto "github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/transport/http/dto/mark"
+	dto "github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/transport/http/dto/trash"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type TrashDeps struct {
+	Service *service.TrashService
+
+	Logger *zap.Logger
+}
+
+type TrashHandler struct {
+	service *service.TrashService
+	logger  *zap.Logger
+}
+
+func RegisterTrashHandler(g *gin.RouterGroup, deps TrashDeps) {
+	h := &TrashHandler{service: deps.Service, logger: deps.Logger}
+
+	g.GET("/marks/trash", auth.AuthRequired(), h.TrashHandle)
+	g.POST("/marks/:markID/restore", auth.AuthRequired(), h.RestoreHandle)
+}
+
+func (h *TrashHandler) TrashHandle(c *gin.Context) {
+	userID, err := helper.GetUserID(c)
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	params, ok := bindPagination(c)
+	if !ok {
+		return
+	}
+
+	marks, count, err := h.service.GetTrash(c.Request.Context(), userID, params)
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	response := dto.NewMultipleResponseTrashMark(marks, h.service.GracePeriod())
+	c.JSON(http.StatusOK, pagination.NewResponse(response, params, count))
+}
+
+func (h *TrashHandler) RestoreHandle(c *gin.Context) {
+	markID, err := middleware.ParsePathParams(c, "markID")
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	userInfo, err := helper.GetUserInfo(c)
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+
+	mark, err := h.service.Restore(c.Request.Context(), int(markID), userInfo)
+	if err != nil {
+		middleware.HandleError(c, err, h.logger)
+		return
+	}
+	c.JSON(http.StatusOK, markdto.NewResponseMark(mark))
+}
@@ -16,6 +16,15 @@ func RegisterRoutes(g *gin.Engine, container *app.Container) {
 	handlers.InitMarkHandler(api, container.MarkService, container.Logger)
 	handlers.InitAdminMarkHandler(api, container.AdminMarkService, container.Logger)
 	handlers.RegisterAccrualHandler(api, handlers.AccrualDeps{Service: container.AccrualService, Logger: container.Logger})
+	handlers.RegisterCheckInHandler(api, handlers.CheckInDeps{Service: container.CheckInService, Logger: container.Logger})
+	handlers.RegisterRSVPHandler(api, handlers.RSVPDeps{Service: container.RSVPService, Logger: container.Logger})
+	handlers.RegisterHistoryHandler(api, handlers.HistoryDeps{Service: container.HistoryService, Logger: container.Logger})
+	handlers.RegisterTrashHandler(api, handlers.TrashDeps{Service: container.TrashService, Logger: container.Logger})
+	handlers.RegisterReportHandler(api, handlers.ReportDeps{Service: container.ReportService, Logger: container.Logger})
+	handlers.RegisterAreaHandler(api, handlers.AreaDeps{Service: container.AreaService, Logger: container.Logger})
+	if container.ShareService != nil {
+		handlers.RegisterShareHandler(api, handlers.ShareDeps{Service: container.ShareService, Logger: container.Logger})
+	}
 
 	// Health
 	health := http.HealthHandler("mark-service", container.DB)
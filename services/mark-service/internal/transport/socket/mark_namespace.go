@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/model"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/repository"
 	subdto "github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/transport/dto/mark"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/transport/http/dto/mark"
 	"github.com/doquangtan/socketio/v4"
@@ -21,7 +23,9 @@ func init() {
 // InitMarkNamespace иницилизирует mark Namespace
 // Позволяет работать с метками в релаьном времени
 // Ивенты Client -> Server
-// message - дефолтный ивент для обработки новых параметров фильтрации
+// message - дефолтный ивент для обработки новых параметров фильтрации.
+// С delta: true в ответ приходят только добавленные и убранные метки относительно
+// прошлого ответа (см. viewport), клиент передает полученную epoch в следующих запросах
 // Ивенты Server -> Client
 // markCreated - создание новой метки
 func InitMarkNamespace(s *SocketServer) {
@@ -29,6 +33,8 @@ func InitMarkNamespace(s *SocketServer) {
 	s.logger.Info("init mark namespace", zap.String("namespace", ns.Name))
 
 	ns.OnConnection(func(socket *socketio.Socket) {
+		view := newViewport()
+
 		socket.On("message", func(event *socketio.EventPayload) {
 			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 			defer cancel()
@@ -41,30 +47,30 @@ func InitMarkNamespace(s *SocketServer) {
 			params, err := parseAndValidate(rawData)
 			if err != nil {
 				s.logger.Warn("failed to validate params", zap.Error(err))
-				if event.Ack != nil {
-					event.Ack(map[string]interface{}{
-						"success": false,
-						"error":   err.Error(),
-					})
-				}
+				ackError(event, err)
+				return
+			}
+			validParams, err := subdto.ToInputFilter(params.FilterParams)
+			if err != nil {
+				s.logger.Warn("failed to validate area", zap.Error(err))
+				ackError(event, err)
 				return
 			}
-			validParams := subdto.ToInputFilter(params)
 
-			if validParams.ZoomLevel < 12 {
+			if params.Delta {
+				s.handleDelta(ctx, event, view, params, validParams)
+				return
+			}
+
+			if validParams.ZoomLevel < subdto.MarksZoom {
 				clusters, err := s.markService.GetMarksInCluster(ctx, validParams)
 				if err != nil {
 					s.logger.Warn("failed to get cluster", zap.Error(err))
-					if event.Ack != nil {
-						event.Ack(map[string]interface{}{
-							"success": false,
-							"error":   err.Error(),
-						})
-					}
+					ackError(event, err)
 					return
 				}
 				clusterResponse := mark.NewMultipleResponseCluster(clusters)
-				event.Ack(map[string]interface{}{
+				ack(event, map[string]interface{}{
 					"success": true,
 					"cluster": clusterResponse,
 				})
@@ -73,23 +79,16 @@ func InitMarkNamespace(s *SocketServer) {
 				marks, err := s.markService.GetMarksInArea(ctx, validParams)
 				if err != nil {
 					s.logger.Warn("failed to get cluster", zap.Error(err))
-					if event.Ack != nil {
-						event.Ack(map[string]interface{}{
-							"success": false,
-							"error":   err,
-						})
-					}
+					ackError(event, err)
 					return
 				}
 				marksResponse := mark.NewMultipleResponseMark(marks)
-				event.Ack(map[string]interface{}{
+				ack(event, map[string]interface{}{
 					"success": true,
 					"marks":   marksResponse,
 				})
 				return
 			}
-
-			// TODO принимаем данные для фильтрации
 		})
 		socket.On("markCreated", func(data *socketio.EventPayload) { // TODO После реализации функционала поменять на Server -> Client
 			// TODO Уведомляем пользователя о новой метке в его зоне
@@ -107,22 +106,118 @@ func InitMarkNamespace(s *SocketServer) {
 
 }
 
-func parseAndValidate(data interface{}) (subdto.FilterParams, error) {
-	var params subdto.FilterParams
+// handleDelta отвечает разницей с уже отправленными метками. Кластеры всегда отправляются
+// целиком и сбрасывают набор, так что следующий ответ с метками будет полным
+func (s *SocketServer) handleDelta(ctx context.Context, event *socketio.EventPayload, view *viewport, params viewportParams, filter repository.Filter) {
+	// Запросы одного клиента обрабатываются по очереди, иначе версии перепутаются
+	view.mu.Lock()
+	defer view.mu.Unlock()
+
+	if filter.ZoomLevel < subdto.MarksZoom {
+		clusters, err := s.markService.GetMarksInCluster(ctx, filter)
+		if err != nil {
+			s.logger.Warn("failed to get cluster", zap.Error(err))
+			ackError(event, err)
+			return
+		}
+		epoch, version := view.reset()
+		ack(event, map[string]interface{}{
+			"success": true,
+			"epoch":   epoch,
+			"version": version,
+			"full":    true,
+			"cluster": mark.NewMultipleResponseCluster(clusters),
+		})
+		return
+	}
+
+	ids, err := s.markService.GetMarkIDsInArea(ctx, filter)
+	if err != nil {
+		s.logger.Warn("failed to get mark ids", zap.Error(err))
+		ackError(event, err)
+		return
+	}
+	delta := view.diff(ids, params.Epoch, params.Resync)
+
+	// Загружаются только новые для клиента метки
+	added, err := s.markService.GetMarksByIDs(ctx, delta.Added)
+	if err != nil {
+		s.logger.Warn("failed to get added marks", zap.Error(err))
+		ackError(event, err)
+		return
+	}
+	// Метки, пропавшие между запросами, не считаются отправленными
+	if len(added) != len(delta.Added) {
+		ids = sentIDs(ids, delta.Added, added)
+	}
+
+	epoch, version := view.commit(ids, delta.Full)
+	ack(event, map[string]interface{}{
+		"success": true,
+		"epoch":   epoch,
+		"version": version,
+		"full":    delta.Full,
+		"added":   mark.NewMultipleResponseMark(added),
+		"removed": delta.Removed,
+	})
+}
+
+// sentIDs исключает из ids метки, которые должны были уйти клиенту, но не загрузились
+func sentIDs(ids, requested []int, loaded []*model.Mark) []int {
+	missing := make(map[int]struct{}, len(requested))
+	for _, id := range requested {
+		missing[id] = struct{}{}
+	}
+	for _, m := range loaded {
+		delete(missing, m.ID)
+	}
+
+	result := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := missing[id]; !ok {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+func ack(event *socketio.EventPayload, data map[string]interface{}) {
+	if event.Ack != nil {
+		event.Ack(data)
+	}
+}
+
+func ackError(event *socketio.EventPayload, err error) {
+	ack(event, map[string]interface{}{
+		"success": false,
+		"error":   err.Error(),
+	})
+}
+
+// viewportParams параметры фильтрации и состояние дельта-обновлений клиента
+type viewportParams struct {
+	subdto.FilterParams
+	Delta  bool   `json:"delta"`  // Отвечать только разницей с прошлым ответом
+	Epoch  uint64 `json:"epoch"`  // Эпоха из прошлого ответа, 0 - нет состояния
+	Resync bool   `json:"resync"` // Запросить полный список
+}
+
+func parseAndValidate(data interface{}) (viewportParams, error) {
+	var params viewportParams
 	params.ZoomLevel = 12
 	params.EndAt = time.Now().UTC()
 
 	jsonBytes, err := json.Marshal(data)
 	if err != nil {
-		return subdto.FilterParams{}, err
+		return viewportParams{}, err
 	}
 
 	// Сериализуем в структуру
 	if err := json.Unmarshal(jsonBytes, &params); err != nil {
-		return subdto.FilterParams{}, err
+		return viewportParams{}, err
 	}
 	if err := validate.Struct(params); err != nil {
-		return subdto.FilterParams{}, err
+		return viewportParams{}, err
 	}
 	return params, nil
 }
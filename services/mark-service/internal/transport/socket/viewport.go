@@ -0,0 +1,74 @@
+package socket
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// viewport метки, уже отправленные клиенту, чтобы при смещении карты отвечать только разницей.
+// Эпоха меняется при каждой полной отправке: клиент с другой эпохой получает полный список.
+// Версия растет с каждым ответом, по ней клиент отбрасывает ответы, пришедшие не по порядку
+type viewport struct {
+	mu sync.Mutex
+
+	epoch   uint64
+	version uint64
+	sent    map[int]struct{}
+}
+
+// viewportDelta изменения набора меток относительно отправленного клиенту
+type viewportDelta struct {
+	Full    bool  // Клиент должен заменить свой набор на Added
+	Added   []int // id меток, которых у клиента нет
+	Removed []int // id меток, которые клиенту нужно убрать
+}
+
+func newViewport() *viewport {
+	// Эпоха от времени подключения, чтобы после переподключения не совпасть со старой
+	return &viewport{epoch: uint64(time.Now().UnixNano()), sent: make(map[int]struct{})}
+}
+
+// diff сравнивает новый набор меток с отправленным, не изменяя состояние.
+// Полный список отдается, если клиент прислал чужую эпоху или попросил resync
+func (v *viewport) diff(ids []int, clientEpoch uint64, resync bool) viewportDelta {
+	if resync || clientEpoch != v.epoch {
+		return viewportDelta{Full: true, Added: ids, Removed: []int{}}
+	}
+
+	current := make(map[int]struct{}, len(ids))
+	delta := viewportDelta{Added: []int{}, Removed: []int{}}
+	for _, id := range ids {
+		current[id] = struct{}{}
+		if _, ok := v.sent[id]; !ok {
+			delta.Added = append(delta.Added, id)
+		}
+	}
+	for id := range v.sent {
+		if _, ok := current[id]; !ok {
+			delta.Removed = append(delta.Removed, id)
+		}
+	}
+	sort.Ints(delta.Removed)
+	return delta
+}
+
+// commit запоминает отправленный клиенту набор и возвращает эпоху и версию ответа
+func (v *viewport) commit(ids []int, full bool) (uint64, uint64) {
+	if full {
+		v.epoch++
+	}
+	v.version++
+
+	v.sent = make(map[int]struct{}, len(ids))
+	for _, id := range ids {
+		v.sent[id] = struct{}{}
+	}
+	return v.epoch, v.version
+}
+
+// reset забывает отправленные метки, например при переходе к кластерам.
+// Следующий ответ с метками будет полным
+func (v *viewport) reset() (uint64, uint64) {
+	return v.commit(nil, true)
+}
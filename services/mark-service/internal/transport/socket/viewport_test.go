@@ -0,0 +1,67 @@
+package socket
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestViewportDiff(t *testing.T) {
+	tests := []struct {
+		name        string
+		sent        []int
+		ids         []int
+		staleEpoch  bool
+		resync      bool
+		wantFull    bool
+		wantAdded   []int
+		wantRemoved []int
+	}{
+		{"смещение карты", []int{1, 2, 3}, []int{2, 3, 4}, false, false, false, []int{4}, []int{1}},
+		{"набор не изменился", []int{1, 2}, []int{2, 1}, false, false, false, []int{}, []int{}},
+		{"все метки ушли", []int{3, 1, 2}, []int{}, false, false, false, []int{}, []int{1, 2, 3}},
+		{"чужая эпоха", []int{1, 2}, []int{2, 5}, true, false, true, []int{2, 5}, []int{}},
+		{"запрошен resync", []int{1, 2}, []int{1, 2}, false, true, true, []int{1, 2}, []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := newViewport()
+			epoch, _ := v.commit(tt.sent, true)
+			if tt.staleEpoch {
+				epoch--
+			}
+
+			delta := v.diff(tt.ids, epoch, tt.resync)
+			if delta.Full != tt.wantFull {
+				t.Errorf("Full = %v, want %v", delta.Full, tt.wantFull)
+			}
+			if !slices.Equal(delta.Added, tt.wantAdded) {
+				t.Errorf("Added = %v, want %v", delta.Added, tt.wantAdded)
+			}
+			if !slices.Equal(delta.Removed, tt.wantRemoved) {
+				t.Errorf("Removed = %v, want %v", delta.Removed, tt.wantRemoved)
+			}
+		})
+	}
+}
+
+func TestViewportCommit(t *testing.T) {
+	v := newViewport()
+
+	epoch, version := v.commit([]int{1, 2}, true)
+	nextEpoch, nextVersion := v.commit([]int{2, 3}, false)
+	if nextEpoch != epoch || nextVersion != version+1 {
+		t.Errorf("delta commit = (%d, %d), want (%d, %d)", nextEpoch, nextVersion, epoch, version+1)
+	}
+	if delta := v.diff([]int{2, 3}, nextEpoch, false); len(delta.Added) != 0 || len(delta.Removed) != 0 {
+		t.Errorf("diff after commit = %+v, want empty", delta)
+	}
+
+	resetEpoch, resetVersion := v.reset()
+	if resetEpoch == nextEpoch || resetVersion != nextVersion+1 {
+		t.Errorf("reset() = (%d, %d), want new epoch and version %d", resetEpoch, resetVersion, nextVersion+1)
+	}
+	if delta := v.diff([]int{2}, resetEpoch, false); !slices.Equal(delta.Added, []int{2}) {
+		t.Errorf("Added after reset = %v, want [2]", delta.Added)
+	}
+}
@@ -0,0 +1,76 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/RealTimeMap/RealTimeMap-backend/services/mark-service/internal/domain/service"
+	"go.uber.org/zap"
+)
+
+// TrashPurger периодически очищает корзину удаленных меток.
+// Реализует runner.Server, запускается вместе с HTTP и gRPC серверами
+type TrashPurger struct {
+	service  *service.TrashService
+	interval time.Duration
+	logger   *zap.Logger
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func NewTrashPurger(service *service.TrashService, interval time.Duration, logger *zap.Logger) *TrashPurger {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &TrashPurger{
+		service:  service,
+		interval: interval,
+		logger:   logger,
+		ctx:      ctx,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+}
+
+// Run блокируется до вызова Shutdown, очищая корзину раз в interval
+func (p *TrashPurger) Run() error {
+	defer close(p.done)
+	p.logger.Info("trash purger starting", zap.Duration("interval", p.interval))
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.purge()
+		select {
+		case <-p.ctx.Done():
+			p.logger.Info("trash purger stopped")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Shutdown останавливает Run, дожидаясь завершения текущего прохода
+func (p *TrashPurger) Shutdown(ctx context.Context) error {
+	p.cancel()
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *TrashPurger) purge() {
+	purged, err := p.service.Purge(p.ctx)
+	if err != nil {
+		if p.ctx.Err() == nil {
+			p.logger.Error("failed to purge trash", zap.Int("purged", purged), zap.Error(err))
+		}
+		return
+	}
+	if purged > 0 {
+		p.logger.Info("trash purged", zap.Int("purged", purged))
+	}
+}
@@ -2,6 +2,7 @@ package app
 
 import (
 	pkgprogress "github.com/RealTimeMap/RealTimeMap-backend/pkg/clients/progress"
+	pkgcomment "github.com/RealTimeMap/RealTimeMap-backend/pkg/clients/stats/comment"
 	pkgmark "github.com/RealTimeMap/RealTimeMap-backend/pkg/clients/stats/mark"
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/database/txmanager"
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/mediavalidator"
@@ -13,6 +14,7 @@ import (
 	"github.com/RealTimeMap/RealTimeMap-backend/services/social-service/internal/domain/service/chat"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/social-service/internal/domain/service/friendship"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/social-service/internal/domain/service/profile"
+	commentstatadapter "github.com/RealTimeMap/RealTimeMap-backend/services/social-service/internal/infrastructure/grpc/commentstats"
 	progressadapter "github.com/RealTimeMap/RealTimeMap-backend/services/social-service/internal/infrastructure/grpc/progress"
 	markstatadapter "github.com/RealTimeMap/RealTimeMap-backend/services/social-service/internal/infrastructure/grpc/stats"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/social-service/internal/infrastructure/persistence/postgres"
@@ -39,8 +41,9 @@ type Container struct {
 
 	Storage storage.Storage
 
-	ProgressClient *pkgprogress.Client
-	MarkStatClient *pkgmark.Client
+	ProgressClient    *pkgprogress.Client
+	MarkStatClient    *pkgmark.Client
+	CommentStatClient *pkgcomment.Client
 
 	Redis *redis.Client
 
@@ -49,6 +52,11 @@ type Container struct {
 }
 
 func (c *Container) Close() error {
+	if c.CommentStatClient != nil {
+		if err := c.CommentStatClient.Close(); err != nil {
+			c.Logger.Warn("comment stats client close failed", zap.Error(err))
+		}
+	}
 	if c.ProgressClient != nil {
 		return c.ProgressClient.Close()
 	}
@@ -70,6 +78,9 @@ func NewContainer(cfg *config.Config, db *gorm.DB, logger *zap.Logger) *Containe
 		progressPort   profile.ProgressGetter
 		markStatClient *pkgmark.Client
 		markStatPort   profile.MarkStatGetter
+
+		commentStatClient *pkgcomment.Client
+		commentStatPort   profile.CommentStatGetter
 	)
 	if cfg.Gamification.Address != "" {
 		c, err := pkgprogress.NewClient(&cfg.Gamification)
@@ -90,19 +101,27 @@ func NewContainer(cfg *config.Config, db *gorm.DB, logger *zap.Logger) *Containe
 			markStatPort = markstatadapter.NewAdapter(markStatClient)
 		}
 	}
+	if cfg.CommentStat.Address != "" {
+		c, err := pkgcomment.NewClient(cfg.CommentStat)
+		if err != nil {
+			logger.Warn("comment stats client init failed, continuing without comment activity", zap.Error(err))
+		} else {
+			commentStatClient = c
+			commentStatPort = commentstatadapter.NewAdapter(commentStatClient)
+		}
+	}
 
 	profileRepo := postgres.NewPgProfileRepository(db, logger)
 	profileService := profile.NewProfileService(profileRepo, store, photoValidator, progressPort, logger)
 	friendRepo := postgres.NewPgFriendshipRepository(db, logger)
-	profileStatService := profile.NewStatService(markStatPort, friendRepo, logger)
-	profileHandler := profilegrpc.NewHandler(profileService, logger)
-
+	profileStatService := profile.NewStatService(markStatPort, commentStatPort, friendRepo, logger)
 	redisCli := getRedisCli(cfg.Redis)
 
 	blockedUserRepo := postgres.NewPgBlockedUserRepository(db, logger)
 	blockedUserService := blockeduser.NewService(blockedUserRepo, profileRepo, logger)
 
 	friendshipService := friendship.NewService(friendRepo, profileRepo, blockedUserRepo, logger)
+	profileHandler := profilegrpc.NewHandler(profileService, friendshipService, logger)
 
 	chatRepo := postgres.NewPgChatRepository(db, logger)
 	chatService := chat.NewService(chatRepo, txManager, logger)
@@ -124,7 +143,9 @@ func NewContainer(cfg *config.Config, db *gorm.DB, logger *zap.Logger) *Containe
 
 		Storage: store,
 
-		ProgressClient: progressClient,
+		ProgressClient:    progressClient,
+		MarkStatClient:    markStatClient,
+		CommentStatClient: commentStatClient,
 
 		Redis:  redisCli,
 		Logger: logger,
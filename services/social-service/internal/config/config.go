@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/clients/progress"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/clients/stats/comment"
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/clients/stats/mark"
 	pkgconfig "github.com/RealTimeMap/RealTimeMap-backend/pkg/config"
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/redis"
@@ -38,6 +39,7 @@ type Config struct {
 	Storage      storage.StorageConfig `yaml:"storage"`
 	Gamification progress.Config       `yaml:"gamification"`
 	MarkStat     mark.Config           `yaml:"mark_stat"`
+	CommentStat  comment.Config        `yaml:"comment_stat"`
 	Redis        redis.Config          `yaml:"redis"`
 }
 
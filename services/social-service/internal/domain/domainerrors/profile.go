@@ -18,6 +18,9 @@ var (
 	MarkServiceUnavailable = func(err error) error {
 		return apperror.NewServiceUnavailableError("mark-service", err)
 	}
+	CommentServiceUnavailable = func(err error) error {
+		return apperror.NewServiceUnavailableError("comment-service", err)
+	}
 	DateValidationErr = func(field, message string, value interface{}) error {
 		return apperror.NewFieldValidationError(field, message, "value_error", value)
 	}
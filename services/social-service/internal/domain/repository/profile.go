@@ -11,6 +11,8 @@ type ProfileRepository interface {
 	GetProfile(ctx context.Context, userID uint) (*model.Profile, error)
 	GetProfiles(ctx context.Context, search string, params pagination.Params) ([]*model.Profile, int64, error)
 	GetProfilesByIDs(ctx context.Context, ids []uint) ([]*model.Profile, error)
+	// GetProfilesByTags профили по тегам без учета регистра, исключая состоящих с viewerID в блокировке
+	GetProfilesByTags(ctx context.Context, tags []string, viewerID uint) ([]*model.Profile, error)
 	Create(ctx context.Context, profile *model.Profile) (*model.Profile, error)
 	Update(ctx context.Context, userID uint, fields map[string]any) (*model.Profile, error)
 	Exist(ctx context.Context, id uint) (bool, error)
@@ -121,6 +121,28 @@ func (s *Service) GetFriendsProfile(ctx context.Context, userID uint, params *Fr
 	return profiles, total, nil
 }
 
+// Relation отношения между двумя пользователями
+type Relation struct {
+	Friends bool
+	Blocked bool // Блокировка в любую сторону
+}
+
+// GetRelation дружба и блокировка между userID и otherID в любом направлении
+func (s *Service) GetRelation(ctx context.Context, userID, otherID uint) (Relation, error) {
+	blocked, err := s.blockedRepo.ExistsBetween(ctx, userID, otherID)
+	if err != nil {
+		return Relation{}, err
+	}
+	relation, err := s.repo.GetRelation(ctx, userID, otherID)
+	if err != nil {
+		return Relation{}, err
+	}
+	return Relation{
+		Friends: relation != nil && relation.Status == model.Accepted,
+		Blocked: blocked,
+	}, nil
+}
+
 func (s *Service) checkProfileExists(ctx context.Context, userID uint) error {
 	_, err := s.profileRepo.GetProfile(ctx, userID)
 	return err
@@ -220,6 +220,15 @@ func (s *Service) GetProfilesByIDs(ctx context.Context, ids []uint) ([]*model.Pr
 	return s.profileRepo.GetProfilesByIDs(ctx, ids)
 }
 
+// GetProfilesByTags профили упомянутых пользователей, видимые viewerID
+func (s *Service) GetProfilesByTags(ctx context.Context, tags []string, viewerID uint) ([]*model.Profile, error) {
+	s.logger.Info("ProfileService.GetProfilesByTags", zap.Int("tags_count", len(tags)), zap.Uint("viewer_id", viewerID))
+	if len(tags) == 0 {
+		return []*model.Profile{}, nil
+	}
+	return s.profileRepo.GetProfilesByTags(ctx, tags, viewerID)
+}
+
 func (s *Service) SearchProfiles(ctx context.Context, input *SearchProfilesInput) ([]*model.Profile, int64, error) {
 	s.logger.Info("ProfileService.SearchProfiles", zap.String("username", input.Username))
 
@@ -4,7 +4,9 @@ import (
 	"context"
 	"time"
 
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/clients/stats/comment"
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/clients/stats/mark"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/date"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/social-service/internal/domain/domainerrors"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/social-service/internal/domain/repository"
 	"go.uber.org/zap"
@@ -17,37 +19,75 @@ type MarkStatGetter interface {
 	GetUserMarksMonthlyActivity(ctx context.Context, userID uint, year int) ([]*mark.MonthlyActivity, error)
 	GetUserMarksHeatMap(ctx context.Context, userID uint, start, end time.Time) ([]*mark.HeatMapItem, error)
 	GetPopularUserCategories(ctx context.Context, userID uint, topN int) ([]*mark.PopularCategory, error)
+	GetUserEngagementStat(ctx context.Context, userID uint, period date.Resolved) (*mark.EngagementStat, error)
+	GetUserCategoryMonthlySeries(ctx context.Context, userID uint, year, topN int) ([]*mark.CategorySeries, error)
+}
+
+type CommentStatGetter interface {
+	GetUserCommentStat(ctx context.Context, userID uint, period date.Resolved) (*comment.CommentStat, error)
+}
+
+// SummaryStat Summary статистика профиля
+type SummaryStat struct {
+	Marks    int64
+	Friends  int64
+	Subs     int64
+	Comments *comment.CommentStat // Комментарии за текущий месяц, nil если comment-service недоступен
 }
 
 type StatService struct {
-	markStat   MarkStatGetter
-	friendRepo repository.FriendShipRepository
-	logger     *zap.Logger
+	markStat    MarkStatGetter
+	commentStat CommentStatGetter
+	friendRepo  repository.FriendShipRepository
+	logger      *zap.Logger
 }
 
-func NewStatService(markStat MarkStatGetter, friendRepo repository.FriendShipRepository, logger *zap.Logger) *StatService {
+func NewStatService(markStat MarkStatGetter, commentStat CommentStatGetter, friendRepo repository.FriendShipRepository, logger *zap.Logger) *StatService {
 	return &StatService{
-		markStat:   markStat,
-		friendRepo: friendRepo,
-		logger:     logger,
+		markStat:    markStat,
+		commentStat: commentStat,
+		friendRepo:  friendRepo,
+		logger:      logger,
 	}
 }
 
 // GetProfileSummaryStat Формирует Summary статистику для отображения в профиле
-func (s *StatService) GetProfileSummaryStat(ctx context.Context, userID uint) (int64, int64, int64, error) {
+func (s *StatService) GetProfileSummaryStat(ctx context.Context, userID uint) (SummaryStat, error) {
 	s.logger.Info("StatService.GetProfileSummaryStat", zap.Uint("user_id", userID))
 
-	marksCount, err := s.markStat.GetUserMarksCount(ctx, userID)
+	var summary SummaryStat
+	var err error
+
+	summary.Marks, err = s.markStat.GetUserMarksCount(ctx, userID)
 	if err != nil {
 		s.logger.Warn("failed to get marks count")
 	}
 
-	friendCount, subsCount, err := s.friendRepo.CountFriendAndSubs(ctx, userID)
+	summary.Friends, summary.Subs, err = s.friendRepo.CountFriendAndSubs(ctx, userID)
 	if err != nil {
 		s.logger.Warn("failed to get marks count")
 	}
 
-	return marksCount, friendCount, subsCount, nil
+	summary.Comments = s.getMonthCommentStat(ctx, userID)
+
+	return summary, nil
+}
+
+// getMonthCommentStat активность в комментариях за текущий месяц. Без comment-service блок не заполняется
+func (s *StatService) getMonthCommentStat(ctx context.Context, userID uint) *comment.CommentStat {
+	if s.commentStat == nil {
+		return nil
+	}
+	period, err := date.Resolve(date.Month, time.Now(), time.UTC)
+	if err != nil {
+		return nil
+	}
+	stat, err := s.commentStat.GetUserCommentStat(ctx, userID, period)
+	if err != nil {
+		s.logger.Warn("failed to get comments stat", zap.Error(err))
+		return nil
+	}
+	return stat
 }
 
 // GetUserMonthlyActivity Формирует данные для предоставления графика активности по месяцам в течении текущего года
@@ -89,6 +129,30 @@ func (s *StatService) GetPopularCategories(ctx context.Context, userID uint) ([]
 	return categories, nil
 }
 
+// GetEngagementStat лайки и переходы по ссылкам, полученные метками пользователя, в сравнении с предыдущим периодом
+func (s *StatService) GetEngagementStat(ctx context.Context, userID uint, period date.Resolved) (*mark.EngagementStat, error) {
+	s.logger.Info("StatService.GetEngagementStat", zap.Uint("user_id", userID))
+
+	stat, err := s.markStat.GetUserEngagementStat(ctx, userID, period)
+	if err != nil {
+		s.logger.Warn("failed to get marks engagement")
+		return nil, err
+	}
+	return stat, nil
+}
+
+// GetCategoryMonthlySeries помесячная активность по популярным категориям в течение текущего года
+func (s *StatService) GetCategoryMonthlySeries(ctx context.Context, userID uint) ([]*mark.CategorySeries, error) {
+	s.logger.Info("StatService.GetCategoryMonthlySeries", zap.Uint("user_id", userID))
+
+	series, err := s.markStat.GetUserCategoryMonthlySeries(ctx, userID, time.Now().Year(), topN)
+	if err != nil {
+		s.logger.Warn("failed to get marks category series")
+		return nil, err
+	}
+	return series, nil
+}
+
 func validateDateRange(start, end time.Time) error {
 	if start.After(end) {
 		return domainerrors.DateValidationErr("start", "must be before end", start)
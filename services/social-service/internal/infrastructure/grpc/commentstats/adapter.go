@@ -0,0 +1,35 @@
+package commentstats
+
+import (
+	"context"
+	"errors"
+
+	pkgcomment "github.com/RealTimeMap/RealTimeMap-backend/pkg/clients/stats/comment"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/date"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/social-service/internal/domain/domainerrors"
+)
+
+type Adapter struct {
+	client *pkgcomment.Client
+}
+
+func NewAdapter(client *pkgcomment.Client) *Adapter {
+	return &Adapter{
+		client: client,
+	}
+}
+
+func (a *Adapter) GetUserCommentStat(ctx context.Context, userID uint, period date.Resolved) (*pkgcomment.CommentStat, error) {
+	result, err := a.client.GetUserCommentStat(ctx, userID, period)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return result, nil
+}
+
+func mapError(err error) error {
+	if errors.Is(err, pkgcomment.ErrServiceUnavailable) {
+		return domainerrors.CommentServiceUnavailable(err)
+	}
+	return err
+}
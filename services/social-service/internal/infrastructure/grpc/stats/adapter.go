@@ -6,6 +6,7 @@ import (
 	"time"
 
 	pkgmark "github.com/RealTimeMap/RealTimeMap-backend/pkg/clients/stats/mark"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/date"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/social-service/internal/domain/domainerrors"
 )
 
@@ -51,6 +52,22 @@ func (a *Adapter) GetPopularUserCategories(ctx context.Context, userID uint, top
 	return result, nil
 }
 
+func (a *Adapter) GetUserEngagementStat(ctx context.Context, userID uint, period date.Resolved) (*pkgmark.EngagementStat, error) {
+	result, err := a.client.GetUserEngagementStat(ctx, userID, period)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return result, nil
+}
+
+func (a *Adapter) GetUserCategoryMonthlySeries(ctx context.Context, userID uint, year, topN int) ([]*pkgmark.CategorySeries, error) {
+	result, err := a.client.GetUserCategoryMonthlySeries(ctx, userID, year, topN)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return result, nil
+}
+
 func mapError(err error) error {
 	if errors.Is(err, pkgmark.ErrServiceUnavailable) {
 		return domainerrors.MarkServiceUnavailable(err)
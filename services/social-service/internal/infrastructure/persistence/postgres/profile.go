@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/pagination"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/social-service/internal/domain/domainerrors"
@@ -77,6 +78,33 @@ func (r *PgProfileRepository) GetProfiles(ctx context.Context, search string, pa
 	return profiles, count, nil
 }
 
+func (r *PgProfileRepository) GetProfilesByTags(ctx context.Context, tags []string, viewerID uint) ([]*model.Profile, error) {
+	r.logger.Info("PgProfileRepository.GetProfilesByTags", zap.Int("tags_count", len(tags)))
+	if len(tags) == 0 {
+		return []*model.Profile{}, nil
+	}
+
+	lowered := make([]string, len(tags))
+	for i, tag := range tags {
+		lowered[i] = strings.ToLower(tag)
+	}
+
+	var profiles []*model.Profile
+	query := r.db.WithContext(ctx).Model(&model.Profile{}).Where("LOWER(tag) IN ?", lowered)
+	if viewerID != 0 {
+		query = query.Where(
+			"NOT EXISTS (SELECT 1 FROM blocked_users b WHERE "+
+				"(b.user_id = ? AND b.blocked_user_id = profiles.user_id) OR "+
+				"(b.user_id = profiles.user_id AND b.blocked_user_id = ?))",
+			viewerID, viewerID,
+		)
+	}
+	if err := query.Find(&profiles).Error; err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
 func (r *PgProfileRepository) Update(ctx context.Context, userID uint, fields map[string]any) (*model.Profile, error) {
 	if len(fields) == 0 {
 		return r.GetProfile(ctx, userID)
@@ -8,6 +8,7 @@ import (
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/apperror"
 	pb "github.com/RealTimeMap/RealTimeMap-backend/pkg/pb/profile"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/social-service/internal/domain/model"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/social-service/internal/domain/service/friendship"
 	"github.com/RealTimeMap/RealTimeMap-backend/services/social-service/internal/domain/service/profile"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
@@ -17,14 +18,16 @@ import (
 type Handler struct {
 	pb.UnimplementedProfileServiceServer
 
-	service *profile.Service
-	logger  *zap.Logger
+	service           *profile.Service
+	friendshipService *friendship.Service
+	logger            *zap.Logger
 }
 
-func NewHandler(service *profile.Service, logger *zap.Logger) *Handler {
+func NewHandler(service *profile.Service, friendshipService *friendship.Service, logger *zap.Logger) *Handler {
 	return &Handler{
-		service: service,
-		logger:  logger,
+		service:           service,
+		friendshipService: friendshipService,
+		logger:            logger,
 	}
 }
 
@@ -60,6 +63,29 @@ func (h *Handler) GetUserProfileByIDs(ctx context.Context, req *pb.MultipleProfi
 	return &pb.MultipleProfileResponse{Profiles: out}, nil
 }
 
+func (h *Handler) GetUserProfilesByTags(ctx context.Context, req *pb.ProfilesByTagsRequest) (*pb.MultipleProfileResponse, error) {
+	profiles, err := h.service.GetProfilesByTags(ctx, req.GetTags(), uint(req.GetViewerId()))
+	if err != nil {
+		h.logger.Error("GetUserProfilesByTags failed", zap.Error(err), zap.Int("tags_count", len(req.GetTags())))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+
+	out := make([]*pb.ProfileResponse, 0, len(profiles))
+	for _, p := range profiles {
+		out = append(out, toResponse(p))
+	}
+	return &pb.MultipleProfileResponse{Profiles: out}, nil
+}
+
+func (h *Handler) GetRelation(ctx context.Context, req *pb.RelationRequest) (*pb.RelationResponse, error) {
+	relation, err := h.friendshipService.GetRelation(ctx, uint(req.GetUserId()), uint(req.GetOtherId()))
+	if err != nil {
+		h.logger.Error("GetRelation failed", zap.Error(err), zap.Uint64("user_id", req.GetUserId()), zap.Uint64("other_id", req.GetOtherId()))
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	return &pb.RelationResponse{Friends: relation.Friends, Blocked: relation.Blocked}, nil
+}
+
 func toResponse(p *model.Profile) *pb.ProfileResponse {
 	fmt.Println(p)
 	return &pb.ProfileResponse{
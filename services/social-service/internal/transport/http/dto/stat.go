@@ -5,20 +5,30 @@ import (
 
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/clients/stats/mark"
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/utils"
+	"github.com/RealTimeMap/RealTimeMap-backend/services/social-service/internal/domain/service/profile"
 )
 
 type SummaryProfileStat struct {
-	MarkCount        string `json:"markCount"`
-	FriendsCount     string `json:"friendsCount"`
-	SubscribersCount string `json:"subscribersCount"`
+	MarkCount        string              `json:"markCount"`
+	FriendsCount     string              `json:"friendsCount"`
+	SubscribersCount string              `json:"subscribersCount"`
+	Comments         *PeriodStatResponse `json:"comments,omitempty"` // Комментарии за текущий месяц
 }
 
-func NewSummaryProfileStat(marks, friends, subs int64) SummaryProfileStat {
-	return SummaryProfileStat{
-		MarkCount:        utils.FormatNumber(marks),
-		FriendsCount:     utils.FormatNumber(friends),
-		SubscribersCount: utils.FormatNumber(subs),
+func NewSummaryProfileStat(data profile.SummaryStat) SummaryProfileStat {
+	res := SummaryProfileStat{
+		MarkCount:        utils.FormatNumber(data.Marks),
+		FriendsCount:     utils.FormatNumber(data.Friends),
+		SubscribersCount: utils.FormatNumber(data.Subs),
 	}
+	if data.Comments != nil {
+		res.Comments = &PeriodStatResponse{
+			Count:     data.Comments.Count,
+			PrevCount: data.Comments.PrevCount,
+			Direction: data.Comments.Direction,
+		}
+	}
+	return res
 }
 
 type MonthActivity struct {
@@ -120,6 +130,57 @@ func NewMultiplePopularCategoryResponse(items []*mark.PopularCategory) []Popular
 	return res
 }
 
+type PeriodStatResponse struct {
+	Count     uint64 `json:"count"`
+	PrevCount uint64 `json:"prevCount"`
+	Direction string `json:"direction"`
+}
+
+func NewPeriodStatResponse(data mark.PeriodStat) PeriodStatResponse {
+	return PeriodStatResponse{
+		Count:     data.Count,
+		PrevCount: data.PrevCount,
+		Direction: data.Direction,
+	}
+}
+
+// EngagementResponse лайки и переходы по ссылкам, полученные метками пользователя
+type EngagementResponse struct {
+	Likes  PeriodStatResponse `json:"likes"`
+	Shares PeriodStatResponse `json:"shares"`
+}
+
+func NewEngagementResponse(data *mark.EngagementStat) EngagementResponse {
+	if data == nil {
+		return EngagementResponse{}
+	}
+	return EngagementResponse{
+		Likes:  NewPeriodStatResponse(data.Likes),
+		Shares: NewPeriodStatResponse(data.Shares),
+	}
+}
+
+type CategorySeriesResponse struct {
+	CategoryName string          `json:"categoryName"`
+	Total        int64           `json:"total"`
+	Months       []MonthActivity `json:"months"`
+}
+
+func NewMultipleCategorySeriesResponse(items []*mark.CategorySeries) []CategorySeriesResponse {
+	res := make([]CategorySeriesResponse, 0, len(items))
+	for _, item := range items {
+		if item == nil {
+			continue
+		}
+		res = append(res, CategorySeriesResponse{
+			CategoryName: item.CategoryName,
+			Total:        item.Total,
+			Months:       NewMultipleMonthlyActivity(item.Months),
+		})
+	}
+	return res
+}
+
 type DateRangeParam struct {
 	Start time.Time `json:"start" form:"start" query:"start" binding:"required"`
 	End   time.Time `json:"end" form:"end" query:"end"`
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/apperror"
+	"github.com/RealTimeMap/RealTimeMap-backend/pkg/date"
 	errorhandler "github.com/RealTimeMap/RealTimeMap-backend/pkg/middleware/error"
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/transport/http/middleware"
 	"github.com/RealTimeMap/RealTimeMap-backend/pkg/transport/http/middleware/cache"
@@ -62,6 +63,16 @@ func RegisterStatHandler(g *gin.RouterGroup, deps StatDeps) {
 			middleware.Exist(deps.ProfileRepo.Exist, handler.logger, "profileID"),
 			handler.withProfileID(handler.GetPopularUserCategories),
 		)
+		statGroup.GET("/statistics/categories/monthly",
+			cache.Middleware(c, cache.Options{TTL: time.Minute * 5, Prefix: "categories_monthly_cache"}),
+			middleware.Exist(deps.ProfileRepo.Exist, handler.logger, "profileID"),
+			handler.withProfileID(handler.GetCategoryMonthlySeries),
+		)
+		statGroup.GET("/statistics/engagement",
+			cache.Middleware(c, cache.Options{TTL: time.Minute * 5, Prefix: "engagement_cache"}),
+			middleware.Exist(deps.ProfileRepo.Exist, handler.logger, "profileID"),
+			handler.withProfileID(handler.GetEngagementStat),
+		)
 	}
 }
 
@@ -91,12 +102,12 @@ func RegisterStatHandler(g *gin.RouterGroup, deps StatDeps) {
 //}
 
 func (h *StatHandler) GetProfileSummaryStat(c *gin.Context, pID uint) {
-	marks, friends, subs, err := h.service.GetProfileSummaryStat(c.Request.Context(), pID)
+	summary, err := h.service.GetProfileSummaryStat(c.Request.Context(), pID)
 	if err != nil {
 		errorhandler.HandleError(c, err, h.logger)
 		return
 	}
-	res := dto.NewSummaryProfileStat(marks, friends, subs)
+	res := dto.NewSummaryProfileStat(summary)
 	c.JSON(http.StatusOK, res)
 }
 
@@ -135,6 +146,36 @@ func (h *StatHandler) GetPopularUserCategories(c *gin.Context, pID uint) {
 	c.JSON(http.StatusOK, response)
 }
 
+func (h *StatHandler) GetCategoryMonthlySeries(c *gin.Context, pID uint) {
+	result, err := h.service.GetCategoryMonthlySeries(c.Request.Context(), pID)
+	if err != nil {
+		errorhandler.HandleError(c, err, h.logger)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NewMultipleCategorySeriesResponse(result))
+}
+
+// GetEngagementStat лайки и переходы по ссылкам за период (?period=week|month|year|allTime)
+func (h *StatHandler) GetEngagementStat(c *gin.Context, pID uint) {
+	var req date.Query
+	if err := c.ShouldBindQuery(&req); err != nil {
+		validation.AbortWithBindingError(c, err)
+		return
+	}
+	period, err := req.Resolve(time.Now(), time.UTC)
+	if err != nil {
+		errorhandler.HandleError(c, err, h.logger)
+		return
+	}
+
+	result, err := h.service.GetEngagementStat(c.Request.Context(), pID, period)
+	if err != nil {
+		errorhandler.HandleError(c, err, h.logger)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NewEngagementResponse(result))
+}
+
 func parseProfileID(c *gin.Context) (uint, error) {
 	pID, err := strconv.Atoi(c.Param("profileID"))
 	if err != nil {